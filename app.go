@@ -7,13 +7,17 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/Positronikal/MCPManager/internal/core/clients"
 	"github.com/Positronikal/MCPManager/internal/core/config"
 	"github.com/Positronikal/MCPManager/internal/core/dependencies"
 	"github.com/Positronikal/MCPManager/internal/core/discovery"
 	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/core/health"
 	"github.com/Positronikal/MCPManager/internal/core/lifecycle"
 	"github.com/Positronikal/MCPManager/internal/core/monitoring"
+	"github.com/Positronikal/MCPManager/internal/core/scheduler"
 	"github.com/Positronikal/MCPManager/internal/models"
 	"github.com/Positronikal/MCPManager/internal/platform"
 	"github.com/Positronikal/MCPManager/internal/storage"
@@ -23,12 +27,15 @@ import (
 // App struct holds all application services
 type App struct {
 	ctx               context.Context
+	clientRegistry    *clients.Registry
 	discoveryService  *discovery.DiscoveryService
 	lifecycleService  *lifecycle.LifecycleService
 	configService     *config.ConfigService
 	clientEditor      *config.ClientEditor
 	monitoringService *monitoring.MonitoringService
 	metricsCollector  *monitoring.MetricsCollector
+	healthChecker     *health.HealthChecker
+	scheduler         *scheduler.Scheduler
 	dependencyService *dependencies.DependencyService
 	updateChecker     *dependencies.UpdateChecker
 	storageService    storage.StorageService
@@ -72,8 +79,21 @@ func (a *App) startup(ctx context.Context) {
 	a.storageService = storageService
 	slog.Info("Storage service initialized")
 
+	// Load the MCP clients whose config files are discovered, watched and edited
+	clientRegistry, err := clients.LoadRegistry(platform.GetMCPManagerDir())
+	if err != nil {
+		slog.Warn("Failed to load user client definitions, using the built-in clients", "error", err)
+	}
+	a.clientRegistry = clientRegistry
+	slog.Info("Client registry initialized", "clients", len(clientRegistry.Clients()))
+
 	// Initialize core services
-	a.discoveryService = discovery.NewDiscoveryService(pathResolver, a.eventBus)
+	a.discoveryService = discovery.NewDiscoveryServiceWithClients(pathResolver, clientRegistry, a.eventBus)
+	if state, err := a.storageService.LoadState(); err != nil {
+		slog.Warn("Failed to load discovery source overrides", "error", err)
+	} else {
+		a.discoveryService.SetSourceOverrides(state.DiscoverySources)
+	}
 	slog.Info("Discovery service initialized")
 
 	// Initialize monitoring service (needed by lifecycle for log capture)
@@ -82,6 +102,26 @@ func (a *App) startup(ctx context.Context) {
 
 	// Initialize lifecycle service with discovery and monitoring dependencies
 	a.lifecycleService = lifecycle.NewLifecycleService(processManager, a.discoveryService, a.monitoringService, a.eventBus)
+	a.lifecycleService.SetJournal(storageService)
+
+	// Servers write their output to files rather than pipes, so that the detach shutdown
+	// policy can leave them running
+	a.lifecycleService.SetOutputDir(filepath.Join(platform.GetMCPManagerDir(), "output"))
+
+	// Place launched servers in their own cgroup where the platform allows it;
+	// otherwise they run without one and configured limits are reported as not applied
+	cgroups := platform.NewCgroupManager()
+	a.lifecycleService.SetResourceLimiter(cgroups)
+	if available, reason := cgroups.Available(); available {
+		slog.Info("Resource limits enabled (cgroup v2)")
+	} else {
+		slog.Info("Resource limits unavailable, servers run without cgroups", "reason", reason)
+	}
+	if available, reason := platform.SandboxSupport(); available {
+		slog.Info("Sandboxed launches enabled (namespaces and Landlock)")
+	} else {
+		slog.Info("Sandboxed launches unavailable, servers with a sandbox profile will not start", "reason", reason)
+	}
 	slog.Info("Lifecycle service initialized")
 
 	configService, err := config.NewConfigService(a.eventBus)
@@ -97,12 +137,22 @@ func (a *App) startup(ctx context.Context) {
 	a.configService = configService
 	slog.Info("Config service initialized")
 
-	a.clientEditor = config.NewClientEditor()
+	a.clientEditor = config.NewClientEditor(clientRegistry)
 	slog.Info("Client editor initialized")
 
 	a.metricsCollector = monitoring.NewMetricsCollector(processInfo, a.eventBus)
 	slog.Info("Metrics collector initialized")
 
+	// Initialize health checker (restarts unhealthy servers through lifecycle)
+	a.healthChecker = health.NewHealthChecker(a.discoveryService, a.lifecycleService, a.eventBus)
+	a.healthChecker.Start()
+	slog.Info("Health checker initialized")
+
+	// Initialize scheduler (runs the schedule rules in the application state)
+	a.scheduler = scheduler.NewScheduler(a.lifecycleService, a.storageService, a.eventBus)
+	a.scheduler.Start()
+	slog.Info("Scheduler initialized")
+
 	a.dependencyService = dependencies.NewDependencyService()
 	slog.Info("Dependency service initialized")
 
@@ -118,6 +168,83 @@ func (a *App) startup(ctx context.Context) {
 		slog.Info("Initial discovery complete", "servers_found", len(servers))
 		// Emit initial servers to frontend
 		runtime.EventsEmit(ctx, "servers:initial", servers)
+
+		// Re-adopt servers left running at the last shutdown before starting anything
+		a.adoptDetachedServers(servers)
+
+		// Start auto-start servers and restore the last session in the background
+		go a.startupServers(servers)
+	}
+}
+
+// startupServers starts the servers selected by the user preferences at launch
+// Outcomes are reported through server:startup:result and startup:completed events
+func (a *App) startupServers(servers []models.MCPServer) {
+	state, err := a.storageService.LoadState()
+	if err != nil {
+		slog.Warn("Failed to load application state, skipping server startup", "error", err)
+		return
+	}
+
+	opts := lifecycle.StartupOptions{AutoStart: state.Preferences.AutoStartServers}
+	if state.Preferences.RestoreSession {
+		opts.Restore = state.LastRunningServers
+	}
+	if !opts.AutoStart && len(opts.Restore) == 0 {
+		return
+	}
+
+	a.lifecycleService.StartupServers(servers, opts)
+}
+
+// adoptDetachedServers re-attaches monitoring and metrics to servers detached at the last shutdown
+// The PID registry is cleared afterwards; servers that could not be adopted are left alone
+func (a *App) adoptDetachedServers(servers []models.MCPServer) {
+	detached, err := a.storageService.LoadDetachedServers()
+	if err != nil {
+		slog.Warn("Failed to load PID registry", "error", err)
+		return
+	}
+	if len(detached) == 0 {
+		return
+	}
+
+	adopted := a.lifecycleService.AdoptServers(servers, detached)
+	for _, serverID := range adopted {
+		if server, exists := a.discoveryService.GetServerByID(serverID); exists && server.PID != nil {
+			a.metricsCollector.UpdatePID(serverID, *server.PID, server.Status.LastStateChange)
+		}
+	}
+	slog.Info("Re-adopted detached servers", "detached", len(detached), "adopted", len(adopted))
+
+	if err := a.storageService.SaveDetachedServers(nil); err != nil {
+		slog.Warn("Failed to clear PID registry", "error", err)
+	}
+}
+
+// shutdownServers records the running servers for session restore and applies the shutdown policy
+// Detached servers are written to the PID registry so the next launch can re-adopt them
+func (a *App) shutdownServers() {
+	state, err := a.storageService.LoadState()
+	if err != nil {
+		slog.Warn("Failed to load application state, stopping managed servers", "error", err)
+		state = models.NewApplicationState()
+	} else {
+		state.LastRunningServers = a.lifecycleService.ManagedServerIDs()
+		if err := a.storageService.SaveState(state); err != nil {
+			slog.Warn("Failed to save running servers", "error", err)
+		}
+	}
+
+	policy := state.Preferences.ShutdownPolicy
+	if policy == "" {
+		policy = models.ShutdownStop
+	}
+	slog.Info("Applying shutdown policy", "policy", policy)
+
+	detached := a.lifecycleService.Shutdown(policy)
+	if err := a.storageService.SaveDetachedServers(detached); err != nil {
+		slog.Warn("Failed to write PID registry", "error", err)
 	}
 }
 
@@ -125,9 +252,24 @@ func (a *App) startup(ctx context.Context) {
 func (a *App) shutdown(ctx context.Context) {
 	slog.Info("Shutting down MCP Manager...")
 
-	// Stop lifecycle service (gracefully stop managed servers)
+	// Stop health checks and scheduled actions before servers go away
+	if a.healthChecker != nil {
+		slog.Info("Stopping health checker...")
+		a.healthChecker.Stop()
+	}
+	if a.scheduler != nil {
+		slog.Info("Stopping scheduler...")
+		a.scheduler.Stop()
+	}
+
+	// Stop or detach managed servers according to the shutdown policy
+	if a.lifecycleService != nil && a.storageService != nil {
+		slog.Info("Shutting down managed servers...")
+		a.shutdownServers()
+	}
+
+	// Stop lifecycle service (monitors, PID validator, pending crash restarts)
 	if a.lifecycleService != nil {
-		slog.Info("Stopping managed servers...")
 		a.lifecycleService.StopAll()
 	}
 
@@ -183,6 +325,76 @@ func (a *App) subscribeToEvents() {
 		}
 	}()
 
+	// Restart scheduled event (crash supervisor countdown)
+	restartScheduledCh := a.eventBus.Subscribe(events.EventServerRestartScheduled)
+	go func() {
+		for event := range restartScheduledCh {
+			runtime.EventsEmit(a.ctx, "server:restart:scheduled", event.Data)
+		}
+	}()
+
+	// Server quarantined event (crash loop detected)
+	quarantinedCh := a.eventBus.Subscribe(events.EventServerQuarantined)
+	go func() {
+		for event := range quarantinedCh {
+			runtime.EventsEmit(a.ctx, "server:quarantined", event.Data)
+		}
+	}()
+
+	// Server health updated event
+	serverHealthCh := a.eventBus.Subscribe(events.EventServerHealthUpdated)
+	go func() {
+		for event := range serverHealthCh {
+			runtime.EventsEmit(a.ctx, "server:health:updated", event.Data)
+		}
+	}()
+
+	// Startup outcome events (auto-start and session restore)
+	startupResultCh := a.eventBus.Subscribe(events.EventServerStartupResult)
+	go func() {
+		for event := range startupResultCh {
+			runtime.EventsEmit(a.ctx, "server:startup:result", event.Data)
+		}
+	}()
+
+	startupCompletedCh := a.eventBus.Subscribe(events.EventStartupCompleted)
+	go func() {
+		for event := range startupCompletedCh {
+			runtime.EventsEmit(a.ctx, "startup:completed", event.Data)
+		}
+	}()
+
+	// Batch operation progress events
+	batchProgressCh := a.eventBus.Subscribe(events.EventBatchProgress)
+	go func() {
+		for event := range batchProgressCh {
+			runtime.EventsEmit(a.ctx, "batch:progress", event.Data)
+		}
+	}()
+
+	batchCompletedCh := a.eventBus.Subscribe(events.EventBatchCompleted)
+	go func() {
+		for event := range batchCompletedCh {
+			runtime.EventsEmit(a.ctx, "batch:completed", event.Data)
+		}
+	}()
+
+	// Missed schedule runs
+	scheduleMissedCh := a.eventBus.Subscribe(events.EventScheduleMissed)
+	go func() {
+		for event := range scheduleMissedCh {
+			runtime.EventsEmit(a.ctx, "schedule:missed", event.Data)
+		}
+	}()
+
+	// Discovery completed, with the scan's report
+	discoveryCompletedCh := a.eventBus.Subscribe(events.EventDiscoveryCompleted)
+	go func() {
+		for event := range discoveryCompletedCh {
+			runtime.EventsEmit(a.ctx, "discovery:completed", event.Data)
+		}
+	}()
+
 	// Config file changed event
 	configChangedCh := a.eventBus.Subscribe(events.EventConfigFileChanged)
 	go func() {
@@ -227,14 +439,15 @@ func (a *App) ListServers() (*ListServersResponse, error) {
 
 // DiscoverServersResponse represents the response from DiscoverServers
 type DiscoverServersResponse struct {
-	Message string `json:"message"`
-	ScanID  string `json:"scanId"`
+	Message string                     `json:"message"`
+	ScanID  string                     `json:"scanId"`
+	Report  *discovery.DiscoveryReport `json:"report"` // What each source examined, failed to parse or skipped
 }
 
 // DiscoverServers triggers a new server discovery scan
 func (a *App) DiscoverServers() (*DiscoverServersResponse, error) {
 	slog.Info("DiscoverServers called")
-	servers, err := a.discoveryService.Discover()
+	servers, report, err := a.discoveryService.DiscoverWithReport("")
 	if err != nil {
 		return nil, fmt.Errorf("discovery failed: %w", err)
 	}
@@ -242,13 +455,57 @@ func (a *App) DiscoverServers() (*DiscoverServersResponse, error) {
 	// Emit discovered servers to frontend
 	runtime.EventsEmit(a.ctx, "servers:discovered", servers)
 
-	_, lastDiscovery, _ := a.discoveryService.GetServers()
+	message := fmt.Sprintf("Discovery complete. Found %d servers.", len(servers))
+	if failed := report.Errors(); failed > 0 {
+		message += fmt.Sprintf(" %d config errors.", failed)
+	}
 	return &DiscoverServersResponse{
-		Message: fmt.Sprintf("Discovery complete. Found %d servers.", len(servers)),
-		ScanID:  "scan-" + lastDiscovery.Format("20060102-150405"),
+		Message: message,
+		ScanID:  report.ScanID,
+		Report:  report,
 	}, nil
 }
 
+// GetDiscoverySources lists the discovery sources, highest priority first
+func (a *App) GetDiscoverySources() []discovery.SourceInfo {
+	slog.Info("GetDiscoverySources called")
+	return a.discoveryService.Sources()
+}
+
+// UpdateDiscoverySource enables or disables a discovery source or changes its priority
+// Nil fields restore the source's default. The override is saved in the application state
+// and applies from the next discovery
+func (a *App) UpdateDiscoverySource(name string, override models.DiscoverySourceOverride) ([]discovery.SourceInfo, error) {
+	slog.Info("UpdateDiscoverySource called", "source", name)
+
+	known := false
+	for _, source := range a.discoveryService.Sources() {
+		known = known || source.Name == name
+	}
+	if !known {
+		return nil, fmt.Errorf("discovery source not found: %s", name)
+	}
+
+	state, err := a.storageService.LoadState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load application state: %w", err)
+	}
+	if override.Enabled == nil && override.Priority == nil {
+		delete(state.DiscoverySources, name)
+	} else {
+		if state.DiscoverySources == nil {
+			state.DiscoverySources = make(map[string]models.DiscoverySourceOverride)
+		}
+		state.DiscoverySources[name] = override
+	}
+	if err := a.storageService.SaveState(state); err != nil {
+		return nil, fmt.Errorf("failed to save application state: %w", err)
+	}
+
+	a.discoveryService.SetSourceOverrides(state.DiscoverySources)
+	return a.discoveryService.Sources(), nil
+}
+
 // GetServer returns a specific server by ID
 func (a *App) GetServer(serverID string) (*models.MCPServer, error) {
 	slog.Info("GetServer called", "serverId", serverID)
@@ -280,12 +537,7 @@ func (a *App) StartServer(serverID string) (*ServerOperationResponse, error) {
 		return nil, fmt.Errorf("server not found: %s", serverID)
 	}
 
-	// Check transport type (Option D: stdio servers require client configuration)
-	if server.Transport == models.TransportStdio {
-		return nil, fmt.Errorf("stdio_requires_client: This server uses stdio transport and must be started through an MCP client (e.g., Claude Desktop). Use the configuration editor to add it to your client's config")
-	}
-
-	// Start standalone servers directly (http/sse/unknown transports)
+	// Start the server directly; stdio servers get a stdin pipe owned by MCP Manager
 	if err := a.lifecycleService.StartServer(server); err != nil {
 		return nil, fmt.Errorf("failed to start server: %w", err)
 	}
@@ -328,12 +580,7 @@ func (a *App) RestartServer(serverID string) (*ServerOperationResponse, error) {
 		return nil, fmt.Errorf("server not found: %s", serverID)
 	}
 
-	// Check transport type (stdio servers must be restarted through their client)
-	if server.Transport == models.TransportStdio {
-		return nil, fmt.Errorf("stdio_requires_client: This server uses stdio transport and must be restarted through an MCP client (e.g., Claude Desktop)")
-	}
-
-	// Restart the server
+	// Restart the server (stdio servers only if MCP Manager launched them)
 	if err := a.lifecycleService.RestartServer(server); err != nil {
 		return nil, fmt.Errorf("failed to restart server: %w", err)
 	}
@@ -345,6 +592,96 @@ func (a *App) RestartServer(serverID string) (*ServerOperationResponse, error) {
 	}, nil
 }
 
+// BatchOperation starts, stops or restarts several servers at once
+// action is "start", "stop" or "restart"; pass either serverIDs or a selector ("running"
+// or "error"). Returns once every server has an outcome; batch:progress events report
+// each server as it finishes
+func (a *App) BatchOperation(action string, serverIDs []string, selector string) (*lifecycle.BatchResult, error) {
+	slog.Info("BatchOperation called", "action", action, "count", len(serverIDs), "selector", selector)
+
+	result, err := a.lifecycleService.RunBatch(lifecycle.BatchRequest{
+		Action:    lifecycle.BatchAction(action),
+		ServerIDs: serverIDs,
+		Selector:  lifecycle.BatchSelector(selector),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run batch operation: %w", err)
+	}
+
+	return result, nil
+}
+
+// StartGroup starts the servers of a named group in dependency order
+// Servers a member requires are started first, even if they are not in the group
+func (a *App) StartGroup(name string) (*lifecycle.BatchResult, error) {
+	slog.Info("StartGroup called", "group", name)
+
+	state, err := a.storageService.LoadState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load application state: %w", err)
+	}
+	group, exists := state.FindGroup(name)
+	if !exists {
+		return nil, fmt.Errorf("group not found: %s", name)
+	}
+
+	result, err := a.lifecycleService.StartGroup(*group, state.Requires)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start group: %w", err)
+	}
+	return result, nil
+}
+
+// StopGroup stops the servers of a named group, dependents before their requirements
+func (a *App) StopGroup(name string, force bool) (*lifecycle.BatchResult, error) {
+	slog.Info("StopGroup called", "group", name, "force", force)
+
+	state, err := a.storageService.LoadState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load application state: %w", err)
+	}
+	group, exists := state.FindGroup(name)
+	if !exists {
+		return nil, fmt.Errorf("group not found: %s", name)
+	}
+
+	result, err := a.lifecycleService.StopGroup(*group, state.Requires, force)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop group: %w", err)
+	}
+	return result, nil
+}
+
+// GetLaunchCommand returns a shell command that launches a server exactly as StartServer would
+// ("copy as shell command"), with its full environment, so a failure can be reproduced by hand
+func (a *App) GetLaunchCommand(serverID string) (string, error) {
+	slog.Info("GetLaunchCommand called", "serverId", serverID)
+
+	server, exists := a.discoveryService.GetServerByID(serverID)
+	if !exists {
+		return "", fmt.Errorf("server not found: %s", serverID)
+	}
+
+	command, err := a.lifecycleService.LaunchCommand(server)
+	if err != nil {
+		return "", fmt.Errorf("failed to build launch command: %w", err)
+	}
+	return command, nil
+}
+
+// GetLaunchEmulationPresets returns the launch profiles of common MCP clients, keyed by client ID
+// A preset is applied by copying it into a server configuration's emulation setting
+func (a *App) GetLaunchEmulationPresets() map[string]models.LaunchEmulation {
+	return models.LaunchEmulationPresets
+}
+
+// GetSchedules returns every schedule rule with its next run and the outcome of its last run
+// Rules are edited through the application state
+func (a *App) GetSchedules() ([]scheduler.RuleStatus, error) {
+	slog.Info("GetSchedules called")
+	return a.scheduler.Status()
+}
+
 // GetServerStatus returns the current status of a server
 func (a *App) GetServerStatus(serverID string) (*models.ServerStatus, error) {
 	slog.Info("GetServerStatus called", "serverId", serverID)
@@ -391,6 +728,9 @@ func (a *App) UpdateConfiguration(serverID string, newConfig *models.ServerConfi
 		return nil, fmt.Errorf("failed to update configuration: %w", err)
 	}
 
+	// Update server's configuration in cache (runtime state is left to the lifecycle service)
+	a.discoveryService.UpdateServerConfiguration(server.ID, *newConfig)
+
 	return newConfig, nil
 }
 
@@ -498,6 +838,65 @@ func (a *App) GetMetrics(serverID string) (*models.ServerMetrics, error) {
 	return metrics, nil
 }
 
+// GetServerHealth returns the latest active health check result for a server
+func (a *App) GetServerHealth(serverID string) (*models.HealthStatus, error) {
+	slog.Info("GetServerHealth called", "serverId", serverID)
+
+	server, exists := a.discoveryService.GetServerByID(serverID)
+	if !exists {
+		return nil, fmt.Errorf("server not found: %s", serverID)
+	}
+
+	if status, ok := a.healthChecker.GetHealth(server.ID); ok {
+		return status, nil
+	}
+
+	return models.NewHealthStatus(server.ID, server.HealthCheckURL()), nil
+}
+
+// GetServerHistory returns the journaled state transitions of a server, oldest first
+// limit keeps only the most recent transitions; 0 returns the full history
+func (a *App) GetServerHistory(serverID string, limit int) ([]models.StateTransition, error) {
+	slog.Info("GetServerHistory called", "serverId", serverID, "limit", limit)
+
+	server, exists := a.discoveryService.GetServerByID(serverID)
+	if !exists {
+		return nil, fmt.Errorf("server not found: %s", serverID)
+	}
+
+	transitions, err := a.storageService.LoadTransitions(server.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server history: %w", err)
+	}
+
+	if limit > 0 && len(transitions) > limit {
+		transitions = transitions[len(transitions)-limit:]
+	}
+	return transitions, nil
+}
+
+// GetServerAvailability returns uptime, MTBF and crashes per day over the last days
+func (a *App) GetServerAvailability(serverID string, days int) (*models.AvailabilityReport, error) {
+	slog.Info("GetServerAvailability called", "serverId", serverID, "days", days)
+
+	if days < 1 || days > 365 {
+		return nil, fmt.Errorf("days must be between 1 and 365, got %d", days)
+	}
+
+	server, exists := a.discoveryService.GetServerByID(serverID)
+	if !exists {
+		return nil, fmt.Errorf("server not found: %s", serverID)
+	}
+
+	transitions, err := a.storageService.LoadTransitions(server.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server history: %w", err)
+	}
+
+	to := time.Now()
+	return models.ComputeAvailability(server.ID, transitions, to.AddDate(0, 0, -days), to), nil
+}
+
 // ========================================
 // Dependency Methods
 // ========================================
@@ -634,6 +1033,7 @@ func (a *App) UpdateApplicationState(state *models.ApplicationState) (*UpdateApp
 	if err := a.storageService.SaveState(state); err != nil {
 		return nil, fmt.Errorf("failed to save application state: %w", err)
 	}
+	a.discoveryService.SetSourceOverrides(state.DiscoverySources)
 
 	return &UpdateApplicationStateResponse{
 		Message: "Application state updated successfully",
@@ -650,12 +1050,26 @@ func (a *App) DetectClients() ([]config.ClientInfo, error) {
 	return a.clientEditor.DetectClients()
 }
 
+// GetClientDefinitions returns the known MCP clients: the built-in ones and those added in
+// ~/.mcpmanager/clients.json
+func (a *App) GetClientDefinitions() []models.ClientDefinition {
+	slog.Info("GetClientDefinitions called")
+	return a.clientRegistry.Clients()
+}
+
 // ReadClientConfig reads and parses an MCP client configuration file
 func (a *App) ReadClientConfig(configPath string) (*config.ClientConfig, error) {
 	slog.Info("ReadClientConfig called", "configPath", configPath)
 	return a.clientEditor.ReadConfig(configPath)
 }
 
+// ReadClientConfigSection reads the servers held by a section of an MCP client configuration
+// file, such as the configSection of a discovered server
+func (a *App) ReadClientConfigSection(configPath string, section []string) (*config.ClientConfig, error) {
+	slog.Info("ReadClientConfigSection called", "configPath", configPath, "section", section)
+	return a.clientEditor.ReadConfigSection(configPath, section)
+}
+
 // WriteClientConfig writes an updated configuration to the client config file
 func (a *App) WriteClientConfig(configPath string, config *config.ClientConfig) error {
 	slog.Info("WriteClientConfig called", "configPath", configPath)
@@ -95,9 +95,8 @@ func (h *ConfigHandlers) UpdateConfiguration(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Update server's configuration in cache
-	server.Configuration = config
-	h.discoveryService.UpdateServer(server)
+	// Update server's configuration in cache (runtime state is left to the lifecycle service)
+	h.discoveryService.UpdateServerConfiguration(server.ID, config)
 
 	// Return updated configuration
 	respondJSON(w, http.StatusOK, &config)
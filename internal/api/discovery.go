@@ -91,9 +91,9 @@ func (h *DiscoveryHandlers) DiscoverServers(w http.ResponseWriter, r *http.Reque
 	// Generate scan ID
 	scanID := uuid.New().String()
 
-	// Trigger discovery in goroutine (non-blocking)
+	// Trigger discovery in goroutine (non-blocking); its report carries the scan ID
 	go func() {
-		_, _ = h.discoveryService.Discover()
+		_, _, _ = h.discoveryService.DiscoverWithReport(scanID)
 	}()
 
 	// Return 202 Accepted immediately
@@ -105,6 +105,18 @@ func (h *DiscoveryHandlers) DiscoverServers(w http.ResponseWriter, r *http.Reque
 	respondJSON(w, http.StatusAccepted, response)
 }
 
+// GetDiscoveryReport handles GET /api/v1/servers/discover
+// Returns the report of the most recent discovery scan
+func (h *DiscoveryHandlers) GetDiscoveryReport(w http.ResponseWriter, r *http.Request) {
+	report := h.discoveryService.LastReport()
+	if report == nil {
+		respondError(w, http.StatusNotFound, "No discovery scan has completed yet")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
 // GetServerByID handles GET /api/v1/servers/{serverId}
 // Returns detailed information about a specific server
 func (h *DiscoveryHandlers) GetServerByID(w http.ResponseWriter, r *http.Request) {
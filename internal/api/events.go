@@ -90,6 +90,13 @@ func (h *SSEHandlers) SSEStream(w http.ResponseWriter, r *http.Request) {
 		events.EventServerLogEntry,
 		events.EventConfigFileChanged,
 		events.EventServerMetricsUpdated,
+		events.EventServerRestartScheduled,
+		events.EventServerQuarantined,
+		events.EventServerHealthUpdated,
+		events.EventBatchProgress,
+		events.EventBatchCompleted,
+		events.EventScheduleMissed,
+		events.EventDiscoveryCompleted,
 	}
 
 	// Create a combined channel for all events
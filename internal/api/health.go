@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Positronikal/MCPManager/internal/core/discovery"
+	"github.com/Positronikal/MCPManager/internal/core/health"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// HealthHandlers contains HTTP handlers for health check endpoints
+type HealthHandlers struct {
+	healthChecker    *health.HealthChecker
+	discoveryService *discovery.DiscoveryService
+}
+
+// NewHealthHandlers creates a new HealthHandlers instance
+func NewHealthHandlers(healthChecker *health.HealthChecker, discoveryService *discovery.DiscoveryService) *HealthHandlers {
+	return &HealthHandlers{
+		healthChecker:    healthChecker,
+		discoveryService: discoveryService,
+	}
+}
+
+// GetServerHealth handles GET /api/v1/servers/{serverId}/health
+// Returns the latest active health check result (state "unknown" if never checked)
+func (h *HealthHandlers) GetServerHealth(w http.ResponseWriter, r *http.Request) {
+	// Extract server ID from URL
+	serverID := chi.URLParam(r, "serverId")
+
+	// Validate UUID format
+	if _, err := uuid.Parse(serverID); err != nil {
+		respondError(w, http.StatusNotFound, "Invalid server ID format")
+		return
+	}
+
+	// Get server from discovery service
+	server, exists := h.discoveryService.GetServerByID(serverID)
+	if !exists {
+		respondError(w, http.StatusNotFound, "Server not found")
+		return
+	}
+
+	if h.healthChecker != nil {
+		if status, ok := h.healthChecker.GetHealth(serverID); ok {
+			respondJSON(w, http.StatusOK, status)
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, models.NewHealthStatus(serverID, server.HealthCheckURL()))
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/discovery"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/storage"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// HistoryHandlers contains HTTP handlers for state transition history endpoints
+type HistoryHandlers struct {
+	storageService   storage.StorageService
+	discoveryService *discovery.DiscoveryService
+}
+
+// NewHistoryHandlers creates a new HistoryHandlers instance
+func NewHistoryHandlers(storageService storage.StorageService, discoveryService *discovery.DiscoveryService) *HistoryHandlers {
+	return &HistoryHandlers{
+		storageService:   storageService,
+		discoveryService: discoveryService,
+	}
+}
+
+// HistoryResponse is the response structure for GET /servers/{serverId}/history
+type HistoryResponse struct {
+	Transitions []models.StateTransition `json:"transitions"`
+	Total       int                      `json:"total"` // Transitions in the requested range before the limit
+}
+
+// GetServerHistory handles GET /api/v1/servers/{serverId}/history
+// Optional query parameters: since and until (RFC 3339), limit (most recent N, default 100)
+func (h *HistoryHandlers) GetServerHistory(w http.ResponseWriter, r *http.Request) {
+	serverID, ok := h.validateServer(w, r)
+	if !ok {
+		return
+	}
+
+	since, err := parseTimeParam(r, "since")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid since parameter, expected RFC 3339 timestamp")
+		return
+	}
+	until, err := parseTimeParam(r, "until")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid until parameter, expected RFC 3339 timestamp")
+		return
+	}
+
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
+			limit = parsedLimit
+		}
+	}
+
+	transitions, err := h.storageService.LoadTransitions(serverID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load server history")
+		return
+	}
+
+	transitions = models.TransitionsBetween(transitions, since, until)
+	total := len(transitions)
+	if total > limit {
+		transitions = transitions[total-limit:]
+	}
+
+	respondJSON(w, http.StatusOK, HistoryResponse{
+		Transitions: transitions,
+		Total:       total,
+	})
+}
+
+// GetServerAvailability handles GET /api/v1/servers/{serverId}/availability
+// Optional query parameter: days (window ending now, 1-365, default 7)
+func (h *HistoryHandlers) GetServerAvailability(w http.ResponseWriter, r *http.Request) {
+	serverID, ok := h.validateServer(w, r)
+	if !ok {
+		return
+	}
+
+	days := 7
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsedDays, err := strconv.Atoi(daysStr)
+		if err != nil || parsedDays < 1 || parsedDays > 365 {
+			respondError(w, http.StatusBadRequest, "Invalid days parameter, expected 1-365")
+			return
+		}
+		days = parsedDays
+	}
+
+	transitions, err := h.storageService.LoadTransitions(serverID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load server history")
+		return
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+	respondJSON(w, http.StatusOK, models.ComputeAvailability(serverID, transitions, from, to))
+}
+
+// validateServer extracts the server ID from the URL and checks that the server exists
+func (h *HistoryHandlers) validateServer(w http.ResponseWriter, r *http.Request) (string, bool) {
+	serverID := chi.URLParam(r, "serverId")
+
+	// Validate UUID format
+	if _, err := uuid.Parse(serverID); err != nil {
+		respondError(w, http.StatusNotFound, "Invalid server ID format")
+		return "", false
+	}
+
+	if _, exists := h.discoveryService.GetServerByID(serverID); !exists {
+		respondError(w, http.StatusNotFound, "Server not found")
+		return "", false
+	}
+
+	return serverID, true
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter; absent means zero time
+func parseTimeParam(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
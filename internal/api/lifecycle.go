@@ -75,13 +75,8 @@ func (h *LifecycleHandlers) StartServer(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Start server asynchronously
-	go func() {
-		err := h.lifecycleService.StartServer(server)
-		if err == nil {
-			// Update server in cache
-			h.discoveryService.UpdateServer(server)
-		}
-	}()
+	// The lifecycle service synchronizes the cache itself
+	go h.lifecycleService.StartServer(server)
 
 	// Return 202 Accepted immediately
 	response := StartServerResponse{
@@ -129,13 +124,8 @@ func (h *LifecycleHandlers) StopServer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Stop server asynchronously
-	go func() {
-		err := h.lifecycleService.StopServer(server, req.Force, req.Timeout)
-		if err == nil {
-			// Update server in cache
-			h.discoveryService.UpdateServer(server)
-		}
-	}()
+	// The lifecycle service synchronizes the cache itself
+	go h.lifecycleService.StopServer(server, req.Force, req.Timeout)
 
 	// Return 202 Accepted immediately
 	response := StopServerResponse{
@@ -165,13 +155,8 @@ func (h *LifecycleHandlers) RestartServer(w http.ResponseWriter, r *http.Request
 	}
 
 	// Restart server asynchronously
-	go func() {
-		err := h.lifecycleService.RestartServer(server)
-		if err == nil {
-			// Update server in cache
-			h.discoveryService.UpdateServer(server)
-		}
-	}()
+	// The lifecycle service synchronizes the cache itself
+	go h.lifecycleService.RestartServer(server)
 
 	// Return 202 Accepted immediately
 	response := RestartServerResponse{
@@ -203,3 +188,81 @@ func (h *LifecycleHandlers) GetServerStatus(w http.ResponseWriter, r *http.Reque
 	// Return current server status
 	respondJSON(w, http.StatusOK, server.Status)
 }
+
+// LaunchCommandResponse is the response structure for GET /servers/{serverId}/launch-command
+type LaunchCommandResponse struct {
+	ServerID string `json:"serverId"`
+	Command  string `json:"command"`
+}
+
+// GetLaunchCommand handles GET /api/v1/servers/{serverId}/launch-command
+func (h *LifecycleHandlers) GetLaunchCommand(w http.ResponseWriter, r *http.Request) {
+	// Extract server ID from URL
+	serverID := chi.URLParam(r, "serverId")
+
+	// Validate UUID format
+	if _, err := uuid.Parse(serverID); err != nil {
+		respondError(w, http.StatusNotFound, "Invalid server ID format")
+		return
+	}
+
+	// Get server from discovery service
+	server, exists := h.discoveryService.GetServerByID(serverID)
+	if !exists {
+		respondError(w, http.StatusNotFound, "Server not found")
+		return
+	}
+
+	command, err := h.lifecycleService.LaunchCommand(server)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, LaunchCommandResponse{ServerID: serverID, Command: command})
+}
+
+// BatchOperationRequest is the request structure for POST /servers:batch
+// Pick servers with either serverIds or selector ("running" or "error")
+type BatchOperationRequest struct {
+	Action      string   `json:"action"`
+	ServerIDs   []string `json:"serverIds,omitempty"`
+	Selector    string   `json:"selector,omitempty"`
+	Force       bool     `json:"force,omitempty"`
+	Timeout     int      `json:"timeout,omitempty"`
+	Parallelism int      `json:"parallelism,omitempty"`
+}
+
+// BatchOperation handles POST /api/v1/servers:batch
+// Unlike the single-server endpoints it responds once every server has an outcome;
+// batch.progress events on /api/v1/events report progress while it runs
+func (h *LifecycleHandlers) BatchOperation(w http.ResponseWriter, r *http.Request) {
+	var req BatchOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Validate UUID format
+	for _, serverID := range req.ServerIDs {
+		if _, err := uuid.Parse(serverID); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid server ID format: "+serverID)
+			return
+		}
+	}
+
+	result, err := h.lifecycleService.RunBatch(lifecycle.BatchRequest{
+		Action:      lifecycle.BatchAction(req.Action),
+		ServerIDs:   req.ServerIDs,
+		Selector:    lifecycle.BatchSelector(req.Selector),
+		Force:       req.Force,
+		Timeout:     req.Timeout,
+		Parallelism: req.Parallelism,
+	})
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/Positronikal/MCPManager/internal/core/dependencies"
 	"github.com/Positronikal/MCPManager/internal/core/discovery"
 	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/core/health"
 	"github.com/Positronikal/MCPManager/internal/core/lifecycle"
 	"github.com/Positronikal/MCPManager/internal/core/monitoring"
 	"github.com/Positronikal/MCPManager/internal/storage"
@@ -18,6 +19,7 @@ type Services struct {
 	ConfigService     *config.ConfigService
 	MonitoringService *monitoring.MonitoringService
 	MetricsCollector  *monitoring.MetricsCollector
+	HealthChecker     *health.HealthChecker
 	DependencyService *dependencies.DependencyService
 	UpdateChecker     *dependencies.UpdateChecker
 	StorageService    storage.StorageService
@@ -38,7 +40,9 @@ func NewRouter(services *Services) *chi.Mux {
 	lifecycleHandlers := NewLifecycleHandlers(services.LifecycleService, services.DiscoveryService)
 	configHandlers := NewConfigHandlers(services.ConfigService, services.DiscoveryService)
 	monitoringHandlers := NewMonitoringHandlers(services.MonitoringService, services.MetricsCollector, services.DiscoveryService)
+	healthHandlers := NewHealthHandlers(services.HealthChecker, services.DiscoveryService)
 	dependencyHandlers := NewDependencyHandlers(services.DependencyService, services.UpdateChecker, services.DiscoveryService)
+	historyHandlers := NewHistoryHandlers(services.StorageService, services.DiscoveryService)
 	appStateHandlers := NewAppStateHandlers(services.StorageService)
 	sseHandlers := NewSSEHandlers(services.EventBus)
 
@@ -47,6 +51,7 @@ func NewRouter(services *Services) *chi.Mux {
 		// Discovery endpoints
 		r.Get("/servers", discoveryHandlers.ListServers)
 		r.Post("/servers/discover", discoveryHandlers.DiscoverServers)
+		r.Get("/servers/discover", discoveryHandlers.GetDiscoveryReport)
 		r.Get("/servers/{serverId}", discoveryHandlers.GetServerByID)
 
 		// Lifecycle endpoints
@@ -54,6 +59,8 @@ func NewRouter(services *Services) *chi.Mux {
 		r.Post("/servers/{serverId}/stop", lifecycleHandlers.StopServer)
 		r.Post("/servers/{serverId}/restart", lifecycleHandlers.RestartServer)
 		r.Get("/servers/{serverId}/status", lifecycleHandlers.GetServerStatus)
+		r.Get("/servers/{serverId}/launch-command", lifecycleHandlers.GetLaunchCommand)
+		r.Post("/servers:batch", lifecycleHandlers.BatchOperation)
 
 		// Configuration endpoints
 		r.Get("/servers/{serverId}/configuration", configHandlers.GetConfiguration)
@@ -63,6 +70,9 @@ func NewRouter(services *Services) *chi.Mux {
 		r.Get("/servers/{serverId}/logs", monitoringHandlers.GetServerLogs)
 		r.Get("/logs", monitoringHandlers.GetAllLogs)
 		r.Get("/servers/{serverId}/metrics", monitoringHandlers.GetServerMetrics)
+		r.Get("/servers/{serverId}/health", healthHandlers.GetServerHealth)
+		r.Get("/servers/{serverId}/history", historyHandlers.GetServerHistory)
+		r.Get("/servers/{serverId}/availability", historyHandlers.GetServerAvailability)
 		r.Get("/netstat", monitoringHandlers.GetNetstat)
 		r.Get("/services", monitoringHandlers.GetServices)
 
@@ -0,0 +1,61 @@
+package clients
+
+// StandardizeJSONC returns a copy of JSON with comments (JSONC), such as VS Code's
+// settings.json, in which comments and trailing commas are replaced by spaces
+// Line endings inside block comments are kept, so the copy is plain JSON with every value
+// at the same byte offset, line and column as in data: decoding errors locate the problem
+// in data, and edits located in the copy can be applied to data, keeping its comments
+func StandardizeJSONC(data []byte) []byte {
+	out := append([]byte(nil), data...)
+	blank := func(from, to int) {
+		for i := from; i < to; i++ {
+			if out[i] != '\n' && out[i] != '\r' {
+				out[i] = ' '
+			}
+		}
+	}
+
+	lastComma := -1 // Offset of a comma not yet followed by a value
+	for i := 0; i < len(out); i++ {
+		switch c := out[i]; {
+		case c == '"':
+			// Skip the string, minding escapes
+			for i++; i < len(out) && out[i] != '"'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+			lastComma = -1
+
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			end := i
+			for end < len(out) && out[end] != '\n' {
+				end++
+			}
+			blank(i, end)
+			i = end - 1
+
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := i + 2
+			for end < len(out) && !(out[end] == '*' && end+1 < len(out) && out[end+1] == '/') {
+				end++
+			}
+			end = min(end+2, len(out))
+			blank(i, end)
+			i = end - 1
+
+		case c == ',':
+			lastComma = i
+
+		case c == '}' || c == ']':
+			if lastComma >= 0 {
+				out[lastComma] = ' '
+			}
+			lastComma = -1
+
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			lastComma = -1
+		}
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestStandardizeJSONC(t *testing.T) {
+	data := `{
+  // Editor settings
+  "editor.fontSize": 14, /* inline */
+  "url": "https://example.com/*not a comment*/",
+  "mcp": {
+    "servers": {
+      "docs": {"command": "npx", "args": ["-y", "docs",],},
+    },
+  },
+}
+`
+	standard := StandardizeJSONC([]byte(data))
+	if len(standard) != len(data) {
+		t.Fatalf("Expected the length to be kept, got %d for %d", len(standard), len(data))
+	}
+
+	var settings struct {
+		URL string `json:"url"`
+		MCP struct {
+			Servers map[string]struct {
+				Args []string `json:"args"`
+			} `json:"servers"`
+		} `json:"mcp"`
+	}
+	if err := json.Unmarshal(standard, &settings); err != nil {
+		t.Fatalf("Expected plain JSON, got %v:\n%s", err, standard)
+	}
+	if settings.URL != "https://example.com/*not a comment*/" {
+		t.Errorf("Expected comment markers inside strings to be kept, got %q", settings.URL)
+	}
+	if args := settings.MCP.Servers["docs"].Args; len(args) != 2 {
+		t.Errorf("Expected the trailing comma to be dropped, got %v", args)
+	}
+}
+
+func TestStandardizeJSONC_KeepsLines(t *testing.T) {
+	data := "{\n  /* one\n     two */\n  \"a\": 1 2\n}"
+	var syntaxErr *json.SyntaxError
+	err := json.Unmarshal(StandardizeJSONC([]byte(data)), &map[string]interface{}{})
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("Expected a syntax error, got %v", err)
+	}
+	// The error offset points into data as well, past the stray value below the comment
+	if data[syntaxErr.Offset-1] != '2' {
+		t.Errorf("Expected the offset to point at the stray value, got %q", data[syntaxErr.Offset-1])
+	}
+}
@@ -0,0 +1,269 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// UserFileName is the file in the MCP Manager directory (~/.mcpmanager) that adds
+// clients to the registry or overrides the built-in ones
+const UserFileName = "clients.json"
+
+// IDs of the built-in clients whose config files are read by discovery sources of their own
+const (
+	ClaudeCodeID = "claude_code"
+	CodexID      = "codex"
+	VSCodeID     = "vscode"
+)
+
+// BuiltinClients are the MCP clients known out of the box
+var BuiltinClients = []models.ClientDefinition{
+	{
+		ID:   "claude_desktop",
+		Name: "Claude Desktop",
+		Paths: map[string][]string{
+			models.ClientPathsDefault: {"${CONFIG}/Claude/claude_desktop_config.json"},
+		},
+		Format:  models.ClientFormatJSON,
+		RootKey: "mcpServers",
+	},
+	{
+		ID:   "cursor",
+		Name: "Cursor",
+		Paths: map[string][]string{
+			models.ClientPathsDefault: {"${HOME}/.cursor/mcp.json"},
+		},
+		Format:      models.ClientFormatJSON,
+		RootKey:     "mcpServers",
+		SupportsURL: true,
+	},
+	{
+		// User-scope servers; per-project servers live in the same file and in .mcp.json files
+		ID:   ClaudeCodeID,
+		Name: "Claude Code",
+		Paths: map[string][]string{
+			models.ClientPathsDefault: {"${HOME}/.claude.json"},
+		},
+		Format:      models.ClientFormatJSON,
+		RootKey:     "mcpServers",
+		SupportsURL: true,
+	},
+	{
+		ID:   CodexID,
+		Name: "Codex CLI",
+		Paths: map[string][]string{
+			models.ClientPathsDefault: {"${HOME}/.codex/config.toml"},
+		},
+		Format:      models.ClientFormatTOML,
+		RootKey:     "mcp_servers",
+		SupportsURL: true,
+	},
+	{
+		// User settings; workspace servers live in .vscode/mcp.json files
+		ID:   VSCodeID,
+		Name: "VS Code",
+		Paths: map[string][]string{
+			models.ClientPathsDefault: {"${CONFIG}/Code/User/settings.json"},
+		},
+		Format:      models.ClientFormatJSONC,
+		RootKey:     "mcp.servers",
+		SupportsURL: true,
+	},
+}
+
+// ProjectFile is a config file a client reads from a project or workspace directory, which
+// is laid out differently from the client's own config files
+type ProjectFile struct {
+	ClientID string
+	Path     string // Relative to the project directory, slash-separated
+	Format   models.ClientFormat
+	RootKey  string
+}
+
+// ProjectFiles are the project config files of the built-in clients
+var ProjectFiles = []ProjectFile{
+	{ClientID: ClaudeCodeID, Path: ".mcp.json", Format: models.ClientFormatJSON, RootKey: "mcpServers"},
+	{ClientID: VSCodeID, Path: ".vscode/mcp.json", Format: models.ClientFormatJSONC, RootKey: "servers"},
+}
+
+// userFile is the layout of the user's clients.json
+type userFile struct {
+	Clients []models.ClientDefinition `json:"clients"`
+}
+
+// ConfigFile is one config file of a client, with its path expanded for this machine
+type ConfigFile struct {
+	Client models.ClientDefinition
+	Path   string
+}
+
+// Registry is the set of MCP clients whose config files are discovered, watched and edited
+// Built-in clients come first, in their fixed order, followed by clients the user added
+type Registry struct {
+	clients []models.ClientDefinition
+}
+
+// NewRegistry creates a registry of the built-in clients
+func NewRegistry() *Registry {
+	registry, _ := newRegistry(nil)
+	return registry
+}
+
+// LoadRegistry creates a registry of the built-in clients and those in dir's clients.json
+// A user client with the ID of a built-in one replaces it, and one with disabled set hides
+// it. A missing file is not an error; on any other error the built-in registry is returned
+// along with the error
+func LoadRegistry(dir string) (*Registry, error) {
+	path := filepath.Join(dir, UserFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewRegistry(), nil
+	}
+	if err != nil {
+		return NewRegistry(), fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var file userFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return NewRegistry(), fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	registry, err := newRegistry(file.Clients)
+	if err != nil {
+		return NewRegistry(), fmt.Errorf("invalid client in %s: %w", path, err)
+	}
+	return registry, nil
+}
+
+// newRegistry merges user client definitions over the built-in ones
+func newRegistry(user []models.ClientDefinition) (*Registry, error) {
+	seen := make(map[string]bool)
+	overrides := make(map[string]models.ClientDefinition)
+	var added []models.ClientDefinition
+	for _, def := range user {
+		if err := def.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[def.ID] {
+			return nil, fmt.Errorf("duplicate client id: %s", def.ID)
+		}
+		seen[def.ID] = true
+
+		if isBuiltin(def.ID) {
+			overrides[def.ID] = def
+		} else {
+			added = append(added, def)
+		}
+	}
+
+	registry := &Registry{}
+	for _, def := range append(append([]models.ClientDefinition{}, BuiltinClients...), added...) {
+		if override, ok := overrides[def.ID]; ok {
+			def = override
+		}
+		if !def.Disabled {
+			registry.clients = append(registry.clients, def)
+		}
+	}
+	return registry, nil
+}
+
+// isBuiltin returns true if id is the ID of a built-in client
+func isBuiltin(id string) bool {
+	for _, def := range BuiltinClients {
+		if def.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Clients returns the client definitions, built-in clients first
+func (r *Registry) Clients() []models.ClientDefinition {
+	return append([]models.ClientDefinition(nil), r.clients...)
+}
+
+// Get returns the client with the given ID
+func (r *Registry) Get(id string) (models.ClientDefinition, bool) {
+	for _, def := range r.clients {
+		if def.ID == id {
+			return def, true
+		}
+	}
+	return models.ClientDefinition{}, false
+}
+
+// ConfigFiles returns every config file of every client on this operating system
+func (r *Registry) ConfigFiles(resolver platform.PathResolver) []ConfigFile {
+	return r.configFiles(resolver, runtime.GOOS)
+}
+
+// configFiles returns every config file of every client on goos
+// Paths whose placeholder cannot be resolved are left out
+func (r *Registry) configFiles(resolver platform.PathResolver, goos string) []ConfigFile {
+	var files []ConfigFile
+	for _, def := range r.clients {
+		for _, path := range def.PathsFor(goos) {
+			if expanded, ok := ExpandPath(path, resolver); ok {
+				files = append(files, ConfigFile{Client: def, Path: expanded})
+			}
+		}
+	}
+	return files
+}
+
+// ClientForPath returns the client that owns a config file
+// A project config file is returned as its client with the file's format and root key
+func (r *Registry) ClientForPath(resolver platform.PathResolver, path string) (models.ClientDefinition, bool) {
+	path = filepath.Clean(path)
+	for _, file := range r.ConfigFiles(resolver) {
+		if file.Path == path {
+			return file.Client, true
+		}
+	}
+	return r.ProjectClient(path)
+}
+
+// ProjectClient returns the client of a project config file, such as <project>/.mcp.json,
+// with the file's format and root key
+func (r *Registry) ProjectClient(path string) (models.ClientDefinition, bool) {
+	slashed := filepath.ToSlash(filepath.Clean(path))
+	for _, file := range ProjectFiles {
+		if !strings.HasSuffix(slashed, "/"+file.Path) {
+			continue
+		}
+		if client, ok := r.Get(file.ClientID); ok {
+			client.Format = file.Format
+			client.RootKey = file.RootKey
+			return client, true
+		}
+	}
+	return models.ClientDefinition{}, false
+}
+
+// ExpandPath replaces the leading placeholder of a client config path
+// Returns false if the placeholder's directory cannot be determined
+func ExpandPath(path string, resolver platform.PathResolver) (string, bool) {
+	dirs := map[string]func() string{
+		"${CONFIG}":  resolver.GetConfigDir,
+		"${APPDATA}": resolver.GetAppDataDir,
+		"${HOME}":    resolver.GetUserHomeDir,
+	}
+	for _, placeholder := range models.ClientPathPlaceholders {
+		if !strings.HasPrefix(path, placeholder) {
+			continue
+		}
+		dir := dirs[placeholder]()
+		if dir == "" {
+			return "", false
+		}
+		return filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(path, placeholder))), true
+	}
+	return filepath.Clean(path), true
+}
@@ -0,0 +1,171 @@
+package clients
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+// mockPathResolver resolves every directory to a subdirectory of root
+type mockPathResolver struct {
+	root string
+}
+
+func (m *mockPathResolver) GetConfigDir() string   { return filepath.Join(m.root, "config") }
+func (m *mockPathResolver) GetAppDataDir() string  { return filepath.Join(m.root, "data") }
+func (m *mockPathResolver) GetUserHomeDir() string { return m.root }
+
+// writeUserFile writes clients.json to a new MCP Manager directory and returns the directory
+func writeUserFile(t *testing.T, content string) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, UserFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// ids returns the IDs of the registry's clients
+func ids(registry *Registry) string {
+	var result []string
+	for _, def := range registry.Clients() {
+		result = append(result, def.ID)
+	}
+	return strings.Join(result, ",")
+}
+
+func TestLoadRegistry_MissingFile(t *testing.T) {
+	registry, err := LoadRegistry(t.TempDir())
+	if err != nil {
+		t.Fatalf("A missing clients.json should not be an error: %v", err)
+	}
+	if got := ids(registry); got != "claude_desktop,cursor,claude_code,codex,vscode" {
+		t.Errorf("Expected the built-in clients, got %s", got)
+	}
+}
+
+func TestLoadRegistry_UserClients(t *testing.T) {
+	dir := writeUserFile(t, `{"clients": [
+		{"id": "editor", "name": "Editor", "paths": {"default": ["${HOME}/.editor/mcp.json"]}, "format": "json", "rootKey": "servers", "supportsUrl": true},
+		{"id": "cursor", "name": "Cursor (beta)", "paths": {"default": ["${HOME}/.cursor-beta/mcp.json"]}, "format": "json", "rootKey": "mcpServers"},
+		{"id": "claude_desktop", "disabled": true}
+	]}`)
+
+	registry, err := LoadRegistry(dir)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	if got := ids(registry); got != "cursor,claude_code,codex,vscode,editor" {
+		t.Errorf("Expected the overridden built-in before the added client, got %s", got)
+	}
+	if cursor, _ := registry.Get("cursor"); cursor.Name != "Cursor (beta)" {
+		t.Errorf("Expected the user definition to replace the built-in, got %+v", cursor)
+	}
+	if _, ok := registry.Get("claude_desktop"); ok {
+		t.Error("Expected the disabled client to be hidden")
+	}
+}
+
+func TestLoadRegistry_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{"syntax error", `{"clients": [}`, "failed to parse"},
+		{"invalid client", `{"clients": [{"id": "editor", "name": "Editor", "format": "json", "rootKey": "servers"}]}`, "at least one config path"},
+		{"duplicate", `{"clients": [{"id": "cursor", "disabled": true}, {"id": "cursor", "disabled": true}]}`, "duplicate client id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := LoadRegistry(writeUserFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Fatalf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+			if got := ids(registry); got != "claude_desktop,cursor,claude_code,codex,vscode" {
+				t.Errorf("Expected to fall back to the built-in clients, got %s", got)
+			}
+		})
+	}
+}
+
+func TestRegistry_ConfigFiles(t *testing.T) {
+	root := t.TempDir()
+	resolver := &mockPathResolver{root: root}
+	registry := NewRegistry()
+
+	files := registry.configFiles(resolver, "linux")
+	if len(files) != 5 {
+		t.Fatalf("Expected 5 config files, got %+v", files)
+	}
+	if want := filepath.Join(root, "config", "Claude", "claude_desktop_config.json"); files[0].Path != want {
+		t.Errorf("Expected %s, got %s", want, files[0].Path)
+	}
+	if want := filepath.Join(root, ".cursor", "mcp.json"); files[1].Path != want || !files[1].Client.SupportsURL {
+		t.Errorf("Expected %s supporting url entries, got %+v", want, files[1])
+	}
+	if want := filepath.Join(root, ".codex", "config.toml"); files[3].Path != want || files[3].Client.Format != models.ClientFormatTOML {
+		t.Errorf("Expected %s in TOML, got %+v", want, files[3])
+	}
+	if want := filepath.Join(root, "config", "Code", "User", "settings.json"); files[4].Path != want || files[4].Client.RootKey != "mcp.servers" {
+		t.Errorf("Expected %s with nested servers, got %+v", want, files[4])
+	}
+
+	client, ok := registry.ClientForPath(resolver, filepath.Join(root, ".cursor", ".", "mcp.json"))
+	if !ok || client.ID != "cursor" {
+		t.Errorf("Expected the path to belong to cursor, got %+v", client)
+	}
+	if _, ok := registry.ClientForPath(resolver, filepath.Join(root, "other.json")); ok {
+		t.Error("Expected an unknown path to belong to no client")
+	}
+}
+
+func TestRegistry_ProjectClient(t *testing.T) {
+	registry := NewRegistry()
+
+	client, ok := registry.ClientForPath(&mockPathResolver{root: t.TempDir()}, filepath.Join("work", "app", ".vscode", "mcp.json"))
+	if !ok || client.ID != VSCodeID || client.RootKey != "servers" || client.Format != models.ClientFormatJSONC {
+		t.Errorf("Expected a VS Code workspace file with top-level servers, got %+v", client)
+	}
+	if client, ok := registry.ProjectClient(filepath.Join("work", "app", ".mcp.json")); !ok || client.ID != ClaudeCodeID {
+		t.Errorf("Expected a Claude Code project file, got %+v", client)
+	}
+	if _, ok := registry.ProjectClient(filepath.Join("work", "app", "mcp.json")); ok {
+		t.Error("Expected a file outside .vscode to belong to no client")
+	}
+
+	registry, err := newRegistry([]models.ClientDefinition{{ID: VSCodeID, Disabled: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := registry.ProjectClient(filepath.Join("app", ".vscode", "mcp.json")); ok {
+		t.Error("Expected the project files of a disabled client to be ignored")
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	resolver := &mockPathResolver{root: "/home/user"}
+
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{"${HOME}/.cursor/mcp.json", "/home/user/.cursor/mcp.json", true},
+		{"${APPDATA}/Editor/mcp.json", "/home/user/data/Editor/mcp.json", true},
+		{"/etc/editor//mcp.json", "/etc/editor/mcp.json", true},
+	}
+	for _, tt := range tests {
+		got, ok := ExpandPath(tt.path, resolver)
+		if ok != tt.ok || got != filepath.FromSlash(tt.want) {
+			t.Errorf("ExpandPath(%q) = %q, %v; want %q, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if _, ok := ExpandPath("${HOME}/.cursor/mcp.json", &mockPathResolver{}); ok {
+		t.Error("Expected an unresolvable home directory to be reported")
+	}
+}
@@ -0,0 +1,1031 @@
+package clients
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TOMLDocument is a parsed TOML file that keeps its comments and layout, so that edits
+// rewrite only the lines they change. Values are string, int64, float64, bool,
+// []interface{} and map[string]interface{}; dates and times are kept as strings
+type TOMLDocument struct {
+	items []tomlItem
+}
+
+// tomlItemKind is the kind of a line of a TOML document
+type tomlItemKind int
+
+const (
+	tomlTrivia   tomlItemKind = iota // Blank or comment-only line
+	tomlHeader                       // [table] or [[array of tables]]
+	tomlKeyValue                     // key = value, possibly spanning several lines
+)
+
+// tomlItem is one line of a TOML document, or several for a multi-line value
+type tomlItem struct {
+	kind    tomlItemKind
+	raw     string      // Source text, including the line ending
+	indent  string      // Whitespace before the key
+	path    []string    // Table path of a header, dotted key of a key/value
+	array   bool        // Header of an array of tables
+	value   interface{} // Value of a key/value
+	comment string      // Text after a key/value's value, e.g. "  # note", without the line ending
+	deleted bool
+}
+
+// TOMLError is a syntax error in a TOML file; Line and Column are 1-based
+type TOMLError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *TOMLError) Error() string {
+	return fmt.Sprintf("toml: line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// TOMLEntry is a key and the value to set it to
+type TOMLEntry struct {
+	Key   string
+	Value interface{}
+}
+
+// tomlParser parses a TOML document line by line
+type tomlParser struct {
+	data string
+	pos  int
+}
+
+// tomlDateTime matches the dates, times and date-times TOML allows as bare values
+var tomlDateTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?([Zz]|[+-]\d{2}:\d{2})?)?|\d{2}:\d{2}:\d{2}(\.\d+)?)$`)
+
+// tomlBareKey matches keys that need no quotes
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ParseTOML parses a TOML document
+// Syntax errors are returned as *TOMLError
+func ParseTOML(data []byte) (*TOMLDocument, error) {
+	p := &tomlParser{data: string(data)}
+	doc := &TOMLDocument{}
+	for p.pos < len(p.data) {
+		item, err := p.parseLine()
+		if err != nil {
+			return nil, err
+		}
+		doc.items = append(doc.items, item)
+	}
+	if err := doc.checkTables(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// errorf returns a TOMLError at the current position
+func (p *tomlParser) errorf(format string, args ...interface{}) error {
+	line, column := 1, 1
+	for _, r := range p.data[:p.pos] {
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return &TOMLError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+// peek returns the byte at the current position, or 0 at the end
+func (p *tomlParser) peek() byte {
+	if p.pos < len(p.data) {
+		return p.data[p.pos]
+	}
+	return 0
+}
+
+// consume skips prefix if the input continues with it
+func (p *tomlParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.data[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+// skipSpace skips spaces and tabs
+func (p *tomlParser) skipSpace() {
+	for p.peek() == ' ' || p.peek() == '\t' {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment up to, not including, the line ending
+func (p *tomlParser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+	for p.pos < len(p.data) && p.data[p.pos] != '\n' && !strings.HasPrefix(p.data[p.pos:], "\r\n") {
+		p.pos++
+	}
+}
+
+// endLine skips trailing whitespace and a comment, then the line ending
+// Returns the skipped text without the line ending
+func (p *tomlParser) endLine() (string, error) {
+	start := p.pos
+	p.skipSpace()
+	p.skipComment()
+	trailing := p.data[start:p.pos]
+	if p.pos < len(p.data) && !p.consume("\n") && !p.consume("\r\n") {
+		return "", p.errorf("expected the end of the line, found %q", p.peek())
+	}
+	return trailing, nil
+}
+
+// parseLine parses the next line, or lines for a multi-line value
+func (p *tomlParser) parseLine() (tomlItem, error) {
+	start := p.pos
+	p.skipSpace()
+	item := tomlItem{indent: p.data[start:p.pos]}
+
+	switch c := p.peek(); {
+	case c == 0 || c == '\n' || c == '\r' || c == '#':
+		item.kind = tomlTrivia
+		if _, err := p.endLine(); err != nil {
+			return item, err
+		}
+
+	case c == '[':
+		item.kind = tomlHeader
+		p.pos++
+		item.array = p.consume("[")
+		p.skipSpace()
+		path, err := p.parseKey()
+		if err != nil {
+			return item, err
+		}
+		item.path = path
+		p.skipSpace()
+		if !p.consume("]") || (item.array && !p.consume("]")) {
+			return item, p.errorf("expected ] to close the table header")
+		}
+		if _, err := p.endLine(); err != nil {
+			return item, err
+		}
+
+	default:
+		item.kind = tomlKeyValue
+		path, err := p.parseKey()
+		if err != nil {
+			return item, err
+		}
+		item.path = path
+		p.skipSpace()
+		if !p.consume("=") {
+			return item, p.errorf("expected = after the key")
+		}
+		p.skipSpace()
+		if item.value, err = p.parseValue(); err != nil {
+			return item, err
+		}
+		if item.comment, err = p.endLine(); err != nil {
+			return item, err
+		}
+	}
+
+	item.raw = p.data[start:p.pos]
+	return item, nil
+}
+
+// parseKey parses a possibly dotted key
+func (p *tomlParser) parseKey() ([]string, error) {
+	var path []string
+	for {
+		p.skipSpace()
+		var part string
+		var err error
+		switch p.peek() {
+		case '"':
+			part, err = p.parseBasicString()
+		case '\'':
+			part, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for p.pos < len(p.data) && tomlBareKey.MatchString(p.data[p.pos:p.pos+1]) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("expected a key")
+			}
+			part = p.data[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, part)
+
+		p.skipSpace()
+		if !p.consume(".") {
+			return path, nil
+		}
+	}
+}
+
+// parseValue parses a value
+func (p *tomlParser) parseValue() (interface{}, error) {
+	rest := p.data[p.pos:]
+	switch {
+	case strings.HasPrefix(rest, `"""`):
+		return p.parseMultilineString(`"""`, true)
+	case strings.HasPrefix(rest, `'''`):
+		return p.parseMultilineString(`'''`, false)
+	case strings.HasPrefix(rest, `"`):
+		return p.parseBasicString()
+	case strings.HasPrefix(rest, `'`):
+		return p.parseLiteralString()
+	case strings.HasPrefix(rest, "["):
+		return p.parseArray()
+	case strings.HasPrefix(rest, "{"):
+		return p.parseInlineTable()
+	}
+	return p.parseScalar()
+}
+
+// parseBasicString parses a "string" with escapes
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		switch c := p.peek(); {
+		case p.pos >= len(p.data) || c == '\n':
+			return "", p.errorf("unterminated string")
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		case c == '\\':
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseLiteralString parses a 'string' without escapes
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	end := strings.IndexAny(p.data[p.pos:], "'\n")
+	if end < 0 || p.data[p.pos+end] != '\'' {
+		return "", p.errorf("unterminated string")
+	}
+	value := p.data[p.pos : p.pos+end]
+	p.pos += end + 1
+	return value, nil
+}
+
+// parseMultilineString parses a multi-line basic or literal string delimited by quotes
+func (p *tomlParser) parseMultilineString(quotes string, escapes bool) (string, error) {
+	p.pos += len(quotes)
+	// A newline right after the opening quotes is not part of the string
+	if !p.consume("\n") {
+		p.consume("\r\n")
+	}
+
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.data) {
+			return "", p.errorf("unterminated multi-line string")
+		}
+		if strings.HasPrefix(p.data[p.pos:], quotes) {
+			// Up to two quotes may directly precede the closing ones
+			n := 0
+			for p.pos+n < len(p.data) && p.data[p.pos+n] == quotes[0] && n < 5 {
+				n++
+			}
+			b.WriteString(p.data[p.pos : p.pos+n-3])
+			p.pos += n
+			return b.String(), nil
+		}
+
+		c := p.data[p.pos]
+		if escapes && c == '\\' {
+			// A backslash at the end of a line trims the line ending and following whitespace
+			rest := strings.TrimLeft(p.data[p.pos+1:], " \t")
+			if strings.HasPrefix(rest, "\n") || strings.HasPrefix(rest, "\r\n") {
+				p.pos = len(p.data) - len(strings.TrimLeft(rest, " \t\r\n"))
+				continue
+			}
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+}
+
+// parseEscape parses an escape sequence in a basic string
+func (p *tomlParser) parseEscape(b *strings.Builder) error {
+	p.pos++
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"':
+		b.WriteByte('"')
+	case '\\':
+		b.WriteByte('\\')
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.pos+n > len(p.data) {
+			return p.errorf("incomplete unicode escape")
+		}
+		code, err := strconv.ParseUint(p.data[p.pos:p.pos+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid unicode escape %q", p.data[p.pos:p.pos+n])
+		}
+		b.WriteRune(rune(code))
+		p.pos += n
+	default:
+		p.pos -= 2
+		return p.errorf("invalid escape sequence")
+	}
+	return nil
+}
+
+// skipArraySpace skips whitespace, line endings and comments inside an array
+func (p *tomlParser) skipArraySpace() {
+	for {
+		p.skipSpace()
+		p.skipComment()
+		if !p.consume("\n") && !p.consume("\r\n") {
+			return
+		}
+	}
+}
+
+// parseArray parses an [array], which may span lines
+func (p *tomlParser) parseArray() (interface{}, error) {
+	p.pos++
+	values := []interface{}{}
+	for {
+		p.skipArraySpace()
+		if p.consume("]") {
+			return values, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		p.skipArraySpace()
+		if p.consume("]") {
+			return values, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ] in the array")
+		}
+	}
+}
+
+// parseInlineTable parses an { inline = "table" }
+func (p *tomlParser) parseInlineTable() (interface{}, error) {
+	p.pos++
+	table := map[string]interface{}{}
+	p.skipSpace()
+	if p.consume("}") {
+		return table, nil
+	}
+	for {
+		path, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume("=") {
+			return nil, p.errorf("expected = after the key")
+		}
+		p.skipSpace()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if !setTOMLPath(table, path, value) {
+			return nil, p.errorf("key %s is defined twice", strings.Join(path, "."))
+		}
+
+		p.skipSpace()
+		if p.consume("}") {
+			return table, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or } in the inline table")
+		}
+		p.skipSpace()
+	}
+}
+
+// parseScalar parses a boolean, number, date or time
+func (p *tomlParser) parseScalar() (interface{}, error) {
+	start := p.pos
+	isScalarByte := func(c byte) bool {
+		return c == '_' || c == '+' || c == '-' || c == '.' || c == ':' ||
+			(c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+	}
+	for p.pos < len(p.data) && isScalarByte(p.data[p.pos]) {
+		p.pos++
+		// A date and time may be separated by a space
+		if p.pos-start == 10 && p.peek() == ' ' && p.pos+1 < len(p.data) && p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' &&
+			tomlDateTime.MatchString(p.data[start:p.pos]) {
+			p.pos++
+		}
+	}
+	token := p.data[start:p.pos]
+
+	switch {
+	case token == "":
+		return nil, p.errorf("expected a value")
+	case token == "true":
+		return true, nil
+	case token == "false":
+		return false, nil
+	case tomlDateTime.MatchString(token):
+		return token, nil
+	}
+
+	digits := strings.ReplaceAll(token, "_", "")
+	base := 10
+	for prefix, prefixBase := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if strings.HasPrefix(digits, prefix) {
+			digits, base = digits[2:], prefixBase
+		}
+	}
+	if value, err := strconv.ParseInt(digits, base, 64); err == nil {
+		return value, nil
+	}
+	if base == 10 {
+		if value, err := strconv.ParseFloat(digits, 64); err == nil {
+			return value, nil
+		}
+	}
+	p.pos = start
+	return nil, p.errorf("invalid value %q", token)
+}
+
+// setTOMLPath sets the value at a dotted key, creating tables on the way
+// Returns false if the key is already defined
+func setTOMLPath(table map[string]interface{}, path []string, value interface{}) bool {
+	for _, key := range path[:len(path)-1] {
+		next, ok := table[key].(map[string]interface{})
+		if !ok {
+			if _, exists := table[key]; exists {
+				return false
+			}
+			next = map[string]interface{}{}
+			table[key] = next
+		}
+		table = next
+	}
+	last := path[len(path)-1]
+	if _, exists := table[last]; exists {
+		return false
+	}
+	table[last] = value
+	return true
+}
+
+// Value returns the document's data as nested maps
+// Later definitions of a key already defined are ignored
+func (d *TOMLDocument) Value() map[string]interface{} {
+	root := map[string]interface{}{}
+	current := root
+	for _, item := range d.items {
+		if item.deleted {
+			continue
+		}
+		switch item.kind {
+		case tomlHeader:
+			table, ok := tomlTableAt(root, item.path, item.array)
+			if !ok {
+				// Conflicting definition; keep the first and collect this one nowhere
+				table = map[string]interface{}{}
+			}
+			current = table
+		case tomlKeyValue:
+			setTOMLPath(current, item.path, item.value)
+		}
+	}
+	return root
+}
+
+// tomlTableAt returns the table a header opens, creating it and its parents
+// An array of tables header appends a new table to the array. Returns false if a key on
+// the path is already defined as a value that is not a table, such as an array
+func tomlTableAt(root map[string]interface{}, path []string, array bool) (map[string]interface{}, bool) {
+	table := root
+	for i, key := range path {
+		last := i == len(path)-1
+		if last && array {
+			tables, ok := table[key].([]interface{})
+			if !ok && table[key] != nil {
+				return nil, false
+			}
+			next := map[string]interface{}{}
+			table[key] = append(tables, next)
+			return next, true
+		}
+
+		switch existing := table[key].(type) {
+		case map[string]interface{}:
+			table = existing
+		case []interface{}:
+			// Headers below an array of tables extend its last table
+			if len(existing) == 0 {
+				return nil, false
+			}
+			next, ok := existing[len(existing)-1].(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			table = next
+		case nil:
+			next := map[string]interface{}{}
+			table[key] = next
+			table = next
+		default:
+			return nil, false
+		}
+	}
+	return table, true
+}
+
+// checkTables returns a TOMLError for the first header whose table is already defined as
+// a value that is not a table, such as mcp_servers = [] before [mcp_servers.docs]
+func (d *TOMLDocument) checkTables() error {
+	root := map[string]interface{}{}
+	current := root
+	line := 1
+	for _, item := range d.items {
+		switch item.kind {
+		case tomlHeader:
+			table, ok := tomlTableAt(root, item.path, item.array)
+			if !ok {
+				return &TOMLError{Line: line, Column: len(item.indent) + 1, Message: fmt.Sprintf("%s is already defined as a value that is not a table", encodeTOMLPath(item.path))}
+			}
+			current = table
+		case tomlKeyValue:
+			setTOMLPath(current, item.path, item.value)
+		}
+		line += strings.Count(item.raw, "\n")
+	}
+	return nil
+}
+
+// Table returns the table at path, if there is one
+func (d *TOMLDocument) Table(path ...string) (map[string]interface{}, bool) {
+	table := d.Value()
+	for _, key := range path {
+		next, ok := table[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		table = next
+	}
+	return table, true
+}
+
+// Bytes returns the document as TOML
+func (d *TOMLDocument) Bytes() []byte {
+	var b strings.Builder
+	for _, item := range d.items {
+		if !item.deleted {
+			b.WriteString(item.raw)
+		}
+	}
+	return []byte(b.String())
+}
+
+// UpdateTable sets and removes keys of the table at path, adding the table at the end of
+// the document if it has no header yet. Lines of keys whose value does not change are left
+// untouched, as are keys named in neither set nor remove. A map value set for a key that
+// has its own [path.key] table replaces the keys of that table
+func (d *TOMLDocument) UpdateTable(path []string, set []TOMLEntry, remove []string) {
+	header := d.findHeader(path)
+	if header < 0 {
+		d.appendTable(path, d.takeImplicitTable(path), set, remove)
+		return
+	}
+
+	values := make(map[string]interface{})
+	for _, entry := range set {
+		values[entry.Key] = normalizeTOMLValue(entry.Value)
+	}
+	removed := make(map[string]bool)
+	for _, key := range remove {
+		removed[key] = true
+	}
+
+	// Update the section's own keys in place
+	done := make(map[string]bool)
+	end := d.sectionEnd(header)
+	last := header
+	for i := header + 1; i < end; i++ {
+		item := &d.items[i]
+		if item.deleted || item.kind != tomlKeyValue {
+			continue
+		}
+		key := item.path[0]
+		value, setting := values[key]
+		switch {
+		case removed[key] || (setting && len(item.path) > 1):
+			// Dotted keys below a key being replaced are rewritten as a whole
+			item.deleted = true
+		case setting:
+			if !reflect.DeepEqual(item.value, value) {
+				item.raw = item.indent + encodeTOMLKey(key) + " = " + encodeTOMLValue(value) + item.comment + lineEnding(item.raw)
+				item.value = value
+			}
+			done[key] = true
+			last = i
+		default:
+			last = i
+		}
+	}
+
+	// Keys removed or replaced may also have tables of their own
+	var added []tomlItem
+	for _, entry := range set {
+		if done[entry.Key] {
+			continue
+		}
+		value := values[entry.Key]
+		subpath := append(append([]string{}, path...), entry.Key)
+		if table, ok := value.(map[string]interface{}); ok && d.findHeader(subpath) >= 0 {
+			existing, _ := d.Table(subpath...)
+			d.UpdateTable(subpath, sortedTOMLEntries(table), missingKeys(existing, table))
+			continue
+		}
+		d.RemoveTable(subpath)
+		added = append(added, newTOMLKeyValue(d.items[header].indent, entry.Key, value))
+	}
+	for _, key := range remove {
+		d.RemoveTable(append(append([]string{}, path...), key))
+	}
+
+	if len(added) > 0 {
+		d.ensureLineEnding(last)
+		d.items = append(d.items[:last+1], append(added, d.items[last+1:]...)...)
+	}
+}
+
+// RemoveTable removes the table at path: its header, the tables below it and the comment
+// block directly above each of them, and any dotted key or inline table defining it
+func (d *TOMLDocument) RemoveTable(path []string) {
+	var section []string
+	for i := range d.items {
+		item := &d.items[i]
+		if item.deleted {
+			continue
+		}
+		switch item.kind {
+		case tomlHeader:
+			section = item.path
+			if hasTOMLPrefix(item.path, path) {
+				// The section goes with its comment block; the next one keeps its own
+				end := d.sectionEnd(i)
+				if end < len(d.items) {
+					end = d.commentBlockStart(end)
+				}
+				for j := d.commentBlockStart(i); j < end; j++ {
+					d.items[j].deleted = true
+				}
+			}
+		case tomlKeyValue:
+			full := append(append([]string{}, section...), item.path...)
+			if hasTOMLPrefix(section, path) || hasTOMLPrefix(full, path) {
+				item.deleted = true
+				continue
+			}
+			// An inline table containing the table loses that key
+			if table, ok := item.value.(map[string]interface{}); ok && hasTOMLPrefix(path, full) {
+				if deleteTOMLPath(table, path[len(full):]) {
+					item.raw = item.indent + encodeTOMLPath(item.path) + " = " + encodeTOMLValue(table) + item.comment + lineEnding(item.raw)
+				}
+			}
+		}
+	}
+	d.compact()
+}
+
+// findHeader returns the index of the [path] header, or -1
+func (d *TOMLDocument) findHeader(path []string) int {
+	for i, item := range d.items {
+		if !item.deleted && item.kind == tomlHeader && !item.array && reflect.DeepEqual(item.path, path) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sectionEnd returns the index of the header after the one at header, or the item count
+func (d *TOMLDocument) sectionEnd(header int) int {
+	for i := header + 1; i < len(d.items); i++ {
+		if !d.items[i].deleted && d.items[i].kind == tomlHeader {
+			return i
+		}
+	}
+	return len(d.items)
+}
+
+// commentBlockStart returns the index of the first comment line directly above the item at i
+func (d *TOMLDocument) commentBlockStart(i int) int {
+	for i > 0 && d.items[i-1].kind == tomlTrivia && strings.HasPrefix(strings.TrimSpace(d.items[i-1].raw), "#") {
+		i--
+	}
+	return i
+}
+
+// takeImplicitTable removes the dotted keys and inline tables that define the table at path
+// without a header, returning the values they held
+func (d *TOMLDocument) takeImplicitTable(path []string) map[string]interface{} {
+	existing, ok := d.Table(path...)
+	if !ok {
+		return nil
+	}
+	// Keep keys that have a header of their own; only the implicit definitions go
+	kept := make(map[string]interface{})
+	for key, value := range existing {
+		if d.findHeader(append(append([]string{}, path...), key)) < 0 {
+			kept[key] = value
+		}
+	}
+	var section []string
+	for i := range d.items {
+		item := &d.items[i]
+		switch {
+		case item.deleted:
+		case item.kind == tomlHeader:
+			section = item.path
+		case item.kind == tomlKeyValue:
+			full := append(append([]string{}, section...), item.path...)
+			if hasTOMLPrefix(full, path) {
+				item.deleted = true
+			} else if table, ok := item.value.(map[string]interface{}); ok && hasTOMLPrefix(path, full) {
+				if deleteTOMLPath(table, path[len(full):]) {
+					item.raw = item.indent + encodeTOMLPath(item.path) + " = " + encodeTOMLValue(table) + item.comment + lineEnding(item.raw)
+				}
+			}
+		}
+	}
+	d.compact()
+	return kept
+}
+
+// appendTable adds a [path] table at the end of the document with the kept keys, less those
+// removed, and the set ones
+func (d *TOMLDocument) appendTable(path []string, kept map[string]interface{}, set []TOMLEntry, remove []string) {
+	for _, key := range remove {
+		delete(kept, key)
+	}
+	for _, entry := range set {
+		delete(kept, entry.Key)
+	}
+	entries := append(sortedTOMLEntries(kept), set...)
+
+	if len(d.items) > 0 {
+		d.ensureLineEnding(len(d.items) - 1)
+		if strings.TrimSpace(d.items[len(d.items)-1].raw) != "" {
+			d.items = append(d.items, tomlItem{kind: tomlTrivia, raw: "\n"})
+		}
+	}
+	d.items = append(d.items, tomlItem{kind: tomlHeader, path: path, raw: "[" + encodeTOMLPath(path) + "]\n"})
+	for _, entry := range entries {
+		d.items = append(d.items, newTOMLKeyValue("", entry.Key, normalizeTOMLValue(entry.Value)))
+	}
+}
+
+// ensureLineEnding terminates the item at i if it is the last line and has no line ending
+func (d *TOMLDocument) ensureLineEnding(i int) {
+	if i >= 0 && i < len(d.items) && !strings.HasSuffix(d.items[i].raw, "\n") {
+		d.items[i].raw += "\n"
+	}
+}
+
+// compact drops deleted items
+func (d *TOMLDocument) compact() {
+	items := d.items[:0]
+	for _, item := range d.items {
+		if !item.deleted {
+			items = append(items, item)
+		}
+	}
+	d.items = items
+}
+
+// newTOMLKeyValue returns a key = value line
+func newTOMLKeyValue(indent, key string, value interface{}) tomlItem {
+	value = normalizeTOMLValue(value)
+	return tomlItem{
+		kind:   tomlKeyValue,
+		indent: indent,
+		path:   []string{key},
+		value:  value,
+		raw:    indent + encodeTOMLKey(key) + " = " + encodeTOMLValue(value) + "\n",
+	}
+}
+
+// lineEnding returns the line ending of a line, "\n" if it has none
+func lineEnding(raw string) string {
+	if strings.HasSuffix(raw, "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// hasTOMLPrefix returns true if path starts with prefix
+func hasTOMLPrefix(path, prefix []string) bool {
+	return len(path) >= len(prefix) && reflect.DeepEqual(path[:len(prefix)], prefix)
+}
+
+// deleteTOMLPath deletes the value at a dotted key, returning false if there is none
+func deleteTOMLPath(table map[string]interface{}, path []string) bool {
+	for _, key := range path[:len(path)-1] {
+		next, ok := table[key].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		table = next
+	}
+	if _, ok := table[path[len(path)-1]]; !ok {
+		return false
+	}
+	delete(table, path[len(path)-1])
+	return true
+}
+
+// sortedTOMLEntries returns the entries of a table sorted by key
+func sortedTOMLEntries(table map[string]interface{}) []TOMLEntry {
+	var entries []TOMLEntry
+	for key, value := range table {
+		entries = append(entries, TOMLEntry{Key: key, Value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// missingKeys returns the keys of existing that are not in table
+func missingKeys(existing, table map[string]interface{}) []string {
+	var keys []string
+	for key := range existing {
+		if _, ok := table[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeTOMLValue converts a value to the types ParseTOML produces
+func normalizeTOMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case []string:
+		values := make([]interface{}, len(v))
+		for i, s := range v {
+			values[i] = s
+		}
+		return values
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, element := range v {
+			values[i] = normalizeTOMLValue(element)
+		}
+		return values
+	case map[string]string:
+		table := make(map[string]interface{}, len(v))
+		for key, s := range v {
+			table[key] = s
+		}
+		return table
+	case map[string]interface{}:
+		table := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			table[key] = normalizeTOMLValue(element)
+		}
+		return table
+	}
+	return value
+}
+
+// encodeTOMLValue returns the TOML text of a normalized value
+func encodeTOMLValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return encodeTOMLString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		switch {
+		case math.IsInf(v, 1):
+			return "inf"
+		case math.IsInf(v, -1):
+			return "-inf"
+		case math.IsNaN(v):
+			return "nan"
+		}
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s
+	case []interface{}:
+		elements := make([]string, len(v))
+		for i, element := range v {
+			elements[i] = encodeTOMLValue(element)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}"
+		}
+		var pairs []string
+		for _, entry := range sortedTOMLEntries(v) {
+			pairs = append(pairs, encodeTOMLKey(entry.Key)+" = "+encodeTOMLValue(entry.Value))
+		}
+		return "{ " + strings.Join(pairs, ", ") + " }"
+	}
+	return encodeTOMLString(fmt.Sprint(value))
+}
+
+// encodeTOMLString returns a basic "string"
+func encodeTOMLString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// encodeTOMLKey returns a key, quoted if it is not bare
+func encodeTOMLKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return encodeTOMLString(key)
+}
+
+// encodeTOMLPath returns a dotted key
+func encodeTOMLPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = encodeTOMLKey(key)
+	}
+	return strings.Join(keys, ".")
+}
@@ -0,0 +1,228 @@
+package clients
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const codexConfig = `# Codex settings
+model = "o3"
+
+[mcp_servers.docs]
+command = "npx"   # launched through npx
+args = ["-y", "@acme/docs-server"]
+
+# Team search server
+[mcp_servers.search]
+command = 'C:\tools\search.exe'
+args = [
+  "--index", # where the index lives
+  "/var/search",
+]
+env = { API_KEY = "secret", "LOG LEVEL" = "debug" }
+
+[mcp_servers.search.limits]
+timeout = 1_000
+ratio = 0.5
+since = 2024-01-02 03:04:05
+
+[profiles.fast]
+model = """
+o4-mini"""
+`
+
+func TestParseTOML_RoundTrip(t *testing.T) {
+	doc, err := ParseTOML([]byte(codexConfig))
+	if err != nil {
+		t.Fatalf("ParseTOML failed: %v", err)
+	}
+	if got := string(doc.Bytes()); got != codexConfig {
+		t.Errorf("Expected an unedited document to round-trip exactly, got:\n%s", got)
+	}
+}
+
+func TestParseTOML_Values(t *testing.T) {
+	doc, err := ParseTOML([]byte(codexConfig))
+	if err != nil {
+		t.Fatalf("ParseTOML failed: %v", err)
+	}
+
+	search, ok := doc.Table("mcp_servers", "search")
+	if !ok {
+		t.Fatal("Expected the search server table")
+	}
+	want := map[string]interface{}{
+		"command": `C:\tools\search.exe`,
+		"args":    []interface{}{"--index", "/var/search"},
+		"env":     map[string]interface{}{"API_KEY": "secret", "LOG LEVEL": "debug"},
+		"limits":  map[string]interface{}{"timeout": int64(1000), "ratio": 0.5, "since": "2024-01-02 03:04:05"},
+	}
+	if !reflect.DeepEqual(search, want) {
+		t.Errorf("Unexpected search table:\n got %#v\nwant %#v", search, want)
+	}
+
+	profile, _ := doc.Table("profiles", "fast")
+	if profile["model"] != "o4-mini" {
+		t.Errorf("Expected the multi-line string without its leading newline, got %q", profile["model"])
+	}
+}
+
+func TestParseTOML_ErrorPosition(t *testing.T) {
+	_, err := ParseTOML([]byte("[mcp_servers.docs]\ncommand = \"npx\nargs = []\n"))
+	var tomlErr *TOMLError
+	if !errors.As(err, &tomlErr) {
+		t.Fatalf("Expected a TOMLError, got %v", err)
+	}
+	if tomlErr.Line != 2 || tomlErr.Column != 15 {
+		t.Errorf("Expected the error at 2:15, got %d:%d (%s)", tomlErr.Line, tomlErr.Column, tomlErr.Message)
+	}
+}
+
+func TestParseTOML_TableDefinedAsValue(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		line int
+	}{
+		{"empty array", "mcp_servers = []\n\n[mcp_servers.docs]\ncommand = \"npx\"\n", 3},
+		{"array of strings", "mcp_servers = [\"docs\"]\n[mcp_servers.docs]\n", 2},
+		{"string", "[mcp_servers]\ndocs = \"npx\"\n[mcp_servers.docs]\n", 3},
+		{"array of tables over a table", "[mcp_servers]\n[[mcp_servers]]\n", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTOML([]byte(tt.data))
+			var tomlErr *TOMLError
+			if !errors.As(err, &tomlErr) {
+				t.Fatalf("Expected a TOMLError, got %v", err)
+			}
+			if tomlErr.Line != tt.line || !strings.Contains(tomlErr.Message, "not a table") {
+				t.Errorf("Expected the error on line %d, got %d (%s)", tt.line, tomlErr.Line, tomlErr.Message)
+			}
+		})
+	}
+
+	// Headers below an array of tables extend its last table
+	doc, err := ParseTOML([]byte("[[profiles]]\nname = \"a\"\n[profiles.env]\nX = \"1\"\n"))
+	if err != nil {
+		t.Fatalf("ParseTOML failed: %v", err)
+	}
+	profiles := doc.Value()["profiles"].([]interface{})
+	if env, ok := profiles[0].(map[string]interface{})["env"].(map[string]interface{}); !ok || env["X"] != "1" {
+		t.Errorf("Expected the subtable in the last array table, got %v", profiles)
+	}
+}
+
+func TestTOMLDocument_UpdateTable(t *testing.T) {
+	doc, err := ParseTOML([]byte(codexConfig))
+	if err != nil {
+		t.Fatalf("ParseTOML failed: %v", err)
+	}
+
+	doc.UpdateTable([]string{"mcp_servers", "docs"}, []TOMLEntry{
+		{Key: "command", Value: "npx"},
+		{Key: "args", Value: []string{"-y", "@acme/docs-server@2"}},
+		{Key: "env", Value: map[string]string{"DEBUG": "1"}},
+	}, []string{"url"})
+
+	want := `# Codex settings
+model = "o3"
+
+[mcp_servers.docs]
+command = "npx"   # launched through npx
+args = ["-y", "@acme/docs-server@2"]
+env = { DEBUG = "1" }
+
+# Team search server
+[mcp_servers.search]
+command = 'C:\tools\search.exe'
+args = [
+  "--index", # where the index lives
+  "/var/search",
+]
+env = { API_KEY = "secret", "LOG LEVEL" = "debug" }
+
+[mcp_servers.search.limits]
+timeout = 1_000
+ratio = 0.5
+since = 2024-01-02 03:04:05
+
+[profiles.fast]
+model = """
+o4-mini"""
+`
+	if got := string(doc.Bytes()); got != want {
+		t.Errorf("Unexpected document after the update:\n%s", got)
+	}
+}
+
+func TestTOMLDocument_UpdateSubtable(t *testing.T) {
+	doc, err := ParseTOML([]byte("[mcp_servers.x]\ncommand = \"x\"\n\n[mcp_servers.x.env]\n# keep me\nA = \"1\"\nB = \"2\"\n"))
+	if err != nil {
+		t.Fatalf("ParseTOML failed: %v", err)
+	}
+
+	doc.UpdateTable([]string{"mcp_servers", "x"}, []TOMLEntry{
+		{Key: "command", Value: "x"},
+		{Key: "env", Value: map[string]string{"A": "1", "C": "3"}},
+	}, nil)
+
+	want := "[mcp_servers.x]\ncommand = \"x\"\n\n[mcp_servers.x.env]\n# keep me\nA = \"1\"\nC = \"3\"\n"
+	if got := string(doc.Bytes()); got != want {
+		t.Errorf("Expected the env table to be edited in place, got:\n%s", got)
+	}
+}
+
+func TestTOMLDocument_AppendAndRemove(t *testing.T) {
+	doc, err := ParseTOML([]byte(codexConfig))
+	if err != nil {
+		t.Fatalf("ParseTOML failed: %v", err)
+	}
+
+	doc.RemoveTable([]string{"mcp_servers", "search"})
+	doc.UpdateTable([]string{"mcp_servers", "new server"}, []TOMLEntry{
+		{Key: "command", Value: "node"},
+		{Key: "args", Value: []string{"server.js"}},
+	}, nil)
+
+	want := `# Codex settings
+model = "o3"
+
+[mcp_servers.docs]
+command = "npx"   # launched through npx
+args = ["-y", "@acme/docs-server"]
+
+[profiles.fast]
+model = """
+o4-mini"""
+
+[mcp_servers."new server"]
+command = "node"
+args = ["server.js"]
+`
+	if got := string(doc.Bytes()); got != want {
+		t.Errorf("Unexpected document after removing and adding servers:\n%s", got)
+	}
+
+	if _, err := ParseTOML(doc.Bytes()); err != nil {
+		t.Errorf("Expected the edited document to parse: %v", err)
+	}
+}
+
+func TestTOMLDocument_ImplicitTables(t *testing.T) {
+	doc, err := ParseTOML([]byte("[mcp_servers]\ndocs = { command = \"npx\", args = [] }\nsearch.command = \"search\"\n"))
+	if err != nil {
+		t.Fatalf("ParseTOML failed: %v", err)
+	}
+
+	doc.UpdateTable([]string{"mcp_servers", "docs"}, []TOMLEntry{{Key: "command", Value: "node"}}, nil)
+	doc.RemoveTable([]string{"mcp_servers", "search"})
+
+	want := "[mcp_servers]\n\n[mcp_servers.docs]\nargs = []\ncommand = \"node\"\n"
+	if got := string(doc.Bytes()); got != want {
+		t.Errorf("Expected the inline table to become a table of its own, got:\n%q", got)
+	}
+}
@@ -1,11 +1,15 @@
 package config
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/clients"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
 )
 
 // ClientType represents the type of MCP client
@@ -19,67 +23,92 @@ const (
 
 // ClientInfo represents information about an installed MCP client
 type ClientInfo struct {
-	Type       ClientType `json:"type"`
-	Name       string     `json:"name"`
-	ConfigPath string     `json:"configPath"`
-	Installed  bool       `json:"installed"`
+	Type        ClientType `json:"type"`
+	Name        string     `json:"name"`
+	ConfigPath  string     `json:"configPath"`
+	Installed   bool       `json:"installed"`
+	SupportsURL bool       `json:"supportsUrl"`
 }
 
 // ServerEntry represents a server entry in the client config
 type ServerEntry struct {
-	Command string            `json:"command"`
-	Args    []string          `json:"args"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
 	Env     map[string]string `json:"env,omitempty"`
+	Type    string            `json:"type,omitempty"` // Transport of url-based entries
+	URL     string            `json:"url,omitempty"`  // Endpoint of a remote server
 }
 
 // ClientConfig represents the structure of an MCP client configuration file
+// MCPServers holds the entries under the client's root key, whatever it is called in the file,
+// or under Section if it is set, such as a project's section of ~/.claude.json
 type ClientConfig struct {
 	MCPServers map[string]ServerEntry `json:"mcpServers"`
+	Section    []string               `json:"section,omitempty"` // Keys of the object that holds the servers
 }
 
+// defaultRootKey holds the servers of config files that belong to no known client
+const defaultRootKey = "mcpServers"
+
 // ClientEditor provides functionality for editing MCP client configuration files
-type ClientEditor struct{}
+// The files and their layout come from the client registry
+type ClientEditor struct {
+	clients      *clients.Registry
+	pathResolver platform.PathResolver
+}
 
-// NewClientEditor creates a new ClientEditor instance
-func NewClientEditor() *ClientEditor {
-	return &ClientEditor{}
+// NewClientEditor creates a new ClientEditor instance for the clients in clientRegistry
+func NewClientEditor(clientRegistry *clients.Registry) *ClientEditor {
+	return &ClientEditor{
+		clients:      clientRegistry,
+		pathResolver: platform.NewPathResolver(),
+	}
 }
 
 // DetectClients detects which MCP clients are installed on the system
-// Returns a list of detected clients with their config file paths
+// Returns a list of detected clients with their config file paths: the first that exists,
+// or else the client's preferred path
 func (ce *ClientEditor) DetectClients() ([]ClientInfo, error) {
-	var clients []ClientInfo
-
-	// Detect Claude Desktop
-	claudeConfig := ce.getClaudeDesktopConfigPath()
-	claudeInstalled := ce.fileExists(claudeConfig)
-	clients = append(clients, ClientInfo{
-		Type:       ClientClaudeDesktop,
-		Name:       "Claude Desktop",
-		ConfigPath: claudeConfig,
-		Installed:  claudeInstalled,
-	})
-
-	// Detect Cursor (common paths)
-	cursorConfig := ce.getCursorConfigPath()
-	cursorInstalled := ce.fileExists(cursorConfig)
-	clients = append(clients, ClientInfo{
-		Type:       ClientCursor,
-		Name:       "Cursor",
-		ConfigPath: cursorConfig,
-		Installed:  cursorInstalled,
-	})
-
-	return clients, nil
+	var detected []ClientInfo
+	byID := make(map[string]int)
+	for _, file := range ce.clients.ConfigFiles(ce.pathResolver) {
+		i, seen := byID[file.Client.ID]
+		if !seen {
+			byID[file.Client.ID] = len(detected)
+			detected = append(detected, ClientInfo{
+				Type:        ClientType(file.Client.ID),
+				Name:        file.Client.Name,
+				ConfigPath:  file.Path,
+				Installed:   ce.fileExists(file.Path),
+				SupportsURL: file.Client.SupportsURL,
+			})
+			continue
+		}
+		if !detected[i].Installed && ce.fileExists(file.Path) {
+			detected[i].ConfigPath = file.Path
+			detected[i].Installed = true
+		}
+	}
+
+	return detected, nil
 }
 
 // ReadConfig reads and parses an MCP client configuration file
 func (ce *ClientEditor) ReadConfig(configPath string) (*ClientConfig, error) {
+	return ce.ReadConfigSection(configPath, nil)
+}
+
+// ReadConfigSection reads the servers held by the object at section in an MCP client
+// configuration file, such as the ConfigSection of a discovered server
+// An empty section stands for the client's root key. The config remembers its section, so
+// WriteConfig writes the servers back to it
+func (ce *ClientEditor) ReadConfigSection(configPath string, section []string) (*ClientConfig, error) {
 	// Check if file exists
 	if !ce.fileExists(configPath) {
 		// Return empty config if file doesn't exist
 		return &ClientConfig{
 			MCPServers: make(map[string]ServerEntry),
+			Section:    section,
 		}, nil
 	}
 
@@ -89,11 +118,18 @@ func (ce *ClientEditor) ReadConfig(configPath string) (*ClientConfig, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse JSON
-	var config ClientConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	// Parse the servers under the client's root key or the section
+	client := ce.client(configPath)
+	var servers map[string]ServerEntry
+	if client.Format == models.ClientFormatTOML {
+		servers, err = readTOMLServers(data, serversPath(client, section))
+	} else {
+		servers, err = readJSONServers(data, client.Format, serversPath(client, section))
 	}
+	if err != nil {
+		return nil, err
+	}
+	config := ClientConfig{MCPServers: servers, Section: section}
 
 	// Ensure MCPServers map is initialized
 	if config.MCPServers == nil {
@@ -103,8 +139,9 @@ func (ce *ClientEditor) ReadConfig(configPath string) (*ClientConfig, error) {
 	return &config, nil
 }
 
-// WriteConfig writes an updated configuration to the client config file
-// Creates a backup before writing and validates the JSON structure
+// WriteConfig writes an updated configuration to the client config file, under the config's
+// section if it has one. Only the servers that changed are rewritten: the file's other settings, its layout and
+// its comments are kept. Creates a backup before writing, unless nothing changed
 func (ce *ClientEditor) WriteConfig(configPath string, config *ClientConfig) error {
 	// Validate config structure
 	if config == nil {
@@ -114,8 +151,41 @@ func (ce *ClientEditor) WriteConfig(configPath string, config *ClientConfig) err
 		config.MCPServers = make(map[string]ServerEntry)
 	}
 
+	client := ce.client(configPath)
+	if !client.SupportsURL {
+		for name, entry := range config.MCPServers {
+			if entry.URL != "" {
+				return fmt.Errorf("server '%s': %s does not support url-based entries", name, client.Name)
+			}
+		}
+	}
+
+	exists := ce.fileExists(configPath)
+	var original []byte
+	if exists {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+		original = data
+	}
+
+	var data []byte
+	var err error
+	if client.Format == models.ClientFormatTOML {
+		data, err = writeTOMLServers(original, serversPath(client, config.Section), config.MCPServers)
+	} else {
+		data, err = writeJSONServers(original, client.Format, serversPath(client, config.Section), config.MCPServers)
+	}
+	if err != nil {
+		return err
+	}
+	if exists && bytes.Equal(data, original) {
+		return nil
+	}
+
 	// Create backup if file exists
-	if ce.fileExists(configPath) {
+	if exists {
 		if err := ce.createBackup(configPath); err != nil {
 			return fmt.Errorf("failed to create backup: %w", err)
 		}
@@ -127,12 +197,6 @@ func (ce *ClientEditor) WriteConfig(configPath string, config *ClientConfig) err
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Marshal config to JSON with indentation for readability
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
-	}
-
 	// Write to file
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
@@ -209,55 +273,22 @@ func (ce *ClientEditor) RemoveServer(config *ClientConfig, serverName string) er
 	return nil
 }
 
-// getClaudeDesktopConfigPath returns the path to the Claude Desktop config file
-func (ce *ClientEditor) getClaudeDesktopConfigPath() string {
-	// Windows: %APPDATA%\Claude\claude_desktop_config.json
-	// macOS: ~/Library/Application Support/Claude/claude_desktop_config.json
-	// Linux: ~/.config/Claude/claude_desktop_config.json
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
-
-	// Detect OS
-	switch {
-	case fileExists(filepath.Join(os.Getenv("APPDATA"), "Claude")):
-		// Windows
-		return filepath.Join(os.Getenv("APPDATA"), "Claude", "claude_desktop_config.json")
-	case fileExists(filepath.Join(homeDir, "Library", "Application Support", "Claude")):
-		// macOS
-		return filepath.Join(homeDir, "Library", "Application Support", "Claude", "claude_desktop_config.json")
-	default:
-		// Linux/Unix
-		return filepath.Join(homeDir, ".config", "Claude", "claude_desktop_config.json")
+// client returns the client that owns a config file
+// Files of unknown clients are treated as an mcpServers file that accepts url entries
+func (ce *ClientEditor) client(configPath string) models.ClientDefinition {
+	if client, ok := ce.clients.ClientForPath(ce.pathResolver, configPath); ok {
+		return client
 	}
+	return models.ClientDefinition{Name: "custom", Format: models.ClientFormatJSON, RootKey: defaultRootKey, SupportsURL: true}
 }
 
-// getCursorConfigPath returns the path to the Cursor config file
-func (ce *ClientEditor) getCursorConfigPath() string {
-	// Cursor typically uses similar paths to VSCode
-	// Windows: %APPDATA%\Cursor\User\mcp_config.json
-	// macOS: ~/Library/Application Support/Cursor/User/mcp_config.json
-	// Linux: ~/.config/Cursor/User/mcp_config.json
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
-
-	// Detect OS
-	switch {
-	case fileExists(filepath.Join(os.Getenv("APPDATA"), "Cursor")):
-		// Windows
-		return filepath.Join(os.Getenv("APPDATA"), "Cursor", "User", "mcp_config.json")
-	case fileExists(filepath.Join(homeDir, "Library", "Application Support", "Cursor")):
-		// macOS
-		return filepath.Join(homeDir, "Library", "Application Support", "Cursor", "User", "mcp_config.json")
-	default:
-		// Linux/Unix
-		return filepath.Join(homeDir, ".config", "Cursor", "User", "mcp_config.json")
+// serversPath returns the keys of the object that holds the servers: section, or the client's
+// root key if section is empty
+func serversPath(client models.ClientDefinition, section []string) []string {
+	if len(section) > 0 {
+		return section
 	}
+	return client.RootPath()
 }
 
 // createBackup creates a timestamped backup of the config file
@@ -285,9 +316,3 @@ func (ce *ClientEditor) fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
 }
-
-// Helper function for OS detection
-func fileExists(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil
-}
@@ -0,0 +1,525 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/core/clients"
+)
+
+// newTestClientEditor returns an editor whose home and config directories are under root,
+// with a client that keeps its servers under "servers" and does not accept url entries
+func newTestClientEditor(t *testing.T, root string) *ClientEditor {
+	userFile := `{"clients": [{"id": "editor", "name": "Editor", "paths": {"default": ["` + filepath.ToSlash(root) + `/editor.json"]}, "format": "json", "rootKey": "servers"}]}`
+	if err := os.WriteFile(filepath.Join(root, clients.UserFileName), []byte(userFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err := clients.LoadRegistry(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClientEditor(registry)
+}
+
+func TestClientEditor_DetectClients(t *testing.T) {
+	root := t.TempDir()
+	editor := newTestClientEditor(t, root)
+	configPath := filepath.Join(root, "editor.json")
+	if err := os.WriteFile(configPath, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	detected, err := editor.DetectClients()
+	if err != nil {
+		t.Fatalf("DetectClients failed: %v", err)
+	}
+	found := false
+	for _, client := range detected {
+		if client.Type == "editor" {
+			found = true
+			if client.ConfigPath != configPath || !client.Installed || client.SupportsURL {
+				t.Errorf("Unexpected client info: %+v", client)
+			}
+		}
+	}
+	if !found || len(detected) != len(clients.BuiltinClients)+1 {
+		t.Fatalf("Expected the built-in clients and the user's client, got %+v", detected)
+	}
+	for i, builtin := range clients.BuiltinClients {
+		if detected[i].Type != ClientType(builtin.ID) {
+			t.Errorf("Expected %s at %d, got %s", builtin.ID, i, detected[i].Type)
+		}
+	}
+}
+
+func TestClientEditor_RootKey(t *testing.T) {
+	root := t.TempDir()
+	editor := newTestClientEditor(t, root)
+	configPath := filepath.Join(root, "editor.json")
+	original := `{"theme": "dark", "servers": {"existing": {"command": "node", "args": ["a.js"]}}}`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := editor.ReadConfig(configPath)
+	if err != nil {
+		t.Fatalf("ReadConfig failed: %v", err)
+	}
+	if _, ok := config.MCPServers["existing"]; !ok || len(config.MCPServers) != 1 {
+		t.Fatalf("Expected the servers under the client's root key, got %+v", config.MCPServers)
+	}
+
+	if err := editor.AddServer(config, "added", "python", []string{"b.py"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written map[string]json.RawMessage
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatal(err)
+	}
+	if string(written["theme"]) != `"dark"` {
+		t.Errorf("Expected other settings to be kept, got %s", data)
+	}
+	if _, ok := written["mcpServers"]; ok || !strings.Contains(string(written["servers"]), `"added"`) {
+		t.Errorf("Expected the servers to be written under the client's root key, got %s", data)
+	}
+}
+
+func TestClientEditor_RejectsUnsupportedURL(t *testing.T) {
+	root := t.TempDir()
+	editor := newTestClientEditor(t, root)
+	configPath := filepath.Join(root, "editor.json")
+
+	config := &ClientConfig{MCPServers: map[string]ServerEntry{
+		"remote": {Type: "http", URL: "https://example.com/mcp"},
+	}}
+	err := editor.WriteConfig(configPath, config)
+	if err == nil || !strings.Contains(err.Error(), "does not support url-based entries") {
+		t.Fatalf("Expected url entries to be rejected, got %v", err)
+	}
+	if _, statErr := os.Stat(configPath); !os.IsNotExist(statErr) {
+		t.Error("Expected nothing to be written")
+	}
+
+	// A file of no known client accepts them
+	otherPath := filepath.Join(root, "other.json")
+	if err := editor.WriteConfig(otherPath, config); err != nil {
+		t.Errorf("Expected a custom file to accept url entries, got %v", err)
+	}
+}
+
+func TestClientEditor_PreservesJSONLayout(t *testing.T) {
+	root := t.TempDir()
+	editor := newTestClientEditor(t, root)
+	configPath := filepath.Join(root, "editor.json")
+	original := `{
+    "theme": "dark",
+    "servers": {
+        "kept": { "command": "node", "args": ["a.js"] },
+        "changed": {"command": "node", "timeout": 30},
+        "removed": {"command": "old"}
+    },
+    "zoom": 1.5
+}
+`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := editor.ReadConfig(configPath)
+	if err != nil {
+		t.Fatalf("ReadConfig failed: %v", err)
+	}
+	if err := editor.UpdateServer(config, "changed", "python", []string{"b.py"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.RemoveServer(config, "removed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+    "theme": "dark",
+    "servers": {
+        "kept": { "command": "node", "args": ["a.js"] },
+        "changed": {
+            "args": [
+                "b.py"
+            ],
+            "command": "python",
+            "timeout": 30
+        }
+    },
+    "zoom": 1.5
+}
+`
+	if string(data) != want {
+		t.Errorf("Expected only the changed servers to be rewritten, got:\n%s", data)
+	}
+
+	// Writing the same servers again leaves the file alone
+	backups, _ := filepath.Glob(configPath + ".backup.*")
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+	if again, _ := filepath.Glob(configPath + ".backup.*"); len(again) != len(backups) {
+		t.Error("Expected no backup when nothing changed")
+	}
+}
+
+func TestClientEditor_ProjectSection(t *testing.T) {
+	root := t.TempDir()
+	editor := newTestClientEditor(t, root)
+	configPath := filepath.Join(root, ".claude.json")
+	original := `{
+  "numStartups": 12,
+  "mcpServers": {
+    "db": {"command": "user-db"}
+  },
+  "projects": {
+    "/work/app": {
+      "allowedTools": [],
+      "mcpServers": {
+        "db": {"command": "db-server", "args": ["--local"]},
+        "scratch": {"command": "scratch"}
+      }
+    },
+    "/work/other": {
+      "mcpServers": {
+        "db": {"command": "other-db"}
+      }
+    }
+  }
+}
+`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	section := []string{"projects", "/work/app", "mcpServers"}
+	config, err := editor.ReadConfigSection(configPath, section)
+	if err != nil {
+		t.Fatalf("ReadConfigSection failed: %v", err)
+	}
+	if len(config.MCPServers) != 2 || config.MCPServers["db"].Command != "db-server" {
+		t.Fatalf("Expected the project's local servers, got %+v", config.MCPServers)
+	}
+	if err := editor.UpdateServer(config, "db", "db-server", []string{"--local", "--verbose"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.RemoveServer(config, "scratch"); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "numStartups": 12,
+  "mcpServers": {
+    "db": {"command": "user-db"}
+  },
+  "projects": {
+    "/work/app": {
+      "allowedTools": [],
+      "mcpServers": {
+        "db": {
+          "args": [
+            "--local",
+            "--verbose"
+          ],
+          "command": "db-server"
+        }
+      }
+    },
+    "/work/other": {
+      "mcpServers": {
+        "db": {"command": "other-db"}
+      }
+    }
+  }
+}
+`
+	if string(data) != want {
+		t.Errorf("Expected only the project's section to change, got:\n%s", data)
+	}
+
+	// The user servers are untouched by the project's edit
+	userConfig, err := editor.ReadConfig(configPath)
+	if err != nil {
+		t.Fatalf("ReadConfig failed: %v", err)
+	}
+	if len(userConfig.MCPServers) != 1 || userConfig.MCPServers["db"].Command != "user-db" {
+		t.Errorf("Expected the user server to be kept, got %+v", userConfig.MCPServers)
+	}
+}
+
+func TestClientEditor_AddsRootKey(t *testing.T) {
+	root := t.TempDir()
+	editor := newTestClientEditor(t, root)
+	configPath := filepath.Join(root, "editor.json")
+	if err := os.WriteFile(configPath, []byte("{\n\t\"theme\": \"dark\"\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ClientConfig{MCPServers: map[string]ServerEntry{"new": {Command: "node"}}}
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{\n\t\"theme\": \"dark\",\n\t\"servers\": {\n\t\t\"new\": {\n\t\t\t\"command\": \"node\"\n\t\t}\n\t}\n}\n"
+	if string(data) != want {
+		t.Errorf("Expected the root key to be added in the file's indentation, got:\n%q", data)
+	}
+}
+
+func TestClientEditor_CodexTOML(t *testing.T) {
+	root := t.TempDir()
+	registry := clients.NewRegistry()
+	codex, _ := registry.Get(clients.CodexID)
+	codex.Paths = map[string][]string{"default": {filepath.ToSlash(root) + "/config.toml"}}
+	userFile, _ := json.Marshal(map[string]interface{}{"clients": []interface{}{codex}})
+	if err := os.WriteFile(filepath.Join(root, clients.UserFileName), userFile, 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err := clients.LoadRegistry(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	editor := NewClientEditor(registry)
+
+	configPath := filepath.Join(root, "config.toml")
+	original := `# Codex settings
+model = "o3"
+
+# Docs lookup
+[mcp_servers.docs]
+command = "npx" # via npx
+args = ["-y", "docs-server"]
+startup_timeout_sec = 20
+
+[mcp_servers.docs.env]
+DOCS_TOKEN = "secret"
+
+# Old search server
+[mcp_servers.search]
+command = "search"
+`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := editor.ReadConfig(configPath)
+	if err != nil {
+		t.Fatalf("ReadConfig failed: %v", err)
+	}
+	if docs := config.MCPServers["docs"]; docs.Command != "npx" || docs.Env["DOCS_TOKEN"] != "secret" || len(config.MCPServers) != 2 {
+		t.Fatalf("Unexpected servers: %+v", config.MCPServers)
+	}
+
+	docs := config.MCPServers["docs"]
+	docs.Args = []string{"-y", "docs-server@2"}
+	config.MCPServers["docs"] = docs
+	if err := editor.RemoveServer(config, "search"); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.AddServer(config, "files", "files-server", []string{"/srv"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Codex settings
+model = "o3"
+
+# Docs lookup
+[mcp_servers.docs]
+command = "npx" # via npx
+args = ["-y", "docs-server@2"]
+startup_timeout_sec = 20
+
+[mcp_servers.docs.env]
+DOCS_TOKEN = "secret"
+
+[mcp_servers.files]
+command = "files-server"
+args = ["/srv"]
+`
+	if string(data) != want {
+		t.Errorf("Expected comments and untouched keys to survive the edit, got:\n%s", data)
+	}
+}
+
+func TestClientEditor_VSCodeSettings(t *testing.T) {
+	root := t.TempDir()
+	registry := clients.NewRegistry()
+	vscode, _ := registry.Get(clients.VSCodeID)
+	vscode.Paths = map[string][]string{"default": {filepath.ToSlash(root) + "/settings.json"}}
+	userFile, _ := json.Marshal(map[string]interface{}{"clients": []interface{}{vscode}})
+	if err := os.WriteFile(filepath.Join(root, clients.UserFileName), userFile, 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err := clients.LoadRegistry(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	editor := NewClientEditor(registry)
+
+	configPath := filepath.Join(root, "settings.json")
+	original := `{
+  // Editor
+  "editor.fontSize": 14,
+  "mcp": {
+    "inputs": [],
+    "servers": {
+      // Used daily
+      "kept": {"command": "node", "args": ["a.js"]}, // trailing note
+      /* Old one */
+      "removed": {"command": "old"},
+      "changed": {"command": "node",},
+    },
+  },
+}
+`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := editor.ReadConfig(configPath)
+	if err != nil {
+		t.Fatalf("ReadConfig failed: %v", err)
+	}
+	if len(config.MCPServers) != 3 || config.MCPServers["kept"].Command != "node" {
+		t.Fatalf("Unexpected servers: %+v", config.MCPServers)
+	}
+	if err := editor.UpdateServer(config, "changed", "python", []string{"b.py"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.RemoveServer(config, "removed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.AddServer(config, "added", "npx", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  // Editor
+  "editor.fontSize": 14,
+  "mcp": {
+    "inputs": [],
+    "servers": {
+      // Used daily
+      "kept": {"command": "node", "args": ["a.js"]}, // trailing note
+      "changed": {
+        "args": [
+          "b.py"
+        ],
+        "command": "python"
+      },
+      "added": {
+        "command": "npx"
+      },
+    },
+  },
+}
+`
+	if string(data) != want {
+		t.Errorf("Expected the comments and the other entries to be kept, got:\n%s", data)
+	}
+}
+
+func TestClientEditor_VSCodeWorkspace(t *testing.T) {
+	root := t.TempDir()
+	editor := newTestClientEditor(t, root)
+	configPath := filepath.Join(root, "project", ".vscode", "mcp.json")
+
+	// A missing workspace file is created with top-level servers
+	config := &ClientConfig{MCPServers: map[string]ServerEntry{
+		"db":      {Type: "stdio", Command: "db-server"},
+		"scratch": {Command: "scratch"},
+	}}
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "{\n  \"servers\": {\n    \"db\": {") {
+		t.Fatalf("Expected a servers object, got:\n%s", data)
+	}
+
+	original := `{
+  "inputs": [{"type": "promptString", "id": "db-key", "password": true}],
+  "servers": {
+    "db": {"type": "stdio", "command": "db-server"},
+    // Scratch server
+    "scratch": {"command": "scratch"} // to remove
+  }
+}
+`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err = editor.ReadConfig(configPath)
+	if err != nil {
+		t.Fatalf("ReadConfig failed: %v", err)
+	}
+	if err := editor.RemoveServer(config, "scratch"); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.WriteConfig(configPath, config); err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	data, err = os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "inputs": [{"type": "promptString", "id": "db-key", "password": true}],
+  "servers": {
+    "db": {"type": "stdio", "command": "db-server"}
+  }
+}
+`
+	if string(data) != want {
+		t.Errorf("Expected the last server to be removed with its comments, got:\n%s", data)
+	}
+}
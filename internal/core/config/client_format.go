@@ -0,0 +1,452 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Positronikal/MCPManager/internal/core/clients"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+// managedTOMLKeys are the keys of a TOML server table that ServerEntry holds; other keys,
+// such as Codex's startup_timeout_sec, are left as they are
+var managedTOMLKeys = []string{"command", "args", "env", "url"}
+
+// managedJSONKeys are the keys of a JSON server entry that ServerEntry holds
+var managedJSONKeys = []string{"command", "args", "env", "type", "url"}
+
+// jsonMember is a member of a JSON object with the byte spans of its key and value
+type jsonMember struct {
+	Key      string
+	Value    json.RawMessage
+	KeyStart int
+	Start    int
+	End      int
+}
+
+// jsonEdit replaces the bytes from From to To with Text
+type jsonEdit struct {
+	From int
+	To   int
+	Text string
+}
+
+// readJSONServers decodes the server entries at rootPath in a JSON or JSONC config file
+func readJSONServers(data []byte, format models.ClientFormat, rootPath []string) (map[string]ServerEntry, error) {
+	if format == models.ClientFormatJSONC {
+		data = clients.StandardizeJSONC(data)
+	}
+
+	servers := make(map[string]ServerEntry)
+	raw := json.RawMessage(data)
+	for i, key := range rootPath {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			if i == 0 {
+				return nil, fmt.Errorf("failed to parse config file: %w", err)
+			}
+			return nil, fmt.Errorf("failed to parse %s in config file: %w", strings.Join(rootPath[:i], "."), err)
+		}
+		var ok bool
+		if raw, ok = object[key]; !ok {
+			return servers, nil
+		}
+	}
+	if err := json.Unmarshal(raw, &servers); err != nil {
+		return nil, fmt.Errorf("failed to parse %s in config file: %w", strings.Join(rootPath, "."), err)
+	}
+	return servers, nil
+}
+
+// readTOMLServers decodes the [rootKey.<name>] tables of a TOML config file
+func readTOMLServers(data []byte, rootPath []string) (map[string]ServerEntry, error) {
+	doc, err := clients.ParseTOML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	servers := make(map[string]ServerEntry)
+	table, _ := doc.Table(rootPath...)
+	for name, value := range table {
+		entry, err := tomlServerEntry(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s.%s in config file: %w", strings.Join(rootPath, "."), name, err)
+		}
+		servers[name] = entry
+	}
+	return servers, nil
+}
+
+// tomlServerEntry converts a server table to a ServerEntry
+func tomlServerEntry(table interface{}) (ServerEntry, error) {
+	var entry ServerEntry
+	data, err := json.Marshal(table)
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
+
+// sameEntry returns true if two entries would be written the same way
+func sameEntry(a, b ServerEntry) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// writeTOMLServers updates the [rootKey.<name>] tables of a TOML config file to hold servers
+// Tables of unchanged servers, comments and the rest of the file are kept as they are
+func writeTOMLServers(data []byte, rootPath []string, servers map[string]ServerEntry) ([]byte, error) {
+	doc, err := clients.ParseTOML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	existing, _ := doc.Table(rootPath...)
+	for name := range existing {
+		if _, ok := servers[name]; !ok {
+			doc.RemoveTable(serverTablePath(rootPath, name))
+		}
+	}
+
+	for _, name := range sortedServerNames(servers) {
+		// TOML clients such as Codex tell url entries apart without a type
+		entry := servers[name]
+		entry.Type = ""
+		if table, ok := existing[name]; ok {
+			if current, err := tomlServerEntry(table); err == nil && sameEntry(current, entry) {
+				continue
+			}
+		}
+
+		var set []clients.TOMLEntry
+		var remove []string
+		values := map[string]interface{}{"command": entry.Command, "args": entry.Args, "env": entry.Env, "url": entry.URL}
+		for _, key := range managedTOMLKeys {
+			switch value := values[key].(type) {
+			case string:
+				if value == "" {
+					remove = append(remove, key)
+					continue
+				}
+			case []string:
+				if len(value) == 0 {
+					remove = append(remove, key)
+					continue
+				}
+			case map[string]string:
+				if len(value) == 0 {
+					remove = append(remove, key)
+					continue
+				}
+			}
+			set = append(set, clients.TOMLEntry{Key: key, Value: values[key]})
+		}
+		doc.UpdateTable(serverTablePath(rootPath, name), set, remove)
+	}
+
+	return doc.Bytes(), nil
+}
+
+// serverTablePath returns the path of a server's table
+func serverTablePath(rootPath []string, name string) []string {
+	return append(append([]string(nil), rootPath...), name)
+}
+
+// writeJSONServers updates the object at rootPath in a JSON or JSONC config file to hold
+// servers. Only the entries that changed are rewritten: the rest of the file, comments
+// included, is kept byte for byte, and changed entries keep the keys ServerEntry does not hold
+func writeJSONServers(data []byte, format models.ClientFormat, rootPath []string, servers map[string]ServerEntry) ([]byte, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		data = []byte("{}\n")
+	}
+	// Offsets are found in a copy without comments, in which they are the same as in data
+	scanned := data
+	if format == models.ClientFormatJSONC {
+		scanned = clients.StandardizeJSONC(data)
+	}
+	indent := detectJSONIndent(data)
+
+	// Find the servers object, adding the keys leading to it if they are missing
+	open := bytes.IndexByte(scanned, '{')
+	if open < 0 || len(bytes.TrimSpace(scanned[:open])) > 0 {
+		return nil, fmt.Errorf("failed to parse config file: expected a JSON object")
+	}
+	for depth, key := range rootPath {
+		members, closing, err := jsonObjectMembers(scanned[open:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		var member *jsonMember
+		for i := range members {
+			if members[i].Key == key {
+				member = &members[i]
+			}
+		}
+
+		switch {
+		case member == nil:
+			value, err := renderJSONPath(rootPath[depth+1:], servers, indent, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			quoted, _ := json.Marshal(key)
+			return insertJSONMember(data, members, open, open+closing, string(quoted)+": "+value, indent, depth), nil
+		case !bytes.HasPrefix(member.Value, []byte("{")):
+			value, err := renderJSONPath(rootPath[depth+1:], servers, indent, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			return applyJSONEdits(data, []jsonEdit{{From: open + member.Start, To: open + member.End, Text: value}}), nil
+		}
+		open += member.Start
+	}
+
+	members, closing, err := jsonObjectMembers(scanned[open:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s in config file: %w", strings.Join(rootPath, "."), err)
+	}
+	depth := len(rootPath)
+	prefix := strings.Repeat(indent, depth+1)
+
+	// The first member of each server is kept; later duplicates and removed servers are not
+	kept := make([]bool, len(members))
+	lastKept := -1
+	written := make(map[string]bool)
+	for i, member := range members {
+		if _, ok := servers[member.Key]; ok && !written[member.Key] {
+			kept[i] = true
+			lastKept = i
+			written[member.Key] = true
+		}
+	}
+
+	var edits []jsonEdit
+	for i, member := range members {
+		switch {
+		case kept[i]:
+			text, err := renderJSONEntry(member.Value, servers[member.Key], prefix, indent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal server '%s': %w", member.Key, err)
+			}
+			if text != string(member.Value) {
+				edits = append(edits, jsonEdit{From: open + member.Start, To: open + member.End, Text: text})
+			}
+		case i < lastKept:
+			from, to := jsonMemberRemoval(data, scanned, open+member.KeyStart, open+member.End)
+			edits = append(edits, jsonEdit{From: from, To: to})
+		}
+	}
+
+	// New servers go after the last kept member, replacing the members removed after it
+	var added []string
+	for _, name := range sortedServerNames(servers) {
+		if written[name] {
+			continue
+		}
+		text, err := renderJSONEntry(nil, servers[name], prefix, indent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal server '%s': %w", name, err)
+		}
+		key, _ := json.Marshal(name)
+		added = append(added, prefix+string(key)+": "+text)
+	}
+	switch {
+	case lastKept >= 0:
+		from := open + members[lastKept].End
+		to := from
+		if lastKept < len(members)-1 {
+			// Along with the rest of the last member's line, such as its trailing comment
+			to = open + members[len(members)-1].End
+			if end := bytes.IndexByte(scanned[to:], '\n'); end >= 0 && len(bytes.TrimSpace(scanned[to:to+end])) == 0 {
+				to += end
+			}
+		}
+		text := ""
+		for _, entry := range added {
+			text += ",\n" + entry
+		}
+		if from != to || text != "" {
+			edits = append(edits, jsonEdit{From: from, To: to, Text: text})
+		}
+	case len(members) > 0 || len(added) > 0:
+		text := ""
+		if len(added) > 0 {
+			text = "\n" + strings.Join(added, ",\n") + "\n" + strings.Repeat(indent, depth)
+		}
+		edits = append(edits, jsonEdit{From: open + 1, To: open + closing, Text: text})
+	}
+
+	return applyJSONEdits(data, edits), nil
+}
+
+// renderJSONPath returns the text of an object at depth holding the keys of path, nested,
+// with servers in the innermost one
+func renderJSONPath(path []string, servers map[string]ServerEntry, indent string, depth int) (string, error) {
+	prefix := strings.Repeat(indent, depth+1)
+	var members []string
+	if len(path) > 0 {
+		value, err := renderJSONPath(path[1:], servers, indent, depth+1)
+		if err != nil {
+			return "", err
+		}
+		key, _ := json.Marshal(path[0])
+		members = append(members, prefix+string(key)+": "+value)
+	} else {
+		for _, name := range sortedServerNames(servers) {
+			text, err := renderJSONEntry(nil, servers[name], prefix, indent)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal server '%s': %w", name, err)
+			}
+			key, _ := json.Marshal(name)
+			members = append(members, prefix+string(key)+": "+text)
+		}
+	}
+
+	if len(members) == 0 {
+		return "{}", nil
+	}
+	return "{\n" + strings.Join(members, ",\n") + "\n" + strings.Repeat(indent, depth) + "}", nil
+}
+
+// insertJSONMember adds the member text after the last of members, in the object at depth
+// whose braces are at open and closing
+func insertJSONMember(data []byte, members []jsonMember, open, closing int, text, indent string, depth int) []byte {
+	prefix := strings.Repeat(indent, depth+1)
+	if len(members) == 0 {
+		return applyJSONEdits(data, []jsonEdit{{From: open + 1, To: closing, Text: "\n" + prefix + text + "\n" + strings.Repeat(indent, depth)}})
+	}
+	last := open + members[len(members)-1].End
+	return applyJSONEdits(data, []jsonEdit{{From: last, To: last, Text: ",\n" + prefix + text}})
+}
+
+// jsonMemberRemoval returns the span to remove for a member that another follows: from its
+// key through the comma after it. A member on lines of its own takes those lines with it,
+// along with the comment lines directly above it and its trailing comment
+func jsonMemberRemoval(data, scanned []byte, keyStart, end int) (int, int) {
+	from := keyStart
+	to := end + bytes.IndexByte(scanned[end:], ',') + 1
+	for to < len(scanned) && (scanned[to] == ' ' || scanned[to] == '\t' || scanned[to] == '\r') {
+		to++
+	}
+
+	lineStart := bytes.LastIndexByte(scanned[:from], '\n') + 1
+	if (to == len(scanned) || scanned[to] == '\n') && len(bytes.TrimSpace(scanned[lineStart:from])) == 0 {
+		from = lineStart
+		to = min(to+1, len(scanned))
+		for from > 0 {
+			previous := bytes.LastIndexByte(scanned[:from-1], '\n') + 1
+			if len(bytes.TrimSpace(scanned[previous:from])) > 0 || len(bytes.TrimSpace(data[previous:from])) == 0 {
+				break
+			}
+			from = previous
+		}
+	}
+	return from, to
+}
+
+// applyJSONEdits returns data with edits, which must not overlap, applied
+func applyJSONEdits(data []byte, edits []jsonEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].From > edits[j].From })
+	out := append([]byte(nil), data...)
+	for _, edit := range edits {
+		out = append(out[:edit.From], append([]byte(edit.Text), out[edit.To:]...)...)
+	}
+	return out
+}
+
+// renderJSONEntry returns the text of an entry whose lines after the first start with
+// prefix: raw itself if the entry did not change, or else raw's other keys with the entry's
+func renderJSONEntry(raw json.RawMessage, entry ServerEntry, prefix, indent string) (string, error) {
+	fields := make(map[string]json.RawMessage)
+	if raw != nil {
+		var current ServerEntry
+		if err := json.Unmarshal(raw, &current); err == nil && sameEntry(current, entry) {
+			return string(raw), nil
+		}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			fields = make(map[string]json.RawMessage)
+		}
+		for _, key := range managedJSONKeys {
+			delete(fields, key)
+		}
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return "", err
+	}
+	text, err := json.MarshalIndent(fields, prefix, indent)
+	return string(text), err
+}
+
+// jsonObjectMembers returns the members of the JSON object in data, in file order, and the
+// offset of its closing brace
+func jsonObjectMembers(data []byte) ([]jsonMember, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	token, err := dec.Token()
+	if err != nil {
+		return nil, 0, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, 0, fmt.Errorf("expected a JSON object")
+	}
+
+	var members []jsonMember
+	for dec.More() {
+		keyStart := skipJSONSeparators(data, int(dec.InputOffset()))
+		token, err := dec.Token()
+		if err != nil {
+			return nil, 0, err
+		}
+		member := jsonMember{Key: token.(string), KeyStart: keyStart}
+		// The decoder has not consumed the colon yet
+		member.Start = skipJSONSeparators(data, int(dec.InputOffset()))
+		if err := dec.Decode(&member.Value); err != nil {
+			return nil, 0, err
+		}
+		member.End = int(dec.InputOffset())
+		members = append(members, member)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, 0, err
+	}
+	return members, int(dec.InputOffset()) - 1, nil
+}
+
+// skipJSONSeparators returns the offset of the first byte from pos that is not whitespace,
+// a colon or a comma
+func skipJSONSeparators(data []byte, pos int) int {
+	for pos < len(data) && strings.IndexByte(" \t\r\n:,", data[pos]) >= 0 {
+		pos++
+	}
+	return pos
+}
+
+// detectJSONIndent returns the indentation of the first indented line, or two spaces
+func detectJSONIndent(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n")[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "  "
+}
+
+// sortedServerNames returns the names of servers in order
+func sortedServerNames(servers map[string]ServerEntry) []string {
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,136 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/Positronikal/MCPManager/internal/core/clients"
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// ClaudeCodeProjectFile is the file at the root of a project that holds the project's
+// shared Claude Code servers
+const ClaudeCodeProjectFile = ".mcp.json"
+
+// ClaudeCodeDiscovery discovers the servers configured for Claude Code: user servers in
+// ~/.claude.json, local servers in that file's per-project sections, and project servers
+// in the .mcp.json file of each project Claude Code has opened
+type ClaudeCodeDiscovery struct {
+	pathResolver platform.PathResolver
+	clients      *clients.Registry
+	eventBus     *events.EventBus
+}
+
+// NewClaudeCodeDiscovery creates a new Claude Code discovery instance
+func NewClaudeCodeDiscovery(pathResolver platform.PathResolver, eventBus *events.EventBus) *ClaudeCodeDiscovery {
+	return &ClaudeCodeDiscovery{
+		pathResolver: pathResolver,
+		clients:      clients.NewRegistry(),
+		eventBus:     eventBus,
+	}
+}
+
+// claudeCodeConfig is the part of ~/.claude.json that configures servers per project
+// User servers are read with decodeServers
+type claudeCodeConfig struct {
+	Projects map[string]claudeCodeProject `json:"projects"` // Project directory -> settings
+}
+
+// claudeCodeProjectSection returns the keys of the object in ~/.claude.json that holds the
+// local servers of the project in dir, as it is spelled in the file
+func claudeCodeProjectSection(dir string) []string {
+	return []string{"projects", dir, "mcpServers"}
+}
+
+// claudeCodeProject is the MCP part of a project's section of ~/.claude.json
+type claudeCodeProject struct {
+	MCPServers             map[string]ServerConfig `json:"mcpServers"`
+	DisabledMcpjsonServers []string                `json:"disabledMcpjsonServers"` // .mcp.json servers the user rejected
+}
+
+// discover discovers servers from each Claude Code config file, recording what it examined in report
+func (ccd *ClaudeCodeDiscovery) discover(report *SourceReport) ([]models.MCPServer, error) {
+	var allServers []models.MCPServer
+
+	for _, file := range ccd.clients.ConfigFiles(ccd.pathResolver) {
+		if file.Client.ID != clients.ClaudeCodeID {
+			continue
+		}
+		servers, err := ccd.discoverFromFile(file.Path, file.Client, report)
+		if err != nil {
+			logConfigError(file.Client, file.Path, err)
+			continue
+		}
+		allServers = append(allServers, servers...)
+	}
+
+	publishDiscovered(ccd.eventBus, allServers)
+
+	return allServers, nil
+}
+
+// discoverFromFile discovers the user and local servers in a ~/.claude.json, then the
+// project servers of every project it lists
+func (ccd *ClaudeCodeDiscovery) discoverFromFile(configPath string, client models.ClientDefinition, report *SourceReport) ([]models.MCPServer, error) {
+	data, err := readConfigFile(configPath, report)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	entries, err := decodeServers(data, client)
+	var config claudeCodeConfig
+	if err == nil {
+		err = json.Unmarshal(data, &config)
+	}
+	if err != nil {
+		report.ParseError(configPath, data, err)
+		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	servers := serversFromEntries(entries, client, configPath, models.DiscoveryClaudeCode, report)
+	return append(servers, ccd.discoverProjects(config, client, configPath, report)...), nil
+}
+
+// discoverProjects discovers the local and project servers of each project, in path order
+// Local servers come first, as Claude Code prefers them over project servers of the same name
+func (ccd *ClaudeCodeDiscovery) discoverProjects(config claudeCodeConfig, client models.ClientDefinition, configPath string, report *SourceReport) []models.MCPServer {
+	dirs := make([]string, 0, len(config.Projects))
+	for dir := range config.Projects {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var servers []models.MCPServer
+	for _, dir := range dirs {
+		project := config.Projects[dir]
+		local := serversFromEntries(project.MCPServers, client, configPath, models.DiscoveryClaudeCode, report)
+		for i := range local {
+			local[i].ConfigSection = claudeCodeProjectSection(dir)
+		}
+		servers = append(servers, local...)
+
+		projectFile := filepath.Join(filepath.FromSlash(dir), ClaudeCodeProjectFile)
+		data, err := readConfigFile(projectFile, report)
+		if err != nil || data == nil {
+			continue
+		}
+		entries, err := decodeServers(data, client)
+		if err != nil {
+			report.ParseError(projectFile, data, err)
+			continue
+		}
+
+		for _, name := range project.DisabledMcpjsonServers {
+			if _, ok := entries[name]; ok {
+				report.Skip(name, projectFile, "rejected in Claude Code for this project")
+				delete(entries, name)
+			}
+		}
+		servers = append(servers, serversFromEntries(entries, client, projectFile, models.DiscoveryClaudeCode, report)...)
+	}
+	return servers
+}
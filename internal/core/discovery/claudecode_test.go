@@ -0,0 +1,125 @@
+package discovery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+func TestClaudeCodeDiscovery_Scopes(t *testing.T) {
+	tmpDir := t.TempDir()
+	project := filepath.Join(tmpDir, "work", "app")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	userConfig := fmt.Sprintf(`{
+  "numStartups": 12,
+  "mcpServers": {"search": {"type": "stdio", "command": "search-server"}},
+  "projects": {
+    %q: {
+      "allowedTools": [],
+      "mcpServers": {"db": {"command": "db-server", "args": ["--local"]}},
+      "disabledMcpjsonServers": ["untrusted"]
+    },
+    %q: {}
+  }
+}`, filepath.ToSlash(project), filepath.Join(tmpDir, "gone"))
+	configPath := filepath.Join(tmpDir, ".claude.json")
+	if err := os.WriteFile(configPath, []byte(userConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	projectConfig := `{"mcpServers": {
+  "docs": {"command": "npx", "args": ["-y", "docs-server"]},
+  "untrusted": {"command": "curl"},
+  "remote": {"type": "http", "url": "https://example.com/mcp"}
+}}`
+	projectPath := filepath.Join(project, ClaudeCodeProjectFile)
+	if err := os.WriteFile(projectPath, []byte(projectConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	discovery := NewClaudeCodeDiscovery(&MockPathResolver{configDir: tmpDir}, eventBus)
+
+	report := &SourceReport{}
+	servers, err := discovery.Discover(report)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	var found []string
+	for _, server := range servers {
+		found = append(found, server.Name+"@"+filepath.Base(server.ConfigFile))
+		if server.Source != models.DiscoveryClaudeCode || server.Client != "Claude Code" {
+			t.Errorf("Expected %s to be attributed to Claude Code, got %s, %q", server.Name, server.Source, server.Client)
+		}
+	}
+	if got := strings.Join(found, ","); got != "search@.claude.json,db@.claude.json,docs@.mcp.json" {
+		t.Errorf("Expected user, local then project servers, got %s", got)
+	}
+
+	// Only the local server lives outside the root key of its file
+	if len(servers) == 3 {
+		section := strings.Join(servers[1].ConfigSection, " ")
+		if want := "projects " + filepath.ToSlash(project) + " mcpServers"; section != want {
+			t.Errorf("Expected local server section %q, got %q", want, section)
+		}
+		if servers[0].ConfigSection != nil || servers[2].ConfigSection != nil {
+			t.Errorf("Expected no section for user and project servers, got %q and %q", servers[0].ConfigSection, servers[2].ConfigSection)
+		}
+	}
+
+	skipped := make(map[string]string)
+	for _, entry := range report.Skipped {
+		skipped[entry.Name] = entry.Reason
+	}
+	if !strings.Contains(skipped["untrusted"], "rejected") {
+		t.Errorf("Expected the rejected project server to be skipped, got %q", skipped["untrusted"])
+	}
+	if !strings.Contains(skipped["remote"], "remote server") {
+		t.Errorf("Expected the remote project server to be skipped, got %q", skipped["remote"])
+	}
+
+	// The project without a .mcp.json is still examined
+	missing := 0
+	for _, file := range report.Files {
+		if file.Missing {
+			missing++
+		}
+	}
+	if len(report.Files) != 3 || missing != 1 {
+		t.Errorf("Expected ~/.claude.json and two project files, one missing, got %+v", report.Files)
+	}
+}
+
+func TestClaudeCodeDiscovery_ProjectParseError(t *testing.T) {
+	tmpDir := t.TempDir()
+	userConfig := fmt.Sprintf(`{"mcpServers": {"search": {"command": "search-server"}}, "projects": {%q: {}}}`, filepath.ToSlash(tmpDir))
+	if err := os.WriteFile(filepath.Join(tmpDir, ".claude.json"), []byte(userConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ClaudeCodeProjectFile), []byte("{\n  \"mcpServers\": {,}\n}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	discovery := NewClaudeCodeDiscovery(&MockPathResolver{configDir: tmpDir}, nil)
+	report := &SourceReport{}
+	servers, err := discovery.Discover(report)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if len(servers) != 1 || servers[0].Name != "search" {
+		t.Errorf("Expected the user server despite the broken project file, got %+v", servers)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Line != 2 {
+		t.Errorf("Expected a parse error on line 2 of the project file, got %+v", report.Errors)
+	}
+}
@@ -3,30 +3,48 @@ package discovery
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
-	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/Positronikal/MCPManager/internal/core/clients"
 	"github.com/Positronikal/MCPManager/internal/core/events"
 	"github.com/Positronikal/MCPManager/internal/models"
 	"github.com/Positronikal/MCPManager/internal/platform"
 )
 
-// ClientConfigDiscovery discovers MCP servers from client configuration files
+// ClientConfigDiscovery discovers MCP servers from the config files of the clients in its registry
 type ClientConfigDiscovery struct {
 	pathResolver platform.PathResolver
+	clients      *clients.Registry
 	eventBus     *events.EventBus
 }
 
-// NewClientConfigDiscovery creates a new client config discovery instance
+// NewClientConfigDiscovery creates a new client config discovery instance for the built-in clients
 func NewClientConfigDiscovery(pathResolver platform.PathResolver, eventBus *events.EventBus) *ClientConfigDiscovery {
 	return &ClientConfigDiscovery{
 		pathResolver: pathResolver,
+		clients:      clients.NewRegistry(),
 		eventBus:     eventBus,
 	}
 }
 
+// dedicatedClients maps the clients whose config files are read by sources of their own to
+// those sources
+var dedicatedClients = map[string]models.DiscoverySource{
+	clients.ClaudeCodeID: models.DiscoveryClaudeCode,
+	clients.CodexID:      models.DiscoveryCodex,
+	clients.VSCodeID:     models.DiscoveryVSCode,
+}
+
+// customClient reads config files that belong to no known client
+var customClient = models.ClientDefinition{ID: "custom", Name: "custom", Format: models.ClientFormatJSON, RootKey: "mcpServers"}
+
 // ClientConfig represents the structure of a client configuration file
+// Clients whose root key is not mcpServers are read with decodeServers
 type ClientConfig struct {
 	MCPServers map[string]ServerConfig `json:"mcpServers"`
 }
@@ -34,6 +52,8 @@ type ClientConfig struct {
 // ServerConfig represents a single server configuration
 type ServerConfig struct {
 	Command  string                 `json:"command"`
+	Type     string                 `json:"type,omitempty"` // Transport of url-based entries, e.g. "http" or "sse"
+	URL      string                 `json:"url,omitempty"`  // Endpoint of a remote server
 	Args     []string               `json:"args,omitempty"`
 	Env      map[string]string      `json:"env,omitempty"`
 	Enabled  *bool                  `json:"enabled,omitempty"`
@@ -50,95 +70,111 @@ func (sc *ServerConfig) IsEnabled() bool {
 
 // DiscoverFromClientConfigs discovers servers from all known client config files
 func (ccd *ClientConfigDiscovery) DiscoverFromClientConfigs() ([]models.MCPServer, error) {
+	return ccd.discover(nil)
+}
+
+// discover discovers servers from all known client config files, recording what it examined in report
+func (ccd *ClientConfigDiscovery) discover(report *SourceReport) ([]models.MCPServer, error) {
 	var allServers []models.MCPServer
 
-	// Get config directory
-	configDir := ccd.pathResolver.GetConfigDir()
-	fmt.Printf("  Config directory: %s\n", configDir)
-	if configDir == "" {
-		return allServers, fmt.Errorf("could not determine config directory")
-	}
-
-	// Define known client config paths
-	configPaths := []struct {
-		name string
-		path string
-	}{
-		{
-			name: "Claude Desktop",
-			path: filepath.Join(configDir, "Claude", "claude_desktop_config.json"),
-		},
-		{
-			name: "Cursor",
-			path: filepath.Join(configDir, "Cursor", "mcp_config.json"),
-		},
-	}
-
-	// Discover from each config file
-	for _, cfg := range configPaths {
-		fmt.Printf("  Checking %s config: %s\n", cfg.name, cfg.path)
-		servers, err := ccd.discoverFromFile(cfg.path, cfg.name)
+	// Discover from each config file of each known client
+	for _, file := range ccd.clients.ConfigFiles(ccd.pathResolver) {
+		if _, dedicated := dedicatedClients[file.Client.ID]; dedicated {
+			continue
+		}
+		servers, err := ccd.discoverFromFile(file.Path, file.Client, report)
 		if err != nil {
-			fmt.Printf("    ERROR: %v\n", err)
-			// Log warning but continue with other files
-			// In production, this would use proper logging
+			// Recorded in the report; continue with the other files
+			logConfigError(file.Client, file.Path, err)
 			continue
 		}
-		fmt.Printf("    Found %d servers\n", len(servers))
 		allServers = append(allServers, servers...)
 	}
 
 	// Publish discovery events
-	for i := range allServers {
-		if ccd.eventBus != nil {
-			ccd.eventBus.Publish(events.ServerDiscoveredEvent(&allServers[i]))
-		}
-	}
+	publishDiscovered(ccd.eventBus, allServers)
 
 	return allServers, nil
 }
 
 // discoverFromFile discovers servers from a specific config file
-func (ccd *ClientConfigDiscovery) discoverFromFile(configPath, clientName string) ([]models.MCPServer, error) {
-	var servers []models.MCPServer
+func (ccd *ClientConfigDiscovery) discoverFromFile(configPath string, client models.ClientDefinition, report *SourceReport) ([]models.MCPServer, error) {
+	return discoverClientFile(configPath, client, models.DiscoveryClientConfig, report)
+}
+
+// discoverClientFile discovers servers from a client config file, attributing them to source
+func discoverClientFile(configPath string, client models.ClientDefinition, source models.DiscoverySource, report *SourceReport) ([]models.MCPServer, error) {
+	data, err := readConfigFile(configPath, report)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	entries, err := decodeServers(data, client)
+	if err != nil {
+		report.ParseError(configPath, data, err)
+		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	return serversFromEntries(entries, client, configPath, source, report), nil
+}
 
+// readConfigFile reads a client config file, recording it in report
+// A missing file is not an error; it has no data
+func readConfigFile(configPath string, report *SourceReport) ([]byte, error) {
 	// Check if file exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		// File doesn't exist - not an error, just no servers from this source
-		fmt.Printf("    File does not exist\n")
-		return servers, nil
+		report.Examined(configPath, true)
+		return nil, nil
 	}
 
-	fmt.Printf("    File exists, reading...\n")
+	report.Examined(configPath, false)
 
 	// Read file
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return servers, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+		report.Error(configPath, err)
+		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
 	}
 
-	fmt.Printf("    Read %d bytes\n", len(data))
+	slog.Debug("[DISCOVERY] Read client config", "path", configPath, "bytes", len(data))
+	return data, nil
+}
+
+// serversFromEntries turns the entries of a client config file into servers attributed to
+// the client and source, skipping disabled and remote entries
+func serversFromEntries(entries map[string]ServerConfig, client models.ClientDefinition, configPath string, source models.DiscoverySource, report *SourceReport) []models.MCPServer {
+	var servers []models.MCPServer
 
-	// Parse JSON
-	var config ClientConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return servers, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
 	}
-
-	fmt.Printf("    Parsed JSON, found %d server entries\n", len(config.MCPServers))
+	sort.Strings(names)
 
 	// Extract servers
-	for name, serverCfg := range config.MCPServers {
-		fmt.Printf("      Server: %s (command: %s, enabled: %v)\n", name, serverCfg.Command, serverCfg.IsEnabled())
-
+	for _, name := range names {
+		serverCfg := entries[name]
 		// Skip disabled servers
 		if !serverCfg.IsEnabled() {
-			fmt.Printf("        Skipped (disabled)\n")
+			report.Skip(name, configPath, fmt.Sprintf("disabled in the %s config", client.Name))
+			continue
+		}
+
+		// Remote servers have no process to manage
+		if serverCfg.Command == "" && serverCfg.URL != "" {
+			reason := fmt.Sprintf("remote server at %s; only local servers are managed", serverCfg.URL)
+			if !client.SupportsURL {
+				reason = fmt.Sprintf("%s does not support url-based entries", client.Name)
+			}
+			report.Skip(name, configPath, reason)
 			continue
 		}
 
 		// Create server model
-		server := models.NewMCPServer(name, serverCfg.Command, models.DiscoveryClientConfig)
+		server := models.NewMCPServer(name, serverCfg.Command, source)
+		server.Client = client.Name
+		server.ConfigFile = configPath
 
 		// Set configuration from client config
 		server.Configuration.CommandLineArguments = serverCfg.Args
@@ -150,35 +186,119 @@ func (ccd *ClientConfigDiscovery) discoverFromFile(configPath, clientName string
 		}
 
 		// Detect transport type based on command
-		server.Transport = ccd.detectTransport(serverCfg.Command)
-
-		fmt.Printf("        Added to server list (transport: %s)\n", server.Transport)
+		server.Transport = detectTransport(serverCfg.Command)
 		servers = append(servers, *server)
 	}
 
-	return servers, nil
+	return servers
 }
 
-// GetConfigPaths returns all known client config paths
-func (ccd *ClientConfigDiscovery) GetConfigPaths() []string {
-	configDir := ccd.pathResolver.GetConfigDir()
-	if configDir == "" {
-		return []string{}
+// logConfigError logs a client config file that could not be read or parsed
+func logConfigError(client models.ClientDefinition, configPath string, err error) {
+	slog.Warn("[DISCOVERY] Failed to discover servers from client config", "client", client.Name, "path", configPath, "error", err)
+}
+
+// publishDiscovered publishes a discovery event for each server
+func publishDiscovered(eventBus *events.EventBus, servers []models.MCPServer) {
+	if eventBus == nil {
+		return
+	}
+	for i := range servers {
+		eventBus.Publish(events.ServerDiscoveredEvent(&servers[i]))
 	}
+}
 
-	return []string{
-		filepath.Join(configDir, "Claude", "claude_desktop_config.json"),
-		filepath.Join(configDir, "Cursor", "mcp_config.json"),
+// GetConfigPaths returns all known client config paths
+func (ccd *ClientConfigDiscovery) GetConfigPaths() []string {
+	paths := []string{}
+	for _, file := range ccd.clients.ConfigFiles(ccd.pathResolver) {
+		paths = append(paths, file.Path)
 	}
+	return paths
 }
 
 // DiscoverFromPath discovers servers from a specific config file path
+// The file is read with the layout of the client that owns it, if any
 func (ccd *ClientConfigDiscovery) DiscoverFromPath(configPath string) ([]models.MCPServer, error) {
-	return ccd.discoverFromFile(configPath, "custom")
+	client, ok := ccd.clients.ClientForPath(ccd.pathResolver, configPath)
+	if !ok {
+		client = customClient
+	}
+	if client.ID == clients.VSCodeID {
+		return discoverVSCodeFile(configPath, client, nil)
+	}
+	if source, dedicated := dedicatedClients[client.ID]; dedicated {
+		return discoverClientFile(configPath, client, source, nil)
+	}
+	return ccd.discoverFromFile(configPath, client, nil)
+}
+
+// decodeServers decodes the server entries held under the client's root key in a config file
+func decodeServers(data []byte, client models.ClientDefinition) (map[string]ServerConfig, error) {
+	if client.Format == models.ClientFormatTOML {
+		return decodeTOMLServers(data, client.RootPath())
+	}
+
+	var entries map[string]ServerConfig
+	if err := decodeJSONPath(data, client.Format, client.RootPath(), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// decodeJSONPath decodes the value at path in a JSON or JSONC config file into target
+// The file is decoded whole into a struct built for the path, so decoding errors carry
+// offsets into data rather than into the value. JSONC is standardized first, which keeps
+// those offsets
+func decodeJSONPath(data []byte, format models.ClientFormat, path []string, target interface{}) error {
+	if format == models.ClientFormatJSONC {
+		data = clients.StandardizeJSONC(data)
+	}
+
+	documentType := reflect.TypeOf(target).Elem()
+	for i := len(path) - 1; i >= 0; i-- {
+		documentType = reflect.StructOf([]reflect.StructField{{
+			Name: "Value",
+			Type: documentType,
+			Tag:  reflect.StructTag("json:" + strconv.Quote(path[i])),
+		}})
+	}
+	document := reflect.New(documentType)
+	if err := json.Unmarshal(data, document.Interface()); err != nil {
+		return err
+	}
+
+	value := document.Elem()
+	for range path {
+		value = value.Field(0)
+	}
+	reflect.ValueOf(target).Elem().Set(value)
+	return nil
+}
+
+// decodeTOMLServers decodes the [rootKey.<name>] tables of a TOML config file
+func decodeTOMLServers(data []byte, rootPath []string) (map[string]ServerConfig, error) {
+	doc, err := clients.ParseTOML(data)
+	if err != nil {
+		return nil, err
+	}
+	table, _ := doc.Table(rootPath...)
+
+	// The tables are re-encoded as JSON to share ServerConfig's decoding; errors are not
+	// wrapped, as their offsets point into the JSON rather than data
+	encoded, err := json.Marshal(table)
+	if err != nil {
+		return nil, err
+	}
+	var entries map[string]ServerConfig
+	if err := json.Unmarshal(encoded, &entries); err != nil {
+		return nil, fmt.Errorf("invalid %s table: %v", strings.Join(rootPath, "."), err)
+	}
+	return entries, nil
 }
 
 // detectTransport determines the transport type for a server based on command
-func (ccd *ClientConfigDiscovery) detectTransport(command string) models.TransportType {
+func detectTransport(command string) models.TransportType {
 	// Use heuristics based on command
 	cmdLower := strings.ToLower(command)
 
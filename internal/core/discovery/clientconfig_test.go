@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Positronikal/MCPManager/internal/core/clients"
 	"github.com/Positronikal/MCPManager/internal/core/events"
 	"github.com/Positronikal/MCPManager/internal/models"
 )
@@ -180,6 +182,48 @@ func TestClientConfigDiscovery_MalformedJSON(t *testing.T) {
 	}
 }
 
+func TestClientConfigDiscovery_ReportsParseErrorPosition(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	claudeDir := filepath.Join(tmpDir, "Claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A trailing comma on the third line
+	configPath := filepath.Join(claudeDir, "claude_desktop_config.json")
+	data := "{\n  \"mcpServers\": {\n    \"a\": {\"command\": \"node\"},\n  }\n}\n"
+	if err := os.WriteFile(configPath, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := &MockPathResolver{configDir: tmpDir}
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	discovery := NewClientConfigDiscovery(resolver, eventBus)
+
+	report := &SourceReport{}
+	if _, err := discovery.Discover(report); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Errors) != 1 {
+		t.Fatalf("Expected 1 parse error, got %+v", report.Errors)
+	}
+	parseErr := report.Errors[0]
+	if parseErr.File != configPath || parseErr.Line != 4 || parseErr.Column != 3 {
+		t.Errorf("Expected the error at %s:4:3, got %s:%d:%d", configPath, parseErr.File, parseErr.Line, parseErr.Column)
+	}
+
+	examined := false
+	for _, file := range report.Files {
+		examined = examined || (file.Path == configPath && !file.Missing)
+	}
+	if !examined {
+		t.Errorf("Expected %s to be reported as examined, got %+v", configPath, report.Files)
+	}
+}
+
 func TestClientConfigDiscovery_DisabledServer(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -228,6 +272,15 @@ func TestClientConfigDiscovery_DisabledServer(t *testing.T) {
 	if len(servers) > 0 && servers[0].Name != "enabled-server" {
 		t.Error("Should only find enabled-server")
 	}
+
+	// The disabled server is reported as skipped
+	report := &SourceReport{}
+	if _, err := discovery.Discover(report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Name != "disabled-server" || report.Skipped[0].File != configPath {
+		t.Errorf("Expected disabled-server to be skipped, got %+v", report.Skipped)
+	}
 }
 
 func TestClientConfigDiscovery_EventsPublished(t *testing.T) {
@@ -289,8 +342,8 @@ func TestClientConfigDiscovery_GetConfigPaths(t *testing.T) {
 
 	paths := discovery.GetConfigPaths()
 
-	if len(paths) != 2 {
-		t.Errorf("Expected 2 config paths, got %d", len(paths))
+	if len(paths) != 5 {
+		t.Errorf("Expected 5 config paths, got %d", len(paths))
 	}
 
 	// Check paths contain expected components
@@ -301,7 +354,7 @@ func TestClientConfigDiscovery_GetConfigPaths(t *testing.T) {
 		if filepath.Base(path) == "claude_desktop_config.json" {
 			foundClaude = true
 		}
-		if filepath.Base(path) == "mcp_config.json" {
+		if path == filepath.Join(tmpDir, ".cursor", "mcp.json") {
 			foundCursor = true
 		}
 	}
@@ -314,6 +367,58 @@ func TestClientConfigDiscovery_GetConfigPaths(t *testing.T) {
 	}
 }
 
+func TestClientConfigDiscovery_RegistryClients(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A client keeping its servers under "servers", without url support
+	userFile := `{"clients": [{"id": "editor", "name": "Editor", "paths": {"default": ["${HOME}/.editor/mcp.json"]}, "format": "json", "rootKey": "servers"}]}`
+	if err := os.WriteFile(filepath.Join(tmpDir, clients.UserFileName), []byte(userFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err := clients.LoadRegistry(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	editorDir := filepath.Join(tmpDir, ".editor")
+	if err := os.MkdirAll(editorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(editorDir, "mcp.json")
+	data := `{"servers": {"local": {"command": "node", "args": ["server.js"]}, "remote": {"type": "http", "url": "https://example.com/mcp"}}, "mcpServers": {"ignored": {"command": "node"}}}`
+	if err := os.WriteFile(configPath, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := &MockPathResolver{configDir: tmpDir}
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	discovery := NewClientConfigDiscovery(resolver, eventBus)
+	discovery.clients = registry
+
+	report := &SourceReport{}
+	servers, err := discovery.Discover(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(servers) != 1 || servers[0].Name != "local" {
+		t.Fatalf("Expected only the local server under the client's root key, got %+v", servers)
+	}
+	if servers[0].Client != "Editor" || servers[0].ConfigFile != configPath {
+		t.Errorf("Expected the server to be attributed to Editor's %s, got %q, %q", configPath, servers[0].Client, servers[0].ConfigFile)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Name != "remote" || !strings.Contains(report.Skipped[0].Reason, "does not support url-based entries") {
+		t.Errorf("Expected the url entry to be skipped as unsupported, got %+v", report.Skipped)
+	}
+
+	// The file is read with its client's layout when discovered by path
+	servers, err = discovery.DiscoverFromPath(configPath)
+	if err != nil || len(servers) != 1 || servers[0].Name != "local" {
+		t.Errorf("Expected DiscoverFromPath to use the client's root key, got %+v, %v", servers, err)
+	}
+}
+
 func TestClientConfigDiscovery_DiscoverFromPath(t *testing.T) {
 	tmpDir := t.TempDir()
 
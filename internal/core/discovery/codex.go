@@ -0,0 +1,46 @@
+package discovery
+
+import (
+	"github.com/Positronikal/MCPManager/internal/core/clients"
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// CodexDiscovery discovers the servers configured for the Codex CLI in the
+// [mcp_servers.<name>] tables of ~/.codex/config.toml
+type CodexDiscovery struct {
+	pathResolver platform.PathResolver
+	clients      *clients.Registry
+	eventBus     *events.EventBus
+}
+
+// NewCodexDiscovery creates a new Codex CLI discovery instance
+func NewCodexDiscovery(pathResolver platform.PathResolver, eventBus *events.EventBus) *CodexDiscovery {
+	return &CodexDiscovery{
+		pathResolver: pathResolver,
+		clients:      clients.NewRegistry(),
+		eventBus:     eventBus,
+	}
+}
+
+// discover discovers servers from each Codex config file, recording what it examined in report
+func (cd *CodexDiscovery) discover(report *SourceReport) ([]models.MCPServer, error) {
+	var allServers []models.MCPServer
+
+	for _, file := range cd.clients.ConfigFiles(cd.pathResolver) {
+		if file.Client.ID != clients.CodexID {
+			continue
+		}
+		servers, err := discoverClientFile(file.Path, file.Client, models.DiscoveryCodex, report)
+		if err != nil {
+			logConfigError(file.Client, file.Path, err)
+			continue
+		}
+		allServers = append(allServers, servers...)
+	}
+
+	publishDiscovered(cd.eventBus, allServers)
+
+	return allServers, nil
+}
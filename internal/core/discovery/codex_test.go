@@ -0,0 +1,88 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+func TestCodexDiscovery_Discover(t *testing.T) {
+	tmpDir := t.TempDir()
+	codexDir := filepath.Join(tmpDir, ".codex")
+	if err := os.MkdirAll(codexDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := `model = "o3"
+
+# Documentation lookup
+[mcp_servers.docs]
+command = "npx"
+args = ["-y", "docs-server"]
+
+[mcp_servers.docs.env]
+DOCS_TOKEN = "secret"
+
+[mcp_servers.paused]
+command = "paused-server"
+enabled = false
+
+[mcp_servers.remote]
+url = "https://example.com/mcp"
+`
+	configPath := filepath.Join(codexDir, "config.toml")
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	discovery := NewCodexDiscovery(&MockPathResolver{configDir: tmpDir}, nil)
+	report := &SourceReport{}
+	servers, err := discovery.Discover(report)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if len(servers) != 1 {
+		t.Fatalf("Expected 1 server, got %+v", servers)
+	}
+	docs := servers[0]
+	if docs.Name != "docs" || docs.InstallationPath != "npx" || len(docs.Configuration.CommandLineArguments) != 2 {
+		t.Errorf("Unexpected server: %+v", docs)
+	}
+	if docs.Configuration.EnvironmentVariables["DOCS_TOKEN"] != "secret" {
+		t.Errorf("Expected the env subtable to be read, got %v", docs.Configuration.EnvironmentVariables)
+	}
+	if docs.Source != models.DiscoveryCodex || docs.Client != "Codex CLI" || docs.ConfigFile != configPath {
+		t.Errorf("Expected the server to be attributed to the Codex CLI, got %s, %q, %q", docs.Source, docs.Client, docs.ConfigFile)
+	}
+	if len(report.Skipped) != 2 {
+		t.Errorf("Expected the disabled and remote servers to be skipped, got %+v", report.Skipped)
+	}
+}
+
+func TestCodexDiscovery_ParseError(t *testing.T) {
+	tmpDir := t.TempDir()
+	codexDir := filepath.Join(tmpDir, ".codex")
+	if err := os.MkdirAll(codexDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(codexDir, "config.toml"), []byte("[mcp_servers.docs]\ncommand = npx\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	discovery := NewCodexDiscovery(&MockPathResolver{configDir: tmpDir}, nil)
+	report := &SourceReport{}
+	if _, err := discovery.Discover(report); err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if len(report.Errors) != 1 {
+		t.Fatalf("Expected one parse error, got %+v", report.Errors)
+	}
+	if e := report.Errors[0]; e.Line != 2 || e.Column != 11 || !strings.Contains(e.Message, "invalid value") {
+		t.Errorf("Expected an invalid value at 2:11, got %+v", e)
+	}
+}
@@ -2,119 +2,148 @@ package discovery
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Positronikal/MCPManager/internal/core/clients"
 	"github.com/Positronikal/MCPManager/internal/core/events"
 	"github.com/Positronikal/MCPManager/internal/models"
 	"github.com/Positronikal/MCPManager/internal/platform"
+	"github.com/google/uuid"
 )
 
 // DiscoveryService orchestrates all discovery sources
+// Registered sources find servers; running processes are then matched against them
 type DiscoveryService struct {
-	clientConfigDiscovery *ClientConfigDiscovery
-	extensionsDiscovery   *ClaudeExtensionsDiscovery
-	filesystemDiscovery   *FilesystemDiscovery
-	processDiscovery      *ProcessDiscovery
-	configFileWatcher     *ConfigFileWatcher // FR-050: Monitor config files for external changes
-	eventBus              *events.EventBus
-	mu                    sync.RWMutex
-	cachedServers         map[string]*models.MCPServer // serverID -> server
-	lastDiscovery         time.Time
+	sources           []Source                                  // In registration order
+	sourceOverrides   map[string]models.DiscoverySourceOverride // Source name -> user override
+	processDiscovery  *ProcessDiscovery
+	configFileWatcher *ConfigFileWatcher // FR-050: Monitor config files for external changes
+	eventBus          *events.EventBus
+	mu                sync.RWMutex
+	cachedServers     map[string]*models.MCPServer // serverID -> server
+	lastDiscovery     time.Time
+	lastReport        *DiscoveryReport
 }
 
-// NewDiscoveryService creates a new discovery service
+// NewDiscoveryService creates a new discovery service for the built-in clients
 func NewDiscoveryService(pathResolver platform.PathResolver, eventBus *events.EventBus) *DiscoveryService {
+	return NewDiscoveryServiceWithClients(pathResolver, clients.NewRegistry(), eventBus)
+}
+
+// NewDiscoveryServiceWithClients creates a new discovery service that reads and watches the
+// config files of the clients in clientRegistry
+func NewDiscoveryServiceWithClients(pathResolver platform.PathResolver, clientRegistry *clients.Registry, eventBus *events.EventBus) *DiscoveryService {
 	// FR-050: Get config file paths to watch
-	configDir := pathResolver.GetConfigDir()
-	configPaths := []string{
-		fmt.Sprintf("%s/Claude/claude_desktop_config.json", configDir),
-		fmt.Sprintf("%s/Cursor/mcp_config.json", configDir),
+	var configPaths []string
+	for _, file := range clientRegistry.ConfigFiles(pathResolver) {
+		configPaths = append(configPaths, file.Path)
 	}
 
 	// FR-050: Initialize file watcher for client config files
 	watcher, err := NewConfigFileWatcher(eventBus, configPaths)
 	if err != nil {
 		// Log error but continue - file watching is non-critical
-		fmt.Printf("Warning: Failed to create config file watcher: %v\n", err)
+		slog.Warn("[DISCOVERY] Failed to create config file watcher", "error", err)
 	} else {
 		// Start watching
 		if err := watcher.Start(); err != nil {
-			fmt.Printf("Warning: Failed to start config file watcher: %v\n", err)
+			slog.Warn("[DISCOVERY] Failed to start config file watcher", "error", err)
 		}
 	}
 
-	return &DiscoveryService{
-		clientConfigDiscovery: NewClientConfigDiscovery(pathResolver, eventBus),
-		extensionsDiscovery:   NewClaudeExtensionsDiscovery(pathResolver, eventBus),
-		filesystemDiscovery:   NewFilesystemDiscovery(pathResolver, eventBus),
-		processDiscovery:      NewProcessDiscovery(eventBus),
-		configFileWatcher:     watcher,
-		eventBus:              eventBus,
-		cachedServers:         make(map[string]*models.MCPServer),
-		lastDiscovery:         time.Time{},
+	ds := &DiscoveryService{
+		sourceOverrides:   make(map[string]models.DiscoverySourceOverride),
+		processDiscovery:  NewProcessDiscovery(eventBus),
+		configFileWatcher: watcher,
+		eventBus:          eventBus,
+		cachedServers:     make(map[string]*models.MCPServer),
+		lastDiscovery:     time.Time{},
+	}
+
+	clientConfigDiscovery := NewClientConfigDiscovery(pathResolver, eventBus)
+	clientConfigDiscovery.clients = clientRegistry
+	claudeCodeDiscovery := NewClaudeCodeDiscovery(pathResolver, eventBus)
+	claudeCodeDiscovery.clients = clientRegistry
+	codexDiscovery := NewCodexDiscovery(pathResolver, eventBus)
+	codexDiscovery.clients = clientRegistry
+	vscodeDiscovery := NewVSCodeDiscovery(pathResolver, eventBus)
+	vscodeDiscovery.clients = clientRegistry
+
+	// Built-in sources; the names are distinct, so registration cannot fail
+	for _, source := range []Source{
+		clientConfigDiscovery,
+		claudeCodeDiscovery,
+		codexDiscovery,
+		vscodeDiscovery,
+		NewClaudeExtensionsDiscovery(pathResolver, eventBus),
+		NewFilesystemDiscovery(pathResolver, eventBus),
+	} {
+		ds.registerSource(source)
 	}
+
+	return ds
 }
 
 // Discover runs all discovery sources following the spec's three-tier strategy:
-// 1. PRIMARY: Read client config files (Claude Desktop, Cursor, etc.)
+// 1. PRIMARY: Read client config files (Claude Desktop, Cursor, Claude Code, Codex CLI, VS Code, etc.)
 // 2. SECONDARY: Scan filesystem for installed servers (npm, pip, Go binaries)
 // 3. TERTIARY: Match running processes against discovered servers (PID tracking)
 //
-// Per spec research.md §16: "Discovery Sources Priority"
+// Per spec research.md §16: "Discovery Sources Priority". Sources run in priority order,
+// skipping disabled ones, and the highest priority source wins for servers found twice
 func (ds *DiscoveryService) Discover() ([]models.MCPServer, error) {
+	servers, _, err := ds.DiscoverWithReport("")
+	return servers, err
+}
+
+// DiscoverWithReport runs discovery like Discover and also returns a report of what each
+// source examined, the files it could not parse and the entries it skipped
+// The report is published as a discovery.completed event; an empty scanID gets a new one
+func (ds *DiscoveryService) DiscoverWithReport(scanID string) ([]models.MCPServer, *DiscoveryReport, error) {
 	fmt.Println("\n=== MCP SERVER DISCOVERY START ===")
 
+	if scanID == "" {
+		scanID = uuid.New().String()
+	}
+	report := &DiscoveryReport{ScanID: scanID, StartedAt: time.Now()}
+
 	ds.mu.Lock()
 	defer ds.mu.Unlock()
 
-	// Phase 1: Discover from client configs (PRIMARY - highest priority)
-	// FR-002: Read MCP client configuration files without modifying them
-	fmt.Println("\n[PHASE 1] Discovering from client configs...")
-	clientServers, err := ds.clientConfigDiscovery.DiscoverFromClientConfigs()
-	if err != nil {
-		fmt.Printf("[PHASE 1] ERROR: %v\n", err)
-		// Log but continue - client configs may not exist yet
-	} else {
-		fmt.Printf("[PHASE 1] Found %d servers from client configs\n", len(clientServers))
-		for i, srv := range clientServers {
-			fmt.Printf("  [%d] %s (cmd: %s, source: %s)\n", i+1, srv.Name, srv.InstallationPath, srv.Source)
+	// Phases 1 & 2: Discover from every enabled source, highest priority first
+	// FR-001, FR-002: Read client configs without modifying them, scan installation locations
+	var found []sourceServers
+	for _, source := range ds.orderedSources() {
+		info := ds.sourceInfo(source)
+		sourceReport := SourceReport{Source: info.Name, Priority: info.Priority, Enabled: info.Enabled}
+		if !info.Enabled {
+			report.Sources = append(report.Sources, sourceReport)
+			continue
 		}
-	}
 
-	// Phase 1.5: Discover from Claude Extensions (HIGH priority - after client configs)
-	fmt.Println("\n[PHASE 1.5] Discovering from Claude Extensions...")
-	extensionServers, err := ds.extensionsDiscovery.DiscoverFromExtensions()
-	if err != nil {
-		fmt.Printf("[PHASE 1.5] ERROR: %v\n", err)
-		// Log but continue - extensions may not exist
-	} else {
-		fmt.Printf("[PHASE 1.5] Found %d servers from Claude Extensions\n", len(extensionServers))
-		for i, srv := range extensionServers {
-			fmt.Printf("  [%d] %s (cmd: %s, source: %s, version: %s)\n", i+1, srv.Name, srv.InstallationPath, srv.Source, srv.Version)
+		startedAt := time.Now()
+		servers, err := source.Discover(&sourceReport)
+		sourceReport.Duration = time.Since(startedAt)
+		if err != nil {
+			// Record the error in the report but continue - a source's files may not exist yet
+			sourceReport.Error("", err)
+			report.Sources = append(report.Sources, sourceReport)
+			continue
 		}
-	}
 
-	// Phase 2: Discover from filesystem (SECONDARY)
-	// FR-001: Scan common installation locations
-	fmt.Println("\n[PHASE 2] Discovering from filesystem...")
-	filesystemServers, err := ds.filesystemDiscovery.DiscoverFromFilesystem()
-	if err != nil {
-		fmt.Printf("[PHASE 2] ERROR: %v\n", err)
-		// Log but continue - packages may not be installed
-	} else {
-		fmt.Printf("[PHASE 2] Found %d servers from filesystem\n", len(filesystemServers))
-		for i, srv := range filesystemServers {
-			fmt.Printf("  [%d] %s (path: %s, source: %s)\n", i+1, srv.Name, srv.InstallationPath, srv.Source)
-		}
+		slog.Debug("[DISCOVERY] Source discovered servers", "source", info.Name, "priority", info.Priority, "found", len(servers), "duration", sourceReport.Duration)
+		sourceReport.Found = len(servers)
+		report.Sources = append(report.Sources, sourceReport)
+		found = append(found, sourceServers{source: len(report.Sources) - 1, servers: servers})
 	}
 
-	// Merge Phase 1, 1.5, & 2: Create authoritative server list
-	// Priority: client_config > extensions > filesystem
+	// Merge: Create authoritative server list
 	fmt.Println("\n[MERGE] Merging servers from all sources...")
-	allServers := ds.mergeServersByName(clientServers, extensionServers, filesystemServers)
+	allServers := ds.mergeSources(report, found)
 	fmt.Printf("[MERGE] Total unique servers after merge: %d\n", len(allServers))
 
 	// Phase 3: Match running processes against discovered servers (TERTIARY)
@@ -130,6 +159,8 @@ func (ds *DiscoveryService) Discover() ([]models.MCPServer, error) {
 		}
 	}
 	fmt.Printf("[PHASE 3] Matched %d running processes\n", runningCount)
+	report.Servers = len(allServers)
+	report.Running = runningCount
 
 	// Update cache - preserve existing servers and merge new discoveries
 	fmt.Println("\n[CACHE UPDATE] Merging discovered servers into cache...")
@@ -143,13 +174,35 @@ func (ds *DiscoveryService) Discover() ([]models.MCPServer, error) {
 			fmt.Printf("  Server %s already in cache (state: %s), updating with discovery results\n",
 				existingServer.Name, existingServer.Status.State)
 
+			// The lifecycle service owns the runtime state of servers it is managing:
+			// a launch in flight, a tracked process that was not matched by name, or
+			// a crash awaiting restart. Take the refreshed definition, keep status and PID
+			managed := existingServer.Status.State == models.StatusStarting ||
+				(discoveredServer.Status.State != models.StatusRunning &&
+					(existingServer.Status.State == models.StatusError ||
+						(existingServer.Status.State == models.StatusRunning && existingServer.PID != nil)))
+
 			// Preserve PID and status if process is still running but not matched
 			// (e.g., server was stopped manually but process hasn't died yet)
-			if existingServer.Status.State == models.StatusStopped && discoveredServer.Status.State == models.StatusStopped {
+			if managed {
+				discoveredServer.Status = existingServer.Status
+				discoveredServer.PID = existingServer.PID
+				discoveredServer.Launcher = existingServer.Launcher
+				discoveredServer.Sandbox = existingServer.Sandbox
+				discoveredServer.Port = existingServer.Port
+				newCache[serverID] = discoveredServer
+			} else if existingServer.Status.State == models.StatusStopped && discoveredServer.Status.State == models.StatusStopped {
 				// Both stopped - use discovered server
 				newCache[serverID] = discoveredServer
 			} else if discoveredServer.Status.State == models.StatusRunning {
 				// Process found during discovery - use discovered state
+				// A process re-adopted by the lifecycle service is no longer its parent's child
+				if existingServer.Launcher != nil && existingServer.Launcher.Manager &&
+					existingServer.PID != nil && *existingServer.PID == *discoveredServer.PID {
+					discoveredServer.Launcher = existingServer.Launcher
+					discoveredServer.Sandbox = existingServer.Sandbox
+					discoveredServer.Port = existingServer.Port
+				}
 				newCache[serverID] = discoveredServer
 			} else {
 				// No process found - preserve existing state if it's stopped
@@ -170,38 +223,51 @@ func (ds *DiscoveryService) Discover() ([]models.MCPServer, error) {
 
 	ds.cachedServers = newCache
 	ds.lastDiscovery = time.Now()
+	report.Duration = time.Since(report.StartedAt)
+	ds.lastReport = report
+
+	if ds.eventBus != nil {
+		ds.eventBus.Publish(events.DiscoveryCompletedEvent(report.ScanID, report.Servers, report.Errors(), report.SkippedEntries(), report.Duration, report))
+	}
 
 	fmt.Printf("\n=== DISCOVERY COMPLETE: %d total servers ===\n\n", len(allServers))
-	return allServers, nil
+	return allServers, report, nil
 }
 
-// mergeServersByName combines servers from multiple sources with priority handling
-// Priority: client_config > extensions > filesystem
-func (ds *DiscoveryService) mergeServersByName(clientServers, extensionServers, filesystemServers []models.MCPServer) []models.MCPServer {
-	serverMap := make(map[string]*models.MCPServer) // name -> server
-
-	// Add filesystem servers first (lowest priority)
-	for i := range filesystemServers {
-		server := &filesystemServers[i]
-		serverMap[server.Name] = server
-	}
+// LastReport returns the report of the most recent discovery run, or nil if none has run
+func (ds *DiscoveryService) LastReport() *DiscoveryReport {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
 
-	// Add extension servers (medium priority - will override filesystem)
-	for i := range extensionServers {
-		server := &extensionServers[i]
-		serverMap[server.Name] = server
-	}
+	return ds.lastReport
+}
 
-	// Add client config servers (highest priority - will override extensions and filesystem)
-	for i := range clientServers {
-		server := &clientServers[i]
-		serverMap[server.Name] = server
-	}
+// sourceServers is the servers one source found
+type sourceServers struct {
+	source  int // Index of the source in the report's Sources
+	servers []models.MCPServer
+}
 
-	// Convert map back to slice
-	result := make([]models.MCPServer, 0, len(serverMap))
-	for _, server := range serverMap {
-		result = append(result, *server)
+// mergeSources combines servers from multiple sources with priority handling
+// found is in priority order, highest first; the first server found with a name wins, and
+// later ones are recorded as skipped in their source's report
+func (ds *DiscoveryService) mergeSources(report *DiscoveryReport, found []sourceServers) []models.MCPServer {
+	winner := make(map[string]string) // server name -> source
+	result := []models.MCPServer{}
+	for _, set := range found {
+		sourceReport := &report.Sources[set.source]
+		for _, server := range set.servers {
+			if source, seen := winner[server.Name]; seen {
+				reason := fmt.Sprintf("shadowed by %s, which takes precedence", source)
+				if source == sourceReport.Source {
+					reason = "defined more than once; the first definition is used"
+				}
+				sourceReport.Skip(server.Name, server.ConfigFile, reason)
+				continue
+			}
+			winner[server.Name] = sourceReport.Source
+			result = append(result, server)
+		}
 	}
 
 	return result
@@ -249,6 +315,7 @@ func (ds *DiscoveryService) matchProcessesToServers(servers []models.MCPServer)
 			// Found a running process for this server
 			fmt.Printf("    ✓ MATCHED PID %d\n", matchedProcess.PID)
 			server.SetPID(matchedProcess.PID)
+			server.Launcher = ds.findLauncher(matchedProcess, currentPID)
 			server.Status.State = models.StatusRunning
 			server.UpdateLastSeen()
 		} else {
@@ -262,6 +329,16 @@ func (ds *DiscoveryService) matchProcessesToServers(servers []models.MCPServer)
 	return servers
 }
 
+// findLauncher identifies the parent of a matched server process, which for stdio
+// servers is the MCP client that owns its pipes; nil if the parent cannot be determined
+func (ds *DiscoveryService) findLauncher(proc *ProcessInfo, currentPID int) *models.ServerLauncher {
+	ppid, name, ok := platform.ParentProcess(proc.PID)
+	if !ok || ppid <= 0 {
+		return nil
+	}
+	return &models.ServerLauncher{PID: ppid, Name: name, Manager: ppid == currentPID}
+}
+
 // findMatchingProcess finds a process that matches the given server
 func (ds *DiscoveryService) findMatchingProcess(server *models.MCPServer, processes []ProcessInfo, currentPID int) *ProcessInfo {
 	for i := range processes {
@@ -423,16 +500,60 @@ func (ds *DiscoveryService) GetLastDiscoveryTime() time.Time {
 	return ds.lastDiscovery
 }
 
-// UpdateServer updates a server in the cache
+// UpdateServer replaces a server in the cache
+// The cache keeps its own copy, so later changes to server do not leak into it
 func (ds *DiscoveryService) UpdateServer(server *models.MCPServer) {
 	ds.mu.Lock()
 	defer ds.mu.Unlock()
 
 	if server != nil {
-		ds.cachedServers[server.ID] = server
+		serverCopy := *server
+		ds.cachedServers[server.ID] = &serverCopy
 	}
 }
 
+// UpdateServerState stores the runtime state (status, PID, launcher, sandbox and port) of a
+// server in the cache, which owns that state. Lifecycle operations, serialized on the
+// server's queue, load it from the cache before acting and publish every change through
+// here; the copies callers hold are snapshots, not the source of truth. Configuration is
+// left alone, so changes made meanwhile are kept; a server that is not cached yet is added
+func (ds *DiscoveryService) UpdateServerState(server *models.MCPServer) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if server == nil {
+		return
+	}
+
+	cached, exists := ds.cachedServers[server.ID]
+	if !exists {
+		serverCopy := *server
+		ds.cachedServers[server.ID] = &serverCopy
+		return
+	}
+
+	cached.Status = server.Status
+	cached.PID = server.PID
+	cached.Launcher = server.Launcher
+	cached.Sandbox = server.Sandbox
+	cached.Port = server.Port
+}
+
+// UpdateServerConfiguration replaces the configuration of a cached server
+// Returns false if the server is not in the cache
+func (ds *DiscoveryService) UpdateServerConfiguration(serverID string, config models.ServerConfiguration) bool {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	cached, exists := ds.cachedServers[serverID]
+	if !exists {
+		return false
+	}
+
+	cached.Configuration = config
+	return true
+}
+
 // RemoveServer removes a server from the cache
 func (ds *DiscoveryService) RemoveServer(serverID string) {
 	ds.mu.Lock()
@@ -125,6 +125,61 @@ func TestDiscoveryService_UpdateServer(t *testing.T) {
 	}
 }
 
+func TestDiscoveryService_UpdateServerStoresCopy(t *testing.T) {
+	resolver := &MockPathResolver{configDir: t.TempDir()}
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+
+	service := NewDiscoveryService(resolver, eventBus)
+
+	server := models.NewMCPServer("test", "/path", models.DiscoveryClientConfig)
+	service.UpdateServer(server)
+
+	// Changes to the caller's server must not reach the cache without an update
+	server.Status.State = models.StatusRunning
+
+	retrieved, _ := service.GetServerByID(server.ID)
+	if retrieved.Status.State != models.StatusStopped {
+		t.Errorf("Cache should own its copy, got state %s", retrieved.Status.State)
+	}
+}
+
+func TestDiscoveryService_UpdateServerState(t *testing.T) {
+	resolver := &MockPathResolver{configDir: t.TempDir()}
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+
+	service := NewDiscoveryService(resolver, eventBus)
+
+	server := models.NewMCPServer("test", "/path", models.DiscoveryClientConfig)
+	service.UpdateServer(server)
+
+	// A lifecycle operation works on a copy taken before the configuration changed
+	working, _ := service.GetServerByID(server.ID)
+
+	config := server.Configuration
+	config.StartupTimeout = 99
+	if !service.UpdateServerConfiguration(server.ID, config) {
+		t.Fatal("Configuration update should find the cached server")
+	}
+
+	working.Status.State = models.StatusStarting
+	working.SetPID(1234)
+	service.UpdateServerState(working)
+
+	retrieved, _ := service.GetServerByID(server.ID)
+	if retrieved.Status.State != models.StatusStarting || retrieved.PID == nil || *retrieved.PID != 1234 {
+		t.Errorf("Runtime state should be updated, got %s %v", retrieved.Status.State, retrieved.PID)
+	}
+	if retrieved.Configuration.StartupTimeout != 99 {
+		t.Error("Runtime state update must not overwrite the newer configuration")
+	}
+
+	if service.UpdateServerConfiguration("missing", config) {
+		t.Error("Configuration update should fail for unknown servers")
+	}
+}
+
 func TestDiscoveryService_RemoveServer(t *testing.T) {
 	resolver := &MockPathResolver{configDir: t.TempDir()}
 	eventBus := events.NewEventBus()
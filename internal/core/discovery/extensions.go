@@ -75,6 +75,11 @@ type ExtensionSettings struct {
 
 // DiscoverFromExtensions discovers servers from Claude Extensions
 func (ced *ClaudeExtensionsDiscovery) DiscoverFromExtensions() ([]models.MCPServer, error) {
+	return ced.discover(nil)
+}
+
+// discover discovers servers from Claude Extensions, recording what it examined in report
+func (ced *ClaudeExtensionsDiscovery) discover(report *SourceReport) ([]models.MCPServer, error) {
 	var allServers []models.MCPServer
 
 	// Get config directory
@@ -94,8 +99,10 @@ func (ced *ClaudeExtensionsDiscovery) DiscoverFromExtensions() ([]models.MCPServ
 	// Check if extensions directory exists
 	if _, err := os.Stat(extensionsDir); os.IsNotExist(err) {
 		fmt.Printf("  Extensions directory does not exist\n")
+		report.Examined(extensionsDir, true)
 		return allServers, nil
 	}
+	report.Examined(extensionsDir, false)
 
 	// Scan extensions directory
 	entries, err := os.ReadDir(extensionsDir)
@@ -116,7 +123,7 @@ func (ced *ClaudeExtensionsDiscovery) DiscoverFromExtensions() ([]models.MCPServ
 
 		// Read manifest
 		manifestPath := filepath.Join(extensionsDir, extensionID, "manifest.json")
-		manifest, err := ced.readManifest(manifestPath)
+		manifest, err := ced.readManifest(manifestPath, report)
 		if err != nil {
 			fmt.Printf("      ERROR reading manifest: %v\n", err)
 			continue
@@ -127,12 +134,13 @@ func (ced *ClaudeExtensionsDiscovery) DiscoverFromExtensions() ([]models.MCPServ
 		// Check if extension has an MCP server
 		if manifest.Server.MCPConfig.Command == "" {
 			fmt.Printf("      No MCP server configuration found\n")
+			report.Skip(extensionID, manifestPath, "the extension has no MCP server command")
 			continue
 		}
 
 		// Read settings
 		settingsPath := filepath.Join(settingsDir, extensionID+".json")
-		settings, err := ced.readSettings(settingsPath)
+		settings, err := ced.readSettings(settingsPath, report)
 		if err != nil {
 			fmt.Printf("      WARNING: Could not read settings: %v (treating as enabled)\n", err)
 			// Default to enabled if settings don't exist
@@ -144,6 +152,7 @@ func (ced *ClaudeExtensionsDiscovery) DiscoverFromExtensions() ([]models.MCPServ
 		// Skip disabled extensions
 		if !settings.IsEnabled {
 			fmt.Printf("      Skipped (disabled)\n")
+			report.Skip(extensionID, settingsPath, "disabled in Claude's extension settings")
 			continue
 		}
 
@@ -166,14 +175,17 @@ func (ced *ClaudeExtensionsDiscovery) DiscoverFromExtensions() ([]models.MCPServ
 }
 
 // readManifest reads and parses an extension manifest.json file
-func (ced *ClaudeExtensionsDiscovery) readManifest(manifestPath string) (*ExtensionManifest, error) {
+func (ced *ClaudeExtensionsDiscovery) readManifest(manifestPath string, report *SourceReport) (*ExtensionManifest, error) {
 	data, err := os.ReadFile(manifestPath)
+	report.Examined(manifestPath, os.IsNotExist(err))
 	if err != nil {
+		report.Error(manifestPath, err)
 		return nil, fmt.Errorf("failed to read manifest: %w", err)
 	}
 
 	var manifest ExtensionManifest
 	if err := json.Unmarshal(data, &manifest); err != nil {
+		report.ParseError(manifestPath, data, err)
 		return nil, fmt.Errorf("failed to parse manifest: %w", err)
 	}
 
@@ -181,20 +193,24 @@ func (ced *ClaudeExtensionsDiscovery) readManifest(manifestPath string) (*Extens
 }
 
 // readSettings reads and parses an extension settings file
-func (ced *ClaudeExtensionsDiscovery) readSettings(settingsPath string) (*ExtensionSettings, error) {
+func (ced *ClaudeExtensionsDiscovery) readSettings(settingsPath string, report *SourceReport) (*ExtensionSettings, error) {
 	// Check if file exists
 	if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
 		// Settings don't exist - treat as enabled by default
+		report.Examined(settingsPath, true)
 		return &ExtensionSettings{IsEnabled: true}, nil
 	}
+	report.Examined(settingsPath, false)
 
 	data, err := os.ReadFile(settingsPath)
 	if err != nil {
+		report.Error(settingsPath, err)
 		return nil, fmt.Errorf("failed to read settings: %w", err)
 	}
 
 	var settings ExtensionSettings
 	if err := json.Unmarshal(data, &settings); err != nil {
+		report.ParseError(settingsPath, data, err)
 		return nil, fmt.Errorf("failed to parse settings: %w", err)
 	}
 
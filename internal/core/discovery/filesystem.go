@@ -28,36 +28,44 @@ func NewFilesystemDiscovery(pathResolver platform.PathResolver, eventBus *events
 
 // DiscoverFromFilesystem discovers servers from NPM, Python, and Go installations
 func (fd *FilesystemDiscovery) DiscoverFromFilesystem() ([]models.MCPServer, error) {
+	return fd.discover(nil)
+}
+
+// discover discovers servers from NPM, Python, and Go installations, recording what it examined in report
+func (fd *FilesystemDiscovery) discover(report *SourceReport) ([]models.MCPServer, error) {
 	allServers := []models.MCPServer{}
 
 	// Discover from NPM global packages
 	fmt.Println("  Scanning NPM global packages...")
-	npmServers, err := fd.discoverNPMServers()
+	npmServers, err := fd.discoverNPMServers(report)
 	if err == nil {
 		fmt.Printf("    Found %d NPM servers\n", len(npmServers))
 		allServers = append(allServers, npmServers...)
 	} else {
 		fmt.Printf("    NPM scan error: %v\n", err)
+		report.Warn("NPM scan: %v", err)
 	}
 
 	// Discover from Python site-packages
 	fmt.Println("  Scanning Python site-packages...")
-	pythonServers, err := fd.discoverPythonServers()
+	pythonServers, err := fd.discoverPythonServers(report)
 	if err == nil {
 		fmt.Printf("    Found %d Python servers\n", len(pythonServers))
 		allServers = append(allServers, pythonServers...)
 	} else {
 		fmt.Printf("    Python scan error: %v\n", err)
+		report.Warn("Python scan: %v", err)
 	}
 
 	// Discover from Go binaries
 	fmt.Println("  Scanning Go binaries...")
-	goServers, err := fd.discoverGoServers()
+	goServers, err := fd.discoverGoServers(report)
 	if err == nil {
 		fmt.Printf("    Found %d Go servers\n", len(goServers))
 		allServers = append(allServers, goServers...)
 	} else {
 		fmt.Printf("    Go scan error: %v\n", err)
+		report.Warn("Go scan: %v", err)
 	}
 
 	// Publish discovery events
@@ -71,7 +79,7 @@ func (fd *FilesystemDiscovery) DiscoverFromFilesystem() ([]models.MCPServer, err
 }
 
 // discoverNPMServers discovers MCP servers from NPM global packages
-func (fd *FilesystemDiscovery) discoverNPMServers() ([]models.MCPServer, error) {
+func (fd *FilesystemDiscovery) discoverNPMServers(report *SourceReport) ([]models.MCPServer, error) {
 	var servers []models.MCPServer
 
 	// Get NPM global root
@@ -92,8 +100,10 @@ func (fd *FilesystemDiscovery) discoverNPMServers() ([]models.MCPServer, error)
 	// Check if directory exists
 	if _, err := os.Stat(npmRoot); os.IsNotExist(err) {
 		fmt.Printf("    Directory does not exist\n")
+		report.Examined(npmRoot, true)
 		return servers, nil
 	}
+	report.Examined(npmRoot, false)
 
 	// Scan for MCP server packages
 	entries, err := os.ReadDir(npmRoot)
@@ -121,6 +131,8 @@ func (fd *FilesystemDiscovery) discoverNPMServers() ([]models.MCPServer, error)
 				server := models.NewMCPServer(name, serverPath, models.DiscoveryFilesystem)
 				server.Configuration.CommandLineArguments = []string{}
 				servers = append(servers, *server)
+			} else {
+				report.Skip(name, serverPath, "the package has no package.json")
 			}
 		}
 	}
@@ -129,7 +141,7 @@ func (fd *FilesystemDiscovery) discoverNPMServers() ([]models.MCPServer, error)
 }
 
 // discoverPythonServers discovers MCP servers from Python site-packages
-func (fd *FilesystemDiscovery) discoverPythonServers() ([]models.MCPServer, error) {
+func (fd *FilesystemDiscovery) discoverPythonServers(report *SourceReport) ([]models.MCPServer, error) {
 	var servers []models.MCPServer
 
 	// Get Python site-packages directory
@@ -152,8 +164,10 @@ func (fd *FilesystemDiscovery) discoverPythonServers() ([]models.MCPServer, erro
 
 	// Check if directory exists
 	if _, err := os.Stat(sitePackages); os.IsNotExist(err) {
+		report.Examined(sitePackages, true)
 		return servers, nil
 	}
+	report.Examined(sitePackages, false)
 
 	// Scan for MCP server packages
 	entries, err := os.ReadDir(sitePackages)
@@ -183,7 +197,7 @@ func (fd *FilesystemDiscovery) discoverPythonServers() ([]models.MCPServer, erro
 }
 
 // discoverGoServers discovers MCP servers from Go binaries
-func (fd *FilesystemDiscovery) discoverGoServers() ([]models.MCPServer, error) {
+func (fd *FilesystemDiscovery) discoverGoServers(report *SourceReport) ([]models.MCPServer, error) {
 	var servers []models.MCPServer
 
 	// Determine GOPATH/bin or ~/go/bin
@@ -201,8 +215,10 @@ func (fd *FilesystemDiscovery) discoverGoServers() ([]models.MCPServer, error) {
 
 	// Check if directory exists
 	if _, err := os.Stat(goBinPath); os.IsNotExist(err) {
+		report.Examined(goBinPath, true)
 		return servers, nil
 	}
+	report.Examined(goBinPath, false)
 
 	// Scan for MCP binaries
 	entries, err := os.ReadDir(goBinPath)
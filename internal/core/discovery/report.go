@@ -0,0 +1,151 @@
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Positronikal/MCPManager/internal/core/clients"
+)
+
+// DiscoveryReport explains the outcome of one discovery run, so the user can tell why a
+// server is missing: a source that is disabled or failed, a config file with a syntax
+// error, or an entry skipped or shadowed by another source
+type DiscoveryReport struct {
+	ScanID    string         `json:"scanId"`
+	StartedAt time.Time      `json:"startedAt"`
+	Duration  time.Duration  `json:"duration"`
+	Sources   []SourceReport `json:"sources"` // In the order they ran, highest priority first
+	Servers   int            `json:"servers"` // Unique servers after merging
+	Running   int            `json:"running"` // Servers matched to a running process
+}
+
+// SourceReport is what one source examined and found during a discovery run
+type SourceReport struct {
+	Source   string           `json:"source"`
+	Priority int              `json:"priority"`
+	Enabled  bool             `json:"enabled"`
+	Duration time.Duration    `json:"duration"`
+	Found    int              `json:"found"`
+	Files    []ExaminedFile   `json:"files,omitempty"`
+	Errors   []DiscoveryError `json:"errors,omitempty"`
+	Warnings []string         `json:"warnings,omitempty"` // Problems that did not stop the source, e.g. npm not installed
+	Skipped  []SkippedEntry   `json:"skipped,omitempty"`
+}
+
+// ExaminedFile is a file or directory a source looked at
+type ExaminedFile struct {
+	Path    string `json:"path"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// DiscoveryError is a file a source could not read or parse; Line and Column are 1-based, 0 if unknown
+type DiscoveryError struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// SkippedEntry is a server entry a source found but did not report
+type SkippedEntry struct {
+	Name   string `json:"name"`
+	File   string `json:"file,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Errors counts the errors across all sources
+func (r *DiscoveryReport) Errors() int {
+	count := 0
+	for _, source := range r.Sources {
+		count += len(source.Errors)
+	}
+	return count
+}
+
+// SkippedEntries counts the skipped entries across all sources
+func (r *DiscoveryReport) SkippedEntries() int {
+	count := 0
+	for _, source := range r.Sources {
+		count += len(source.Skipped)
+	}
+	return count
+}
+
+// The recording methods below do nothing on a nil report, so sources can be run without one
+
+// Examined records a file or directory the source looked at
+func (r *SourceReport) Examined(path string, missing bool) {
+	if r == nil {
+		return
+	}
+	r.Files = append(r.Files, ExaminedFile{Path: path, Missing: missing})
+}
+
+// Error records a file the source could not read
+func (r *SourceReport) Error(file string, err error) {
+	if r == nil {
+		return
+	}
+	r.Errors = append(r.Errors, DiscoveryError{File: file, Message: err.Error()})
+}
+
+// ParseError records a JSON or TOML file the source could not parse, locating the error in data
+func (r *SourceReport) ParseError(file string, data []byte, err error) {
+	if r == nil {
+		return
+	}
+	line, column := jsonErrorPosition(data, err)
+	var tomlErr *clients.TOMLError
+	if errors.As(err, &tomlErr) {
+		line, column = tomlErr.Line, tomlErr.Column
+	}
+	r.Errors = append(r.Errors, DiscoveryError{File: file, Line: line, Column: column, Message: err.Error()})
+}
+
+// Warn records a problem that did not stop the source
+func (r *SourceReport) Warn(format string, args ...interface{}) {
+	if r == nil {
+		return
+	}
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Skip records a server entry the source did not report
+func (r *SourceReport) Skip(name, file, reason string) {
+	if r == nil {
+		return
+	}
+	r.Skipped = append(r.Skipped, SkippedEntry{Name: name, File: file, Reason: reason})
+}
+
+// jsonErrorPosition returns the 1-based line and column of a JSON decoding error in data
+// Returns 0, 0 if the error carries no offset
+func jsonErrorPosition(data []byte, err error) (int, int) {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return 0, 0
+	}
+	if offset <= 0 || offset > int64(len(data)) {
+		return 0, 0
+	}
+
+	// The offset points just past the byte that caused the error
+	line, lineStart := 1, 0
+	for i, b := range data[:offset-1] {
+		if b == '\n' {
+			line++
+			lineStart = i + 1
+		}
+	}
+	return line, utf8.RuneCount(data[lineStart:offset])
+}
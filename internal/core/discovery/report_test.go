@@ -0,0 +1,49 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestJSONErrorPosition(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		line   int
+		column int
+	}{
+		{"first line", `{"a": x}`, 1, 7},
+		{"later line", "{\n  \"a\": 1,\n  \"b\": ]\n}", 3, 8},
+		{"trailing comma", "{\n  \"a\": 1,\n}", 3, 1},
+		{"unexpected end", "{\n  \"a\": [1,", 2, 10},
+		{"type mismatch", "{\n  \"mcpServers\": []\n}", 2, 17},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config ClientConfig
+			err := json.Unmarshal([]byte(tt.data), &config)
+			if err == nil {
+				t.Fatal("Expected a decoding error")
+			}
+			line, column := jsonErrorPosition([]byte(tt.data), err)
+			if line != tt.line || column != tt.column {
+				t.Errorf("Expected %d:%d, got %d:%d (%v)", tt.line, tt.column, line, column, err)
+			}
+		})
+	}
+
+	if line, column := jsonErrorPosition([]byte("{}"), fmt.Errorf("not a JSON error")); line != 0 || column != 0 {
+		t.Errorf("Expected no position for other errors, got %d:%d", line, column)
+	}
+}
+
+func TestSourceReport_NilIsNoOp(t *testing.T) {
+	var report *SourceReport
+	report.Examined("/tmp/config.json", false)
+	report.Error("/tmp/config.json", fmt.Errorf("unreadable"))
+	report.ParseError("/tmp/config.json", []byte("{"), fmt.Errorf("bad"))
+	report.Warn("npm not found")
+	report.Skip("server", "/tmp/config.json", "disabled")
+}
@@ -0,0 +1,210 @@
+package discovery
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+// Default priorities of the built-in sources
+// Per spec research.md §16: client configs > Claude Extensions > filesystem
+const (
+	PriorityClientConfig = 300
+	PriorityExtensions   = 200
+	PriorityFilesystem   = 100
+)
+
+// Source finds MCP servers in one place, such as the config files of MCP clients
+// When several sources find a server with the same name, the entry from the source with
+// the highest priority wins. Name, Priority and Enabled are defaults; the user can
+// override the last two per source in the application state
+type Source interface {
+	Name() string                                              // Unique, stable identifier
+	Priority() int                                             // Default priority
+	Enabled() bool                                             // Whether the source runs unless overridden
+	Discover(report *SourceReport) ([]models.MCPServer, error) // Records what it examined in report
+}
+
+// SourceInfo describes a registered source with the user's overrides applied
+type SourceInfo struct {
+	Name            string `json:"name"`
+	Priority        int    `json:"priority"`
+	Enabled         bool   `json:"enabled"`
+	DefaultPriority int    `json:"defaultPriority"`
+	DefaultEnabled  bool   `json:"defaultEnabled"`
+}
+
+// RegisterSource adds a discovery source, which takes part from the next Discover on
+// Returns an error if the name is empty or already registered
+func (ds *DiscoveryService) RegisterSource(source Source) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	return ds.registerSource(source)
+}
+
+// registerSource adds a source; must be called with ds.mu held or before the service is shared
+func (ds *DiscoveryService) registerSource(source Source) error {
+	name := source.Name()
+	if name == "" {
+		return fmt.Errorf("discovery source name cannot be empty")
+	}
+	for _, registered := range ds.sources {
+		if registered.Name() == name {
+			return fmt.Errorf("discovery source already registered: %s", name)
+		}
+	}
+	ds.sources = append(ds.sources, source)
+	return nil
+}
+
+// SetSourceOverrides replaces the user's per-source enable and priority overrides
+// Overrides for sources that are not registered are kept, in case they register later
+func (ds *DiscoveryService) SetSourceOverrides(overrides map[string]models.DiscoverySourceOverride) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.sourceOverrides = make(map[string]models.DiscoverySourceOverride, len(overrides))
+	for name, override := range overrides {
+		ds.sourceOverrides[name] = override
+	}
+}
+
+// Sources describes the registered sources, highest priority first
+func (ds *DiscoveryService) Sources() []SourceInfo {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	infos := make([]SourceInfo, 0, len(ds.sources))
+	for _, source := range ds.orderedSources() {
+		infos = append(infos, ds.sourceInfo(source))
+	}
+	return infos
+}
+
+// sourceInfo applies the user's overrides to a source's defaults; must be called with ds.mu held
+func (ds *DiscoveryService) sourceInfo(source Source) SourceInfo {
+	info := SourceInfo{
+		Name:            source.Name(),
+		Priority:        source.Priority(),
+		Enabled:         source.Enabled(),
+		DefaultPriority: source.Priority(),
+		DefaultEnabled:  source.Enabled(),
+	}
+	if override, exists := ds.sourceOverrides[info.Name]; exists {
+		if override.Priority != nil {
+			info.Priority = *override.Priority
+		}
+		if override.Enabled != nil {
+			info.Enabled = *override.Enabled
+		}
+	}
+	return info
+}
+
+// orderedSources returns the registered sources, highest priority first
+// Sources with equal priority keep their registration order; must be called with ds.mu held
+func (ds *DiscoveryService) orderedSources() []Source {
+	ordered := append([]Source(nil), ds.sources...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ds.sourceInfo(ordered[i]).Priority > ds.sourceInfo(ordered[j]).Priority
+	})
+	return ordered
+}
+
+// Source implementation for client config files
+
+// Name identifies the client config source
+func (ccd *ClientConfigDiscovery) Name() string { return string(models.DiscoveryClientConfig) }
+
+// Priority is the highest of the built-in sources: client configs say how servers actually run
+func (ccd *ClientConfigDiscovery) Priority() int { return PriorityClientConfig }
+
+// Enabled is true by default
+func (ccd *ClientConfigDiscovery) Enabled() bool { return true }
+
+// Discover reads the MCP client configuration files
+func (ccd *ClientConfigDiscovery) Discover(report *SourceReport) ([]models.MCPServer, error) {
+	return ccd.discover(report)
+}
+
+// Source implementation for Claude Code
+
+// Name identifies the Claude Code source
+func (ccd *ClaudeCodeDiscovery) Name() string { return string(models.DiscoveryClaudeCode) }
+
+// Priority is that of the other client configs, which it follows
+func (ccd *ClaudeCodeDiscovery) Priority() int { return PriorityClientConfig }
+
+// Enabled is true by default
+func (ccd *ClaudeCodeDiscovery) Enabled() bool { return true }
+
+// Discover reads ~/.claude.json and the .mcp.json files of the projects it lists
+func (ccd *ClaudeCodeDiscovery) Discover(report *SourceReport) ([]models.MCPServer, error) {
+	return ccd.discover(report)
+}
+
+// Source implementation for the Codex CLI
+
+// Name identifies the Codex CLI source
+func (cd *CodexDiscovery) Name() string { return string(models.DiscoveryCodex) }
+
+// Priority is that of the other client configs, which it follows
+func (cd *CodexDiscovery) Priority() int { return PriorityClientConfig }
+
+// Enabled is true by default
+func (cd *CodexDiscovery) Enabled() bool { return true }
+
+// Discover reads ~/.codex/config.toml
+func (cd *CodexDiscovery) Discover(report *SourceReport) ([]models.MCPServer, error) {
+	return cd.discover(report)
+}
+
+// Source implementation for VS Code
+
+// Name identifies the VS Code source
+func (vd *VSCodeDiscovery) Name() string { return string(models.DiscoveryVSCode) }
+
+// Priority is that of the other client configs, which it follows
+func (vd *VSCodeDiscovery) Priority() int { return PriorityClientConfig }
+
+// Enabled is true by default
+func (vd *VSCodeDiscovery) Enabled() bool { return true }
+
+// Discover reads the user settings.json and the .vscode/mcp.json files of opened workspaces
+func (vd *VSCodeDiscovery) Discover(report *SourceReport) ([]models.MCPServer, error) {
+	return vd.discover(report)
+}
+
+// Source implementation for Claude Extensions
+
+// Name identifies the Claude Extensions source
+func (ced *ClaudeExtensionsDiscovery) Name() string { return string(models.DiscoveryExtension) }
+
+// Priority ranks extensions below client configs and above the filesystem
+func (ced *ClaudeExtensionsDiscovery) Priority() int { return PriorityExtensions }
+
+// Enabled is true by default
+func (ced *ClaudeExtensionsDiscovery) Enabled() bool { return true }
+
+// Discover reads the installed Claude Extensions
+func (ced *ClaudeExtensionsDiscovery) Discover(report *SourceReport) ([]models.MCPServer, error) {
+	return ced.discover(report)
+}
+
+// Source implementation for installed packages
+
+// Name identifies the filesystem source
+func (fd *FilesystemDiscovery) Name() string { return string(models.DiscoveryFilesystem) }
+
+// Priority is the lowest of the built-in sources: an installed package says nothing of how it is run
+func (fd *FilesystemDiscovery) Priority() int { return PriorityFilesystem }
+
+// Enabled is true by default
+func (fd *FilesystemDiscovery) Enabled() bool { return true }
+
+// Discover scans the common installation locations
+func (fd *FilesystemDiscovery) Discover(report *SourceReport) ([]models.MCPServer, error) {
+	return fd.discover(report)
+}
@@ -0,0 +1,215 @@
+package discovery
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+// fakeSource is a discovery source returning fixed servers
+type fakeSource struct {
+	name     string
+	priority int
+	enabled  bool
+	servers  []string // Names of the servers found
+	err      error
+	calls    int
+}
+
+func (f *fakeSource) Name() string  { return f.name }
+func (f *fakeSource) Priority() int { return f.priority }
+func (f *fakeSource) Enabled() bool { return f.enabled }
+
+func (f *fakeSource) Discover(report *SourceReport) ([]models.MCPServer, error) {
+	f.calls++
+	report.Examined("/opt/"+f.name, false)
+	if f.err != nil {
+		return nil, f.err
+	}
+	var servers []models.MCPServer
+	for _, name := range f.servers {
+		server := models.NewMCPServer(name, "/opt/"+f.name+"/"+name, models.DiscoveryFilesystem)
+		servers = append(servers, *server)
+	}
+	return servers, nil
+}
+
+// newSourceTestService returns a discovery service with only the given sources enabled,
+// and the overrides disabling the built-in sources
+func newSourceTestService(t *testing.T, sources ...Source) (*DiscoveryService, map[string]models.DiscoverySourceOverride) {
+	resolver := &MockPathResolver{configDir: t.TempDir()}
+	eventBus := events.NewEventBus()
+	t.Cleanup(eventBus.Close)
+
+	service := NewDiscoveryService(resolver, eventBus)
+	disabled := false
+	overrides := make(map[string]models.DiscoverySourceOverride)
+	for _, info := range service.Sources() {
+		overrides[info.Name] = models.DiscoverySourceOverride{Enabled: &disabled}
+	}
+	service.SetSourceOverrides(overrides)
+
+	for _, source := range sources {
+		if err := service.RegisterSource(source); err != nil {
+			t.Fatalf("RegisterSource failed: %v", err)
+		}
+	}
+	return service, overrides
+}
+
+// commandOf returns the command of the discovered server with the given name
+func commandOf(servers []models.MCPServer, name string) string {
+	for _, server := range servers {
+		if server.Name == name {
+			return server.InstallationPath
+		}
+	}
+	return ""
+}
+
+func TestDiscoveryService_BuiltinSources(t *testing.T) {
+	resolver := &MockPathResolver{configDir: t.TempDir()}
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+
+	service := NewDiscoveryService(resolver, eventBus)
+	var names []string
+	for _, info := range service.Sources() {
+		names = append(names, info.Name)
+		if !info.Enabled {
+			t.Errorf("Expected %s to be enabled by default", info.Name)
+		}
+	}
+	if got := strings.Join(names, ","); got != "client_config,claude_code,codex,vscode,extension,filesystem" {
+		t.Errorf("Expected built-in sources in priority order, got %s", got)
+	}
+}
+
+func TestDiscoveryService_RegisterSource(t *testing.T) {
+	service, _ := newSourceTestService(t, &fakeSource{name: "custom", priority: 50, enabled: true})
+
+	if err := service.RegisterSource(&fakeSource{name: "custom"}); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("Expected duplicate registration to fail, got %v", err)
+	}
+	if err := service.RegisterSource(&fakeSource{}); err == nil {
+		t.Error("Expected a source without a name to be rejected")
+	}
+}
+
+func TestDiscoveryService_SourcePriority(t *testing.T) {
+	low := &fakeSource{name: "low", priority: 10, enabled: true, servers: []string{"shared", "low-only"}}
+	high := &fakeSource{name: "high", priority: 20, enabled: true, servers: []string{"shared"}}
+	service, overrides := newSourceTestService(t, low, high)
+
+	servers, err := service.Discover()
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("Expected 2 servers after merging, got %d", len(servers))
+	}
+	if got := commandOf(servers, "shared"); got != "/opt/high/shared" {
+		t.Errorf("Expected the higher priority source to win, got %s", got)
+	}
+
+	// Raise the low source above the other
+	priority := 30
+	overrides["low"] = models.DiscoverySourceOverride{Priority: &priority}
+	service.SetSourceOverrides(overrides)
+	servers, err = service.Discover()
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if got := commandOf(servers, "shared"); got != "/opt/low/shared" {
+		t.Errorf("Expected the overridden priority to win, got %s", got)
+	}
+
+	// Built-in sources rank higher; low now comes before high
+	infos := service.Sources()
+	last := infos[len(infos)-2:]
+	if last[0].Name != "low" || last[0].Priority != 30 || last[0].DefaultPriority != 10 || last[1].Name != "high" {
+		t.Errorf("Expected low before high with its overridden priority, got %+v", last)
+	}
+}
+
+func TestDiscoveryService_SourceEnabled(t *testing.T) {
+	optIn := &fakeSource{name: "opt-in", priority: 10, enabled: false, servers: []string{"a"}}
+	failing := &fakeSource{name: "failing", priority: 20, enabled: true, err: fmt.Errorf("unreadable")}
+	service, overrides := newSourceTestService(t, optIn, failing)
+
+	servers, err := service.Discover()
+	if err != nil {
+		t.Fatalf("A failing source should not fail discovery: %v", err)
+	}
+	if len(servers) != 0 || optIn.calls != 0 {
+		t.Errorf("Expected a source disabled by default not to run, got %d servers", len(servers))
+	}
+
+	enabled := true
+	overrides["opt-in"] = models.DiscoverySourceOverride{Enabled: &enabled}
+	service.SetSourceOverrides(overrides)
+	servers, err = service.Discover()
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(servers) != 1 || optIn.calls != 1 {
+		t.Errorf("Expected the enabled source to run, got %d servers", len(servers))
+	}
+}
+
+func TestDiscoveryService_Report(t *testing.T) {
+	optIn := &fakeSource{name: "opt-in", priority: 10, enabled: false}
+	low := &fakeSource{name: "low", priority: 20, enabled: true, servers: []string{"shared", "low-only"}}
+	high := &fakeSource{name: "high", priority: 30, enabled: true, servers: []string{"shared"}}
+	failing := &fakeSource{name: "failing", priority: 40, enabled: true, err: fmt.Errorf("unreadable")}
+	service, _ := newSourceTestService(t, optIn, low, high, failing)
+	completedCh := service.eventBus.Subscribe(events.EventDiscoveryCompleted)
+
+	_, report, err := service.DiscoverWithReport("scan-1")
+	if err != nil {
+		t.Fatalf("DiscoverWithReport failed: %v", err)
+	}
+	if report.ScanID != "scan-1" || report.Servers != 2 {
+		t.Errorf("Expected scan-1 with 2 servers, got %s with %d", report.ScanID, report.Servers)
+	}
+
+	sources := make(map[string]SourceReport)
+	for _, source := range report.Sources {
+		sources[source.Source] = source
+	}
+	if source := sources["opt-in"]; source.Enabled || len(source.Files) != 0 {
+		t.Errorf("Expected the disabled source to be reported without running, got %+v", source)
+	}
+	if source := sources["failing"]; len(source.Errors) != 1 || source.Errors[0].Message != "unreadable" {
+		t.Errorf("Expected the source's error to be reported, got %+v", source.Errors)
+	}
+	if source := sources["high"]; source.Found != 1 || len(source.Skipped) != 0 {
+		t.Errorf("Expected high to find 1 server and skip none, got %+v", source)
+	}
+	lowReport := sources["low"]
+	if lowReport.Found != 2 || len(lowReport.Files) != 1 || lowReport.Files[0].Path != "/opt/low" {
+		t.Errorf("Expected low to find 2 servers in /opt/low, got %+v", lowReport)
+	}
+	if len(lowReport.Skipped) != 1 || lowReport.Skipped[0].Name != "shared" || !strings.Contains(lowReport.Skipped[0].Reason, "shadowed by high") {
+		t.Errorf("Expected low's shared server to be shadowed by high, got %+v", lowReport.Skipped)
+	}
+	if report.Errors() != 1 || report.SkippedEntries() != 1 {
+		t.Errorf("Expected 1 error and 1 skipped entry, got %d and %d", report.Errors(), report.SkippedEntries())
+	}
+
+	if service.LastReport() != report {
+		t.Error("Expected the report to be kept as the last report")
+	}
+	select {
+	case event := <-completedCh:
+		if event.Data["scanID"] != "scan-1" || event.Data["errors"] != 1 || event.Data["skipped"] != 1 {
+			t.Errorf("Unexpected discovery.completed event: %v", event.Data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a discovery.completed event")
+	}
+}
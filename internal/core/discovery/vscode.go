@@ -0,0 +1,219 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/Positronikal/MCPManager/internal/core/clients"
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// VSCodeWorkspaceFile is the file in a workspace folder that holds the workspace's VS Code
+// servers, relative to the folder
+const VSCodeWorkspaceFile = ".vscode/mcp.json"
+
+// VSCodeDiscovery discovers the servers configured for VS Code: user servers under mcp in
+// settings.json, and workspace servers in the .vscode/mcp.json file of each folder VS Code
+// has opened
+type VSCodeDiscovery struct {
+	pathResolver platform.PathResolver
+	clients      *clients.Registry
+	eventBus     *events.EventBus
+}
+
+// NewVSCodeDiscovery creates a new VS Code discovery instance
+func NewVSCodeDiscovery(pathResolver platform.PathResolver, eventBus *events.EventBus) *VSCodeDiscovery {
+	return &VSCodeDiscovery{
+		pathResolver: pathResolver,
+		clients:      clients.NewRegistry(),
+		eventBus:     eventBus,
+	}
+}
+
+// vscodeInput is an entry of the inputs array next to servers, which VS Code prompts the
+// user for when a server references it as ${input:<id>}
+type vscodeInput struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Password    bool   `json:"password"`
+}
+
+// vscodeInputPattern matches ${input:<id>} placeholders
+var vscodeInputPattern = regexp.MustCompile(`\$\{input:([^}]+)\}`)
+
+// discover discovers servers from each VS Code settings file and the workspaces opened
+// with it, recording what it examined in report
+func (vd *VSCodeDiscovery) discover(report *SourceReport) ([]models.MCPServer, error) {
+	var allServers []models.MCPServer
+
+	for _, file := range vd.clients.ConfigFiles(vd.pathResolver) {
+		if file.Client.ID != clients.VSCodeID {
+			continue
+		}
+		servers, err := discoverVSCodeFile(file.Path, file.Client, report)
+		if err != nil {
+			logConfigError(file.Client, file.Path, err)
+		} else {
+			allServers = append(allServers, servers...)
+		}
+
+		allServers = append(allServers, vd.discoverWorkspaces(file.Path, report)...)
+	}
+
+	publishDiscovered(vd.eventBus, allServers)
+
+	return allServers, nil
+}
+
+// discoverWorkspaces discovers the servers of each workspace folder opened with the VS Code
+// whose user settings are at settingsPath, in path order
+// Folders without a .vscode/mcp.json are common and are not recorded in report
+func (vd *VSCodeDiscovery) discoverWorkspaces(settingsPath string, report *SourceReport) []models.MCPServer {
+	var servers []models.MCPServer
+	for _, folder := range workspaceFolders(settingsPath) {
+		workspaceFile := filepath.Join(folder, filepath.FromSlash(VSCodeWorkspaceFile))
+		if _, err := os.Stat(workspaceFile); err != nil {
+			continue
+		}
+		client, ok := vd.clients.ProjectClient(workspaceFile)
+		if !ok {
+			continue
+		}
+		found, err := discoverVSCodeFile(workspaceFile, client, report)
+		if err != nil {
+			logConfigError(client, workspaceFile, err)
+			continue
+		}
+		servers = append(servers, found...)
+	}
+	return servers
+}
+
+// workspaceFolders returns the local folders VS Code has opened, in path order
+// VS Code records each in a workspace.json file in the workspaceStorage directory next to
+// the user settings
+func workspaceFolders(settingsPath string) []string {
+	pattern := filepath.Join(filepath.Dir(settingsPath), "workspaceStorage", "*", "workspace.json")
+	matches, _ := filepath.Glob(pattern)
+
+	seen := make(map[string]bool)
+	var folders []string
+	for _, match := range matches {
+		data, err := os.ReadFile(match)
+		if err != nil {
+			continue
+		}
+		var workspace struct {
+			Folder string `json:"folder"` // Unset for multi-root workspaces
+		}
+		if err := json.Unmarshal(data, &workspace); err != nil {
+			continue
+		}
+		folder, ok := folderPath(workspace.Folder)
+		if !ok || seen[folder] {
+			continue
+		}
+		seen[folder] = true
+		folders = append(folders, folder)
+	}
+	sort.Strings(folders)
+	return folders
+}
+
+// folderPath returns the local path of a folder URI, such as file:///home/user/project
+// Remote folders, such as vscode-remote:// ones, have none
+func folderPath(uri string) (string, bool) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" || parsed.Path == "" {
+		return "", false
+	}
+	path := parsed.Path
+	// Windows folders look like file:///c%3A/Users/user/project
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path), true
+}
+
+// discoverVSCodeFile discovers the servers of a VS Code settings.json or .vscode/mcp.json
+// Values that reference an input are not taken literally: the servers require user input
+func discoverVSCodeFile(configPath string, client models.ClientDefinition, report *SourceReport) ([]models.MCPServer, error) {
+	data, err := readConfigFile(configPath, report)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	// The inputs are a sibling of the servers
+	rootPath := client.RootPath()
+	inputsPath := append(append([]string(nil), rootPath[:len(rootPath)-1]...), "inputs")
+
+	entries, err := decodeServers(data, client)
+	var inputs []vscodeInput
+	if err == nil {
+		err = decodeJSONPath(data, client.Format, inputsPath, &inputs)
+	}
+	if err != nil {
+		report.ParseError(configPath, data, err)
+		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	servers := serversFromEntries(entries, client, configPath, models.DiscoveryVSCode, report)
+	for i := range servers {
+		applyInputs(&servers[i], inputs)
+	}
+	return servers, nil
+}
+
+// applyInputs records the inputs a server references as required inputs
+// VS Code prompts for them when it starts the server; MCP Manager cannot, so environment
+// variables and arguments keep the placeholder, along with any text around it such as
+// "Bearer ${input:token}", until the user replaces it with the value
+func applyInputs(server *models.MCPServer, inputs []vscodeInput) {
+	declared := make(map[string]vscodeInput)
+	for _, input := range inputs {
+		declared[input.ID] = input
+	}
+
+	index := make(map[string]int)
+	require := func(id string) *models.ServerInput {
+		if i, ok := index[id]; ok {
+			return &server.RequiredInputs[i]
+		}
+		// Inputs missing from the inputs array are required all the same, undescribed
+		input := declared[id]
+		index[id] = len(server.RequiredInputs)
+		server.RequiredInputs = append(server.RequiredInputs, models.ServerInput{
+			ID:          id,
+			Description: input.Description,
+			Password:    input.Password,
+		})
+		return &server.RequiredInputs[len(server.RequiredInputs)-1]
+	}
+
+	env := server.Configuration.EnvironmentVariables
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, match := range vscodeInputPattern.FindAllStringSubmatch(env[name], -1) {
+			if input := require(match[1]); len(input.Env) == 0 || input.Env[len(input.Env)-1] != name {
+				input.Env = append(input.Env, name)
+			}
+		}
+	}
+
+	for _, arg := range server.Configuration.CommandLineArguments {
+		for _, match := range vscodeInputPattern.FindAllStringSubmatch(arg, -1) {
+			require(match[1])
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+func TestVSCodeDiscovery_Discover(t *testing.T) {
+	tmpDir := t.TempDir()
+	userDir := filepath.Join(tmpDir, "Code", "User")
+	projectDir := filepath.Join(tmpDir, "project")
+	storageDir := filepath.Join(userDir, "workspaceStorage", "4f2c")
+	for _, dir := range []string{storageDir, filepath.Join(projectDir, ".vscode")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	settings := `{
+  // Appearance
+  "editor.fontSize": 14,
+  "mcp": {
+    "inputs": [
+      {"type": "promptString", "id": "github-token", "description": "GitHub token", "password": true},
+    ],
+    "servers": {
+      "github": {
+        "command": "npx",
+        "args": ["-y", "github-server"],
+        "env": {"GITHUB_TOKEN": "${input:github-token}", "AUTHORIZATION": "Bearer ${input:github-token}", "LOG_LEVEL": "info"},
+      },
+      /* Hosted */
+      "remote": {"type": "http", "url": "https://example.com/mcp"},
+    },
+  },
+}
+`
+	settingsPath := filepath.Join(userDir, "settings.json")
+	workspaceFile := filepath.Join(projectDir, ".vscode", "mcp.json")
+	files := map[string]string{
+		settingsPath: settings,
+		filepath.Join(storageDir, "workspace.json"): `{"folder": "file://` + filepath.ToSlash(projectDir) + `"}`,
+		workspaceFile: `{
+  "servers": {
+    // Project database
+    "db": {"type": "stdio", "command": "db-server", "args": ["--key", "${input:db-key}"]}
+  }
+}`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	discovery := NewVSCodeDiscovery(&MockPathResolver{configDir: tmpDir}, nil)
+	report := &SourceReport{}
+	servers, err := discovery.Discover(report)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	var found []string
+	for _, server := range servers {
+		found = append(found, server.Name+"@"+filepath.Base(server.ConfigFile))
+		if server.Source != models.DiscoveryVSCode || server.Client != "VS Code" {
+			t.Errorf("Expected %s to be attributed to VS Code, got %s, %q", server.Name, server.Source, server.Client)
+		}
+	}
+	if got := strings.Join(found, ","); got != "github@settings.json,db@mcp.json" {
+		t.Fatalf("Expected user then workspace servers, got %s", got)
+	}
+
+	github := servers[0]
+	env := github.Configuration.EnvironmentVariables
+	if env["AUTHORIZATION"] != "Bearer ${input:github-token}" || env["LOG_LEVEL"] != "info" {
+		t.Errorf("Expected the values to be kept with their placeholders, got %v", env)
+	}
+	want := models.ServerInput{ID: "github-token", Description: "GitHub token", Password: true, Env: []string{"AUTHORIZATION", "GITHUB_TOKEN"}}
+	if !reflect.DeepEqual(github.RequiredInputs, []models.ServerInput{want}) {
+		t.Errorf("Expected %+v to be required, got %+v", want, github.RequiredInputs)
+	}
+	if missing := github.MissingInputs(); len(missing) != 1 {
+		t.Errorf("Expected the input to be missing until it is replaced, got %+v", missing)
+	}
+
+	// Supplying the value keeps the text around the placeholder
+	env["GITHUB_TOKEN"] = "abc123"
+	env["AUTHORIZATION"] = "Bearer abc123"
+	if missing := github.MissingInputs(); len(missing) != 0 {
+		t.Errorf("Expected no missing inputs once the values are set, got %+v", missing)
+	}
+
+	db := servers[1]
+	if db.ConfigFile != workspaceFile || len(db.MissingInputs()) != 1 || db.MissingInputs()[0].ID != "db-key" {
+		t.Errorf("Expected the workspace server to require the undeclared db-key input, got %+v", db)
+	}
+
+	if len(report.Skipped) != 1 || report.Skipped[0].Name != "remote" {
+		t.Errorf("Expected the remote server to be skipped, got %+v", report.Skipped)
+	}
+}
+
+func TestVSCodeDiscovery_ParseError(t *testing.T) {
+	tmpDir := t.TempDir()
+	userDir := filepath.Join(tmpDir, "Code", "User")
+	if err := os.MkdirAll(userDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	settings := "{\n  // Servers\n  \"mcp\": {\"servers\": {\"a\": {\"command\": 1}}}\n}"
+	if err := os.WriteFile(filepath.Join(userDir, "settings.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := &SourceReport{}
+	servers, _ := NewVSCodeDiscovery(&MockPathResolver{configDir: tmpDir}, nil).Discover(report)
+	if len(servers) != 0 {
+		t.Errorf("Expected no servers, got %+v", servers)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Line != 3 {
+		t.Errorf("Expected a parse error on line 3 of settings.json, got %+v", report.Errors)
+	}
+}
+
+func TestFolderPath(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want string
+		ok   bool
+	}{
+		{"file:///home/user/my%20project", "/home/user/my project", true},
+		{"file:///c%3A/Users/user/project", "c:/Users/user/project", true},
+		{"vscode-remote://ssh-remote%2Bhost/home/user/project", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := folderPath(tt.uri)
+		if ok != tt.ok || got != filepath.FromSlash(tt.want) {
+			t.Errorf("folderPath(%q) = %q, %v; want %q, %v", tt.uri, got, ok, tt.want, tt.ok)
+		}
+	}
+}
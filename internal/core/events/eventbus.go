@@ -11,11 +11,20 @@ import (
 type EventType string
 
 const (
-	EventServerDiscovered     EventType = "server.discovered"
-	EventServerStatusChanged  EventType = "server.status.changed"
-	EventServerLogEntry       EventType = "server.log.entry"
-	EventConfigFileChanged    EventType = "config.file.changed"
-	EventServerMetricsUpdated EventType = "server.metrics.updated"
+	EventServerDiscovered       EventType = "server.discovered"
+	EventServerStatusChanged    EventType = "server.status.changed"
+	EventServerLogEntry         EventType = "server.log.entry"
+	EventConfigFileChanged      EventType = "config.file.changed"
+	EventServerMetricsUpdated   EventType = "server.metrics.updated"
+	EventServerRestartScheduled EventType = "server.restart.scheduled"
+	EventServerQuarantined      EventType = "server.quarantined"
+	EventServerHealthUpdated    EventType = "server.health.updated"
+	EventServerStartupResult    EventType = "server.startup.result"
+	EventStartupCompleted       EventType = "startup.completed"
+	EventBatchProgress          EventType = "batch.progress"
+	EventBatchCompleted         EventType = "batch.completed"
+	EventScheduleMissed         EventType = "schedule.missed"
+	EventDiscoveryCompleted     EventType = "discovery.completed"
 )
 
 // Event represents a generic event in the system
@@ -52,13 +61,31 @@ func ServerStatusChangedEvent(serverID string, oldState, newState models.StatusS
 	})
 }
 
+// ServerExitedStatusEvent creates a server status changed event for a process that exited
+// Carries the exit code, terminating signal and resource usage in addition to the states
+func ServerExitedStatusEvent(serverID string, oldState, newState models.StatusState, exit *models.ProcessExit) *Event {
+	event := ServerStatusChangedEvent(serverID, oldState, newState)
+	if exit != nil {
+		event.Data["exitCode"] = exit.ExitCode
+		event.Data["signal"] = exit.Signal
+		event.Data["userTimeMs"] = exit.UserTime.Milliseconds()
+		event.Data["systemTimeMs"] = exit.SystemTime.Milliseconds()
+		event.Data["maxRss"] = exit.MaxRSS
+	}
+	return event
+}
+
 // ServerLogEntryEvent creates a server log entry event
 func ServerLogEntryEvent(serverID string, entry *models.LogEntry) *Event {
-	return NewEvent(EventServerLogEntry, map[string]interface{}{
+	data := map[string]interface{}{
 		"serverID": serverID,
 		"severity": entry.Severity,
 		"message":  entry.Message,
-	})
+	}
+	if len(entry.Metadata) > 0 {
+		data["metadata"] = entry.Metadata
+	}
+	return NewEvent(EventServerLogEntry, data)
 }
 
 // ConfigFileChangedEvent creates a config file changed event
@@ -76,6 +103,112 @@ func ServerMetricsUpdatedEvent(serverID string, metrics map[string]interface{})
 	})
 }
 
+// ServerRestartScheduledEvent creates a restart scheduled event
+// restartAt lets the UI display a countdown until the next attempt
+func ServerRestartScheduledEvent(serverID string, attempt, maxAttempts int, delay time.Duration, restartAt time.Time) *Event {
+	return NewEvent(EventServerRestartScheduled, map[string]interface{}{
+		"serverID":    serverID,
+		"attempt":     attempt,
+		"maxAttempts": maxAttempts,
+		"delayMs":     delay.Milliseconds(),
+		"restartAt":   restartAt,
+	})
+}
+
+// ServerQuarantinedEvent creates a server quarantined event
+func ServerQuarantinedEvent(serverID string, reason string, crashCount int) *Event {
+	return NewEvent(EventServerQuarantined, map[string]interface{}{
+		"serverID":   serverID,
+		"reason":     reason,
+		"crashCount": crashCount,
+	})
+}
+
+// ServerHealthUpdatedEvent creates a health check result event
+func ServerHealthUpdatedEvent(serverID string, status *models.HealthStatus, previous models.HealthState) *Event {
+	return NewEvent(EventServerHealthUpdated, map[string]interface{}{
+		"serverID":            serverID,
+		"state":               status.State,
+		"previousState":       previous,
+		"consecutiveFailures": status.ConsecutiveFailures,
+		"latencyMs":           status.LastLatency.Milliseconds(),
+		"lastError":           status.LastError,
+	})
+}
+
+// ServerStartupResultEvent creates an event for the outcome of starting one server at launch
+// reason is "autostart" or "restore"; outcome is "started", "failed" or "skipped"
+func ServerStartupResultEvent(serverID, reason, outcome, errorMessage string, duration time.Duration) *Event {
+	return NewEvent(EventServerStartupResult, map[string]interface{}{
+		"serverID":   serverID,
+		"reason":     reason,
+		"outcome":    outcome,
+		"error":      errorMessage,
+		"durationMs": duration.Milliseconds(),
+	})
+}
+
+// StartupCompletedEvent creates an event once every server selected at launch has an outcome
+func StartupCompletedEvent(started, failed, skipped int) *Event {
+	return NewEvent(EventStartupCompleted, map[string]interface{}{
+		"started": started,
+		"failed":  failed,
+		"skipped": skipped,
+	})
+}
+
+// BatchProgressEvent creates an event as each server in a batch operation finishes
+// completed counts the servers finished so far, out of total
+func BatchProgressEvent(batchID, action, serverID string, success bool, errorMessage string, completed, total int) *Event {
+	return NewEvent(EventBatchProgress, map[string]interface{}{
+		"batchID":   batchID,
+		"action":    action,
+		"serverID":  serverID,
+		"success":   success,
+		"error":     errorMessage,
+		"completed": completed,
+		"total":     total,
+	})
+}
+
+// BatchCompletedEvent creates an event once every server in a batch operation has an outcome
+func BatchCompletedEvent(batchID, action string, succeeded, failed int, duration time.Duration) *Event {
+	return NewEvent(EventBatchCompleted, map[string]interface{}{
+		"batchID":    batchID,
+		"action":     action,
+		"succeeded":  succeeded,
+		"failed":     failed,
+		"durationMs": duration.Milliseconds(),
+	})
+}
+
+// ScheduleMissedEvent creates an event when a schedule rule did not run at its scheduled time
+// missed counts the skipped occurrences, the latest at lastMissed; caughtUp is true if the
+// rule runs now instead
+func ScheduleMissedEvent(ruleID, rule string, missed int, lastMissed time.Time, reason string, caughtUp bool) *Event {
+	return NewEvent(EventScheduleMissed, map[string]interface{}{
+		"ruleID":     ruleID,
+		"rule":       rule,
+		"missed":     missed,
+		"lastMissed": lastMissed,
+		"reason":     reason,
+		"caughtUp":   caughtUp,
+	})
+}
+
+// DiscoveryCompletedEvent creates an event when a discovery run finishes
+// report is the run's *discovery.DiscoveryReport (an interface to avoid a circular dependency)
+func DiscoveryCompletedEvent(scanID string, servers, errors, skipped int, duration time.Duration, report interface{}) *Event {
+	return NewEvent(EventDiscoveryCompleted, map[string]interface{}{
+		"scanID":     scanID,
+		"servers":    servers,
+		"errors":     errors,
+		"skipped":    skipped,
+		"durationMs": duration.Milliseconds(),
+		"report":     report,
+	})
+}
+
 // EventBus is a lightweight pub/sub event bus
 type EventBus struct {
 	subscribers map[EventType][]chan *Event
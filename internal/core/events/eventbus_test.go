@@ -292,4 +292,26 @@ func TestEventHelpers(t *testing.T) {
 	if event.Data["filePath"] != "/path/to/config.json" {
 		t.Error("File path not in event data")
 	}
+
+	// Test ServerRestartScheduledEvent
+	restartAt := time.Now().Add(2 * time.Second)
+	event = ServerRestartScheduledEvent("server-123", 2, 3, 2*time.Second, restartAt)
+	if event.Type != EventServerRestartScheduled {
+		t.Error("Wrong event type")
+	}
+	if event.Data["delayMs"] != int64(2000) {
+		t.Errorf("Expected delayMs 2000, got %v", event.Data["delayMs"])
+	}
+	if event.Data["attempt"] != 2 {
+		t.Error("Attempt not in event data")
+	}
+
+	// Test ServerQuarantinedEvent
+	event = ServerQuarantinedEvent("server-123", "crash loop detected", 5)
+	if event.Type != EventServerQuarantined {
+		t.Error("Wrong event type")
+	}
+	if event.Data["reason"] != "crash loop detected" {
+		t.Error("Reason not in event data")
+	}
 }
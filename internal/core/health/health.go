@@ -0,0 +1,282 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+const (
+	// DefaultTimeout is used when a server does not configure healthCheckTimeout
+	DefaultTimeout = 5 * time.Second
+
+	// DefaultFailureThreshold is used when a server does not configure healthCheckFailures
+	DefaultFailureThreshold = 3
+
+	// scanInterval is how often the checker looks for servers that are due
+	scanInterval = 1 * time.Second
+)
+
+// ServerProvider supplies the current server list (avoid circular dependency)
+type ServerProvider interface {
+	GetCachedServers() []models.MCPServer
+}
+
+// ServerRestarter restarts unhealthy servers (avoid circular dependency)
+type ServerRestarter interface {
+	RestartServer(server *models.MCPServer) error
+}
+
+// checkState tracks scheduling and results for a single server
+type checkState struct {
+	status   *models.HealthStatus
+	inFlight bool
+}
+
+// HealthChecker actively probes HTTP/SSE servers on their configured interval.
+// Servers whose process stays alive but stops answering are marked unhealthy and,
+// if RestartOnUnhealthy is set, restarted through the lifecycle service.
+type HealthChecker struct {
+	servers   ServerProvider
+	restarter ServerRestarter
+	eventBus  *events.EventBus
+	client    *http.Client
+	mu        sync.RWMutex
+	states    map[string]*checkState // serverID -> state
+	stopChan  chan struct{}
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewHealthChecker creates a new health checker
+// restarter may be nil, in which case unhealthy servers are only reported
+func NewHealthChecker(servers ServerProvider, restarter ServerRestarter, eventBus *events.EventBus) *HealthChecker {
+	return &HealthChecker{
+		servers:   servers,
+		restarter: restarter,
+		eventBus:  eventBus,
+		client:    &http.Client{},
+		states:    make(map[string]*checkState),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start begins the background check loop
+func (hc *HealthChecker) Start() {
+	hc.wg.Add(1)
+	go hc.run()
+}
+
+// Stop ends the background check loop and waits for in-flight checks
+func (hc *HealthChecker) Stop() {
+	hc.stopOnce.Do(func() {
+		close(hc.stopChan)
+	})
+	hc.wg.Wait()
+}
+
+// GetHealth returns a copy of the latest health status for a server
+func (hc *HealthChecker) GetHealth(serverID string) (*models.HealthStatus, bool) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+
+	state, exists := hc.states[serverID]
+	if !exists {
+		return nil, false
+	}
+
+	status := *state.status
+	return &status, true
+}
+
+// run periodically scans servers and launches checks that are due
+func (hc *HealthChecker) run() {
+	defer hc.wg.Done()
+
+	ticker := time.NewTicker(scanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-hc.stopChan:
+			return
+		case <-ticker.C:
+			hc.scan()
+		}
+	}
+}
+
+// scan launches checks for eligible servers and forgets servers that are no longer eligible
+func (hc *HealthChecker) scan() {
+	if hc.servers == nil {
+		return
+	}
+
+	eligible := make(map[string]bool)
+	for _, server := range hc.servers.GetCachedServers() {
+		if !isCheckable(&server) {
+			continue
+		}
+		eligible[server.ID] = true
+
+		if hc.isDue(&server) {
+			hc.wg.Add(1)
+			go func() {
+				defer hc.wg.Done()
+				hc.CheckServer(&server)
+			}()
+		}
+	}
+
+	// Health history restarts when a server stops or changes endpoint
+	hc.mu.Lock()
+	for serverID, state := range hc.states {
+		if !eligible[serverID] && !state.inFlight {
+			delete(hc.states, serverID)
+		}
+	}
+	hc.mu.Unlock()
+}
+
+// isCheckable reports whether a server should be actively probed
+func isCheckable(server *models.MCPServer) bool {
+	if server.Status.State != models.StatusRunning {
+		return false
+	}
+	if server.Transport != models.TransportHTTP && server.Transport != models.TransportSSE {
+		return false
+	}
+	return server.HealthCheckURL() != "" && server.Configuration.HealthCheckInterval > 0
+}
+
+// isDue reports whether a server's interval has elapsed since its last check
+func (hc *HealthChecker) isDue(server *models.MCPServer) bool {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+
+	state, exists := hc.states[server.ID]
+	if !exists {
+		return true
+	}
+	if state.inFlight {
+		return false
+	}
+
+	interval := time.Duration(server.Configuration.HealthCheckInterval) * time.Second
+	return time.Since(state.status.LastCheck) >= interval
+}
+
+// CheckServer probes a server once, records the result and publishes it
+// Returns the updated health status
+func (hc *HealthChecker) CheckServer(server *models.MCPServer) *models.HealthStatus {
+	cfg := server.Configuration
+	endpoint := server.HealthCheckURL()
+
+	hc.mu.Lock()
+	state, exists := hc.states[server.ID]
+	if !exists || state.status.Endpoint != endpoint {
+		state = &checkState{status: models.NewHealthStatus(server.ID, endpoint)}
+		hc.states[server.ID] = state
+	}
+	state.inFlight = true
+	hc.mu.Unlock()
+
+	timeout := DefaultTimeout
+	if cfg.HealthCheckTimeout > 0 {
+		timeout = time.Duration(cfg.HealthCheckTimeout) * time.Second
+	}
+	threshold := DefaultFailureThreshold
+	if cfg.HealthCheckFailures > 0 {
+		threshold = cfg.HealthCheckFailures
+	}
+
+	latency, err := hc.probe(endpoint, server.Transport, timeout)
+
+	hc.mu.Lock()
+	previous := state.status.State
+	if err != nil {
+		state.status.RecordFailure(latency, err.Error(), threshold)
+	} else {
+		state.status.RecordSuccess(latency)
+	}
+	state.inFlight = false
+	result := *state.status
+	hc.mu.Unlock()
+
+	if err != nil {
+		slog.Warn("[HEALTH] Check failed", "serverId", server.ID, "endpoint", endpoint,
+			"consecutiveFailures", result.ConsecutiveFailures, "error", err)
+	}
+
+	if hc.eventBus != nil {
+		hc.eventBus.Publish(events.ServerHealthUpdatedEvent(server.ID, &result, previous))
+	}
+
+	// Restart only on the transition into unhealthy, not on every failure after it
+	if result.State == models.HealthUnhealthy && previous != models.HealthUnhealthy {
+		hc.handleUnhealthy(server, &result)
+	}
+
+	return &result
+}
+
+// probe performs a single HTTP GET against the endpoint
+// SSE endpoints are considered healthy as soon as the response headers arrive
+func (hc *HealthChecker) probe(endpoint string, transport models.TransportType, timeout time.Duration) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid health check endpoint: %w", err)
+	}
+	if transport == models.TransportSSE {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	start := time.Now()
+	resp, err := hc.client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return latency, fmt.Errorf("health check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if transport != models.TransportSSE {
+		// Drain a bounded amount so the connection can be reused
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return latency, fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+
+	return latency, nil
+}
+
+// handleUnhealthy restarts a server that just became unhealthy, if configured to
+func (hc *HealthChecker) handleUnhealthy(server *models.MCPServer, status *models.HealthStatus) {
+	slog.Error("[HEALTH] Server is unhealthy", "serverId", server.ID, "serverName", server.Name,
+		"consecutiveFailures", status.ConsecutiveFailures, "lastError", status.LastError)
+
+	if !server.Configuration.RestartOnUnhealthy || hc.restarter == nil {
+		return
+	}
+
+	if hc.eventBus != nil {
+		entry := models.NewLogEntry(models.LogWarning, server.ID,
+			fmt.Sprintf("Restarting %s after %d failed health checks: %s", server.Name, status.ConsecutiveFailures, status.LastError))
+		hc.eventBus.Publish(events.ServerLogEntryEvent(server.ID, entry))
+	}
+
+	if err := hc.restarter.RestartServer(server); err != nil {
+		slog.Error("[HEALTH] Restart of unhealthy server failed", "serverId", server.ID, "error", err)
+	}
+}
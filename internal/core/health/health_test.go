@@ -0,0 +1,246 @@
+package health
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+// MockServerProvider is a mock implementation of ServerProvider for testing
+type MockServerProvider struct {
+	mu      sync.Mutex
+	servers []models.MCPServer
+}
+
+func (m *MockServerProvider) GetCachedServers() []models.MCPServer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]models.MCPServer(nil), m.servers...)
+}
+
+// MockRestarter is a mock implementation of ServerRestarter for testing
+type MockRestarter struct {
+	restarts atomic.Int32
+}
+
+func (m *MockRestarter) RestartServer(server *models.MCPServer) error {
+	m.restarts.Add(1)
+	return nil
+}
+
+// newHTTPServer creates a running HTTP-transport server probing the given endpoint
+func newHTTPServer(endpoint string) *models.MCPServer {
+	server := models.NewMCPServer("http-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Transport = models.TransportHTTP
+	server.Status.State = models.StatusRunning
+	server.Configuration.HealthCheckEndpoint = endpoint
+	server.Configuration.HealthCheckInterval = 1
+	server.Configuration.HealthCheckTimeout = 1
+	return server
+}
+
+func TestHealthChecker_CheckServer_Healthy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	healthEvents := eventBus.Subscribe(events.EventServerHealthUpdated)
+
+	hc := NewHealthChecker(&MockServerProvider{}, nil, eventBus)
+	server := newHTTPServer(ts.URL + "/health")
+
+	status := hc.CheckServer(server)
+	if status.State != models.HealthHealthy {
+		t.Errorf("Expected healthy, got %s (%s)", status.State, status.LastError)
+	}
+	if status.TotalChecks != 1 {
+		t.Errorf("Expected 1 check, got %d", status.TotalChecks)
+	}
+
+	select {
+	case event := <-healthEvents:
+		if event.Data["state"] != models.HealthHealthy {
+			t.Errorf("Expected healthy event, got %v", event.Data["state"])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for health event")
+	}
+
+	stored, exists := hc.GetHealth(server.ID)
+	if !exists || stored.State != models.HealthHealthy {
+		t.Error("GetHealth should return the recorded status")
+	}
+}
+
+func TestHealthChecker_CheckServer_FailureThreshold(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	restarter := &MockRestarter{}
+	hc := NewHealthChecker(&MockServerProvider{}, restarter, nil)
+	server := newHTTPServer(ts.URL)
+	server.Configuration.HealthCheckFailures = 2
+	server.Configuration.RestartOnUnhealthy = true
+
+	if status := hc.CheckServer(server); status.State != models.HealthDegraded {
+		t.Errorf("Expected degraded after first failure, got %s", status.State)
+	}
+	if restarter.restarts.Load() != 0 {
+		t.Error("Should not restart below the threshold")
+	}
+
+	status := hc.CheckServer(server)
+	if status.State != models.HealthUnhealthy {
+		t.Errorf("Expected unhealthy at threshold, got %s", status.State)
+	}
+	if status.ConsecutiveFailures != 2 {
+		t.Errorf("Expected 2 consecutive failures, got %d", status.ConsecutiveFailures)
+	}
+	if restarter.restarts.Load() != 1 {
+		t.Errorf("Expected one restart, got %d", restarter.restarts.Load())
+	}
+
+	// Further failures do not restart again
+	hc.CheckServer(server)
+	if restarter.restarts.Load() != 1 {
+		t.Errorf("Expected restart only on transition to unhealthy, got %d", restarter.restarts.Load())
+	}
+}
+
+func TestHealthChecker_CheckServer_NoRestartWhenDisabled(t *testing.T) {
+	restarter := &MockRestarter{}
+	hc := NewHealthChecker(&MockServerProvider{}, restarter, nil)
+
+	// Nothing listens on this endpoint
+	server := newHTTPServer("http://127.0.0.1:1/health")
+	server.Configuration.HealthCheckFailures = 1
+
+	status := hc.CheckServer(server)
+	if status.State != models.HealthUnhealthy {
+		t.Errorf("Expected unhealthy, got %s", status.State)
+	}
+	if status.LastError == "" {
+		t.Error("Expected failure reason to be recorded")
+	}
+	if restarter.restarts.Load() != 0 {
+		t.Error("Should not restart when restartOnUnhealthy is false")
+	}
+}
+
+func TestHealthChecker_CheckServer_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	hc := NewHealthChecker(&MockServerProvider{}, nil, nil)
+	server := newHTTPServer(ts.URL)
+
+	start := time.Now()
+	status := hc.CheckServer(server)
+	if status.State == models.HealthHealthy {
+		t.Error("Hanging server should not be healthy")
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Check should time out after about 1s, took %v", elapsed)
+	}
+}
+
+func TestHealthChecker_SSEEndpoint(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		// Keep the stream open like a real SSE server
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	hc := NewHealthChecker(&MockServerProvider{}, nil, nil)
+	server := newHTTPServer(ts.URL + "/sse")
+	server.Transport = models.TransportSSE
+
+	if status := hc.CheckServer(server); status.State != models.HealthHealthy {
+		t.Errorf("Expected SSE endpoint to be healthy, got %s (%s)", status.State, status.LastError)
+	}
+}
+
+func TestHealthChecker_ScanSkipsIneligibleServers(t *testing.T) {
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer ts.Close()
+
+	stdio := newHTTPServer(ts.URL)
+	stdio.Transport = models.TransportStdio
+	stopped := newHTTPServer(ts.URL)
+	stopped.Status.State = models.StatusStopped
+	eligible := newHTTPServer(ts.URL)
+	eligible.ID = "b0e3c7a6-7f6b-4c55-9c1b-1f0f5d0b6a11"
+
+	provider := &MockServerProvider{servers: []models.MCPServer{*stdio, *stopped, *eligible}}
+	hc := NewHealthChecker(provider, nil, nil)
+	hc.scan()
+	hc.wg.Wait()
+
+	if hits.Load() != 1 {
+		t.Errorf("Expected only the eligible server to be probed, got %d probes", hits.Load())
+	}
+	if _, exists := hc.GetHealth(eligible.ID); !exists {
+		t.Error("Eligible server should have a health status")
+	}
+}
+
+func TestHealthChecker_CheckServer_PathOnPort(t *testing.T) {
+	var probedPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probedPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	hc := NewHealthChecker(&MockServerProvider{}, nil, nil)
+	server := newHTTPServer("/healthz")
+
+	// The path has nothing to resolve against until the server's port is known
+	if isCheckable(server) {
+		t.Error("Expected a path endpoint without a port not to be checkable")
+	}
+
+	port := ts.Listener.Addr().(*net.TCPAddr).Port
+	server.Port = &port
+	if !isCheckable(server) {
+		t.Fatal("Expected the server to be checkable once its port is known")
+	}
+
+	status := hc.CheckServer(server)
+	if status.State != models.HealthHealthy {
+		t.Errorf("Expected healthy, got %s (%s)", status.State, status.LastError)
+	}
+	if probedPath != "/healthz" {
+		t.Errorf("Expected /healthz to be probed, got %q", probedPath)
+	}
+	if want := fmt.Sprintf("http://localhost:%d/healthz", port); status.Endpoint != want {
+		t.Errorf("Expected endpoint %s, got %s", want, status.Endpoint)
+	}
+}
@@ -0,0 +1,312 @@
+package lifecycle
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/google/uuid"
+)
+
+// DefaultBatchParallelism bounds concurrent operations when BatchRequest leaves it unset
+const DefaultBatchParallelism = 4
+
+// BatchAction is the lifecycle operation applied to every server in a batch
+type BatchAction string
+
+const (
+	BatchStart   BatchAction = "start"
+	BatchStop    BatchAction = "stop"
+	BatchRestart BatchAction = "restart"
+)
+
+// ValidBatchActions contains all valid batch actions
+var ValidBatchActions = []BatchAction{BatchStart, BatchStop, BatchRestart}
+
+// IsValid checks if the batch action is valid
+func (a BatchAction) IsValid() bool {
+	for _, valid := range ValidBatchActions {
+		if a == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// BatchSelector picks servers from the discovery cache instead of listing their IDs
+type BatchSelector string
+
+const (
+	BatchSelectRunning BatchSelector = "running" // Every server that is running or starting
+	BatchSelectError   BatchSelector = "error"   // Every server in the error state
+)
+
+// ValidBatchSelectors contains all valid batch selectors
+var ValidBatchSelectors = []BatchSelector{BatchSelectRunning, BatchSelectError}
+
+// IsValid checks if the batch selector is valid
+func (s BatchSelector) IsValid() bool {
+	for _, valid := range ValidBatchSelectors {
+		if s == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// BatchRequest describes one bulk lifecycle operation
+// Exactly one of ServerIDs and Selector picks the servers
+type BatchRequest struct {
+	Action      BatchAction   `json:"action"`
+	ServerIDs   []string      `json:"serverIds,omitempty"`
+	Selector    BatchSelector `json:"selector,omitempty"`
+	Force       bool          `json:"force,omitempty"`       // Stop only: skip the graceful stages
+	Timeout     int           `json:"timeout,omitempty"`     // Stop only: seconds; 0 = each server's ShutdownTimeout
+	Parallelism int           `json:"parallelism,omitempty"` // Maximum concurrent operations; <= 0 = DefaultBatchParallelism
+}
+
+// Validate checks that the request names an action and exactly one way to pick servers
+func (r *BatchRequest) Validate() error {
+	if !r.Action.IsValid() {
+		return fmt.Errorf("invalid batch action: %q", r.Action)
+	}
+	if len(r.ServerIDs) > 0 && r.Selector != "" {
+		return fmt.Errorf("serverIds and selector cannot be combined")
+	}
+	if len(r.ServerIDs) == 0 && r.Selector == "" {
+		return fmt.Errorf("serverIds or selector is required")
+	}
+	if r.Selector != "" && !r.Selector.IsValid() {
+		return fmt.Errorf("invalid batch selector: %q", r.Selector)
+	}
+	if r.Timeout < 0 {
+		return fmt.Errorf("timeout cannot be negative")
+	}
+	return nil
+}
+
+// BatchServerResult is the outcome of the batch operation on one server
+type BatchServerResult struct {
+	ServerID string             `json:"serverId"`
+	Name     string             `json:"name,omitempty"`
+	Success  bool               `json:"success"`
+	Error    string             `json:"error,omitempty"`
+	State    models.StatusState `json:"state,omitempty"` // State once the operation finished
+	Duration time.Duration      `json:"duration"`
+}
+
+// BatchResult aggregates the per-server outcomes of a batch operation
+type BatchResult struct {
+	BatchID   string              `json:"batchId"`
+	Action    BatchAction         `json:"action"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BatchServerResult `json:"results"` // In selection order
+	Duration  time.Duration       `json:"duration"`
+}
+
+// RunBatch applies req.Action to the selected servers, at most req.Parallelism at a time
+// Starts and restarts wait until the server is running or has failed, so each result
+// reflects the server's real outcome. Progress is published as each server finishes,
+// tagged with the batch ID returned in the result
+func (ls *LifecycleService) RunBatch(req BatchRequest) (*BatchResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ids := req.ServerIDs
+	if req.Selector != "" {
+		ids = ls.selectServers(req.Selector)
+	}
+	ids = dedupe(ids)
+
+	parallelism := req.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultBatchParallelism
+	}
+
+	run := ls.newBatchRun(req.Action, len(ids))
+	slog.Info("[BATCH] Starting batch operation", "batchId", run.result.BatchID, "action", req.Action, "count", len(ids), "parallelism", parallelism)
+
+	run.run(ids, parallelism, func(id string) BatchServerResult {
+		return ls.batchServer(id, req)
+	})
+	return run.finish(), nil
+}
+
+// batchRun collects the results of one batch operation and publishes its progress
+type batchRun struct {
+	ls        *LifecycleService
+	result    *BatchResult
+	total     int
+	startedAt time.Time
+
+	mu        sync.Mutex
+	completed int
+}
+
+// newBatchRun starts collecting results for a batch operation on total servers
+func (ls *LifecycleService) newBatchRun(action BatchAction, total int) *batchRun {
+	return &batchRun{
+		ls: ls,
+		result: &BatchResult{
+			BatchID: uuid.New().String(),
+			Action:  action,
+			Results: make([]BatchServerResult, 0, total),
+		},
+		total:     total,
+		startedAt: time.Now(),
+	}
+}
+
+// run applies op to ids, at most parallelism at a time, and appends the results in ids order
+// Returns the results of this call so callers running in stages can inspect them
+func (b *batchRun) run(ids []string, parallelism int, op func(id string) BatchServerResult) []BatchServerResult {
+	results := make([]BatchServerResult, len(ids))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result := op(id)
+			results[i] = result
+
+			b.mu.Lock()
+			b.completed++
+			completed := b.completed
+			b.mu.Unlock()
+
+			if b.ls.eventBus != nil {
+				b.ls.eventBus.Publish(events.BatchProgressEvent(b.result.BatchID, string(b.result.Action), result.ServerID, result.Success, result.Error, completed, b.total))
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	b.result.Results = append(b.result.Results, results...)
+	return results
+}
+
+// finish totals the results and publishes the completion event
+func (b *batchRun) finish() *BatchResult {
+	batch := b.result
+	for _, result := range batch.Results {
+		if result.Success {
+			batch.Succeeded++
+		} else {
+			batch.Failed++
+		}
+	}
+	batch.Duration = time.Since(b.startedAt)
+
+	slog.Info("[BATCH] Batch operation complete", "batchId", batch.BatchID, "action", batch.Action, "succeeded", batch.Succeeded, "failed", batch.Failed)
+
+	if b.ls.eventBus != nil {
+		b.ls.eventBus.Publish(events.BatchCompletedEvent(batch.BatchID, string(batch.Action), batch.Succeeded, batch.Failed, batch.Duration))
+	}
+
+	return batch
+}
+
+// selectServers returns the IDs of the cached servers matching a selector
+func (ls *LifecycleService) selectServers(selector BatchSelector) []string {
+	if ls.discoveryService == nil {
+		return nil
+	}
+
+	var ids []string
+	for _, server := range ls.discoveryService.GetCachedServers() {
+		switch selector {
+		case BatchSelectRunning:
+			if server.Status.State == models.StatusRunning || server.Status.State == models.StatusStarting {
+				ids = append(ids, server.ID)
+			}
+		case BatchSelectError:
+			if server.Status.State == models.StatusError {
+				ids = append(ids, server.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// batchServer applies the batch action to one server and waits for the outcome
+func (ls *LifecycleService) batchServer(serverID string, req BatchRequest) (result BatchServerResult) {
+	result.ServerID = serverID
+	server, exists := ls.cachedServer(serverID)
+	if !exists {
+		result.Error = "server not found"
+		return result
+	}
+	result.Name = server.Name
+
+	startedAt := time.Now()
+	defer func() { result.Duration = time.Since(startedAt) }()
+
+	var err error
+	switch {
+	case req.Action == BatchStart && server.Status.State == models.StatusRunning,
+		req.Action == BatchStop && (server.Status.State == models.StatusStopped || server.Status.State == models.StatusError):
+		// Already where the operation would leave it
+		result.Success = true
+		result.State = server.Status.State
+		return result
+	case req.Action == BatchStart && server.Status.State == models.StatusStarting:
+		// Started elsewhere; wait for the outcome below
+	case req.Action == BatchStart:
+		err = ls.StartServer(server)
+	case req.Action == BatchStop:
+		timeout := req.Timeout
+		if timeout == 0 {
+			timeout = server.Configuration.ShutdownTimeout
+		}
+		err = ls.StopServer(server, req.Force, timeout)
+	case req.Action == BatchRestart:
+		err = ls.RestartServer(server)
+	}
+
+	if err == nil && req.Action != BatchStop {
+		status := ls.awaitStartup(server)
+		switch status.State {
+		case models.StatusRunning:
+		case models.StatusStarting:
+			err = fmt.Errorf("server did not become ready in time")
+		default:
+			err = fmt.Errorf("server is %s", status.State)
+			if status.ErrorMessage != "" {
+				err = fmt.Errorf("%s", status.ErrorMessage)
+			}
+		}
+	}
+
+	result.Success = err == nil
+	if err != nil {
+		slog.Warn("[BATCH] Server operation failed", "serverId", serverID, "serverName", server.Name, "action", req.Action, "error", err)
+		result.Error = err.Error()
+	}
+	if cached, exists := ls.cachedServer(serverID); exists {
+		result.State = cached.Status.State
+	}
+	return result
+}
+
+// dedupe removes repeated IDs, keeping the first occurrence
+func dedupe(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
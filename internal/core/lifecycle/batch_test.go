@@ -0,0 +1,182 @@
+package lifecycle
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+func TestBatchRequest_Validate(t *testing.T) {
+	id := "6f1c1e4e-3a1b-4c57-9d8e-2b0f5e7a9c10"
+	tests := []struct {
+		name    string
+		req     BatchRequest
+		wantErr bool
+	}{
+		{"ids", BatchRequest{Action: BatchStart, ServerIDs: []string{id}}, false},
+		{"selector", BatchRequest{Action: BatchStop, Selector: BatchSelectRunning}, false},
+		{"invalid action", BatchRequest{Action: "pause", ServerIDs: []string{id}}, true},
+		{"no servers", BatchRequest{Action: BatchStart}, true},
+		{"ids and selector", BatchRequest{Action: BatchStart, ServerIDs: []string{id}, Selector: BatchSelectError}, true},
+		{"invalid selector", BatchRequest{Action: BatchRestart, Selector: "stopped"}, true},
+		{"negative timeout", BatchRequest{Action: BatchStop, Selector: BatchSelectRunning, Timeout: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLifecycleService_RunBatch_Start(t *testing.T) {
+	var servers []models.MCPServer
+	var ids []string
+	for i := 0; i < 4; i++ {
+		server := models.NewMCPServer(fmt.Sprintf("server-%d", i), fmt.Sprintf("/path/to/server-%d", i), models.DiscoveryClientConfig)
+		server.Transport = models.TransportHTTP
+		servers = append(servers, *server)
+		ids = append(ids, server.ID)
+	}
+	failing := models.NewMCPServer("failing", "/path/to/failing", models.DiscoveryClientConfig)
+	failing.Transport = models.TransportHTTP
+	servers = append(servers, *failing)
+	gone := "6f1c1e4e-3a1b-4c57-9d8e-2b0f5e7a9c10"
+	ids = append(ids, failing.ID, gone, ids[0])
+
+	// Track how many servers are starting at once
+	var concurrencyMu sync.Mutex
+	maxStarting := 0
+	ds := cacheDiscoveryService(servers, func(cache map[string]models.MCPServer) {
+		starting := 0
+		for _, server := range cache {
+			if server.Status.State == models.StatusStarting {
+				starting++
+			}
+		}
+		concurrencyMu.Lock()
+		defer concurrencyMu.Unlock()
+		if starting > maxStarting {
+			maxStarting = starting
+		}
+	})
+
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			if spec.Command == failing.InstallationPath {
+				return nil, fmt.Errorf("executable not found")
+			}
+			return mockProcess(1234), nil
+		},
+	}
+
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	progressEvents := eventBus.Subscribe(events.EventBatchProgress)
+	completedEvents := eventBus.Subscribe(events.EventBatchCompleted)
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+
+	result, err := service.RunBatch(BatchRequest{Action: BatchStart, ServerIDs: ids, Parallelism: 2})
+	if err != nil {
+		t.Fatalf("RunBatch failed: %v", err)
+	}
+
+	// The repeated ID runs once
+	if len(result.Results) != 6 {
+		t.Fatalf("Expected 6 results, got %d: %+v", len(result.Results), result.Results)
+	}
+	if result.Succeeded != 4 || result.Failed != 2 {
+		t.Errorf("Expected 4 succeeded and 2 failed, got %d and %d", result.Succeeded, result.Failed)
+	}
+	for i, r := range result.Results[:4] {
+		if r.ServerID != ids[i] || !r.Success || r.State != models.StatusRunning {
+			t.Errorf("Expected %s to be running, got %+v", ids[i], r)
+		}
+		// Each waited for its server to become ready
+		if r.Duration <= 0 {
+			t.Errorf("Expected %s to report how long its start took, got %s", ids[i], r.Duration)
+		}
+	}
+	if r := result.Results[4]; r.Success || r.Error == "" {
+		t.Errorf("Expected failing server to report its error, got %+v", r)
+	}
+	if r := result.Results[5]; r.Success || r.Error != "server not found" {
+		t.Errorf("Expected unknown server to be reported, got %+v", r)
+	}
+
+	concurrencyMu.Lock()
+	if maxStarting > 2 {
+		t.Errorf("Expected at most 2 servers starting at once, got %d", maxStarting)
+	}
+	concurrencyMu.Unlock()
+
+	for i := 1; i <= 6; i++ {
+		select {
+		case event := <-progressEvents:
+			if event.Data["batchID"] != result.BatchID || event.Data["total"] != 6 {
+				t.Errorf("Unexpected progress event: %v", event.Data)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected a progress event per server, got %d", i-1)
+		}
+	}
+	select {
+	case event := <-completedEvents:
+		if event.Data["succeeded"] != 4 || event.Data["failed"] != 2 {
+			t.Errorf("Unexpected batch summary: %v", event.Data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a batch completed event")
+	}
+}
+
+func TestLifecycleService_RunBatch_StopRunning(t *testing.T) {
+	running := models.NewMCPServer("running", "/path/to/running", models.DiscoveryClientConfig)
+	running.Transport = models.TransportHTTP
+	running.Configuration.ShutdownTimeout = 7
+	stopped := models.NewMCPServer("stopped", "/path/to/stopped", models.DiscoveryClientConfig)
+	stopped.Transport = models.TransportHTTP
+	ds := cacheDiscoveryService([]models.MCPServer{*running, *stopped}, nil)
+
+	var mu sync.Mutex
+	var stopTimeouts []int
+	pm := &MockProcessManager{
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopTimeouts = append(stopTimeouts, timeout)
+			return nil
+		},
+	}
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+	startManaged(t, service, running)
+
+	result, err := service.RunBatch(BatchRequest{Action: BatchStop, Selector: BatchSelectRunning})
+	if err != nil {
+		t.Fatalf("RunBatch failed: %v", err)
+	}
+	if len(result.Results) != 1 || result.Results[0].ServerID != running.ID {
+		t.Fatalf("Expected only the running server to be selected, got %+v", result.Results)
+	}
+	if !result.Results[0].Success || result.Results[0].State != models.StatusStopped {
+		t.Errorf("Expected running server to stop, got %+v", result.Results[0])
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(stopTimeouts) != 1 || stopTimeouts[0] != 7 {
+		t.Errorf("Expected the server's ShutdownTimeout (7) to be used, got %v", stopTimeouts)
+	}
+}
@@ -0,0 +1,73 @@
+package lifecycle
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// cgroupName is the name of a server's sub-group in the limiter
+func cgroupName(serverID string) string {
+	return "server-" + serverID
+}
+
+// resourceLimits converts a server's configured limits for the limiter
+func resourceLimits(cfg models.ServerConfiguration) platform.ResourceLimits {
+	return platform.ResourceLimits{
+		MemoryMax:       uint64(cfg.MemoryMaxMB) * 1024 * 1024,
+		CPUQuotaPercent: cfg.CPUQuotaPercent,
+		PidsMax:         cfg.PidsMax,
+	}
+}
+
+// prepareCgroup creates the server's cgroup and returns its directory for the launch
+// Returns "" when there is no limiter or the cgroup cannot be created; the server then
+// runs without limits, which is only worth a warning if limits were configured
+func (ls *LifecycleService) prepareCgroup(server *models.MCPServer) string {
+	ls.mu.RLock()
+	limiter := ls.limiter
+	ls.mu.RUnlock()
+
+	if limiter == nil {
+		return ""
+	}
+
+	limits := resourceLimits(server.Configuration)
+	if available, reason := limiter.Available(); !available {
+		if !limits.IsZero() {
+			slog.Warn("[CGROUP] Resource limits not applied", "serverId", server.ID, "serverName", server.Name, "reason", reason)
+			ls.publishLog(server.ID, models.LogWarning, fmt.Sprintf("Resource limits for %s are not applied: %s", server.Name, reason))
+		}
+		return ""
+	}
+
+	dir, err := limiter.Create(cgroupName(server.ID), limits)
+	if err != nil {
+		slog.Warn("[CGROUP] Failed to create cgroup", "serverId", server.ID, "serverName", server.Name, "error", err)
+		if !limits.IsZero() {
+			ls.publishLog(server.ID, models.LogWarning, fmt.Sprintf("Resource limits for %s are not applied: %v", server.Name, err))
+		}
+		return ""
+	}
+
+	slog.Info("[CGROUP] Server placed in cgroup", "serverId", server.ID, "cgroup", dir,
+		"memoryMax", limits.MemoryMax, "cpuQuotaPercent", limits.CPUQuotaPercent, "pidsMax", limits.PidsMax)
+	return dir
+}
+
+// removeCgroup deletes a server's cgroup once its process has been released
+// Anything the server left running in the group is killed
+func (ls *LifecycleService) removeCgroup(serverID string) {
+	ls.mu.RLock()
+	limiter := ls.limiter
+	ls.mu.RUnlock()
+
+	if limiter == nil {
+		return
+	}
+	if err := limiter.Remove(cgroupName(serverID)); err != nil {
+		slog.Warn("[CGROUP] Failed to remove cgroup", "serverId", serverID, "error", err)
+	}
+}
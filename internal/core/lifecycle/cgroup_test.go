@@ -0,0 +1,126 @@
+package lifecycle
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// mockLimiter records the cgroups a LifecycleService creates and removes
+type mockLimiter struct {
+	mu        sync.Mutex
+	available bool
+	createErr error
+	created   map[string]platform.ResourceLimits
+	removed   []string
+}
+
+func (m *mockLimiter) Available() (bool, string) {
+	return m.available, "delegation unavailable"
+}
+
+func (m *mockLimiter) Create(name string, limits platform.ResourceLimits) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.createErr != nil {
+		return "", m.createErr
+	}
+	if m.created == nil {
+		m.created = make(map[string]platform.ResourceLimits)
+	}
+	m.created[name] = limits
+	return "/sys/fs/cgroup/test/" + name, nil
+}
+
+func (m *mockLimiter) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.removed = append(m.removed, name)
+	return nil
+}
+
+func TestLifecycleService_StartServer_Cgroup(t *testing.T) {
+	server := models.NewMCPServer("limited", "/path/to/server", models.DiscoveryClientConfig)
+	server.Configuration.MemoryMaxMB = 256
+	server.Configuration.CPUQuotaPercent = 50
+	server.Configuration.PidsMax = 32
+
+	var mu sync.Mutex
+	var launchedIn string
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			launchedIn = spec.Cgroup
+			return mockProcess(1234), nil
+		},
+	}
+
+	limiter := &mockLimiter{available: true}
+	service := NewLifecycleService(pm, cacheDiscoveryService([]models.MCPServer{*server}, nil), &MockMonitoringService{}, nil)
+	service.SetResourceLimiter(limiter)
+	defer service.StopAll()
+
+	startManaged(t, service, server)
+
+	name := cgroupName(server.ID)
+	mu.Lock()
+	if launchedIn != "/sys/fs/cgroup/test/"+name {
+		t.Errorf("Expected the server to launch in its cgroup, got %q", launchedIn)
+	}
+	mu.Unlock()
+
+	limiter.mu.Lock()
+	limits := limiter.created[name]
+	limiter.mu.Unlock()
+	if limits.MemoryMax != 256*1024*1024 || limits.CPUQuotaPercent != 50 || limits.PidsMax != 32 {
+		t.Errorf("Unexpected limits: %+v", limits)
+	}
+
+	if err := service.StopServer(server, false, 5); err != nil {
+		t.Fatalf("StopServer failed: %v", err)
+	}
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if len(limiter.removed) != 1 || limiter.removed[0] != name {
+		t.Errorf("Expected the cgroup to be removed after stop, got %v", limiter.removed)
+	}
+}
+
+func TestLifecycleService_StartServer_CgroupFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		limiter *mockLimiter
+	}{
+		{"delegation unavailable", &mockLimiter{available: false}},
+		{"create fails", &mockLimiter{available: true, createErr: fmt.Errorf("the memory controller is not delegated")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := models.NewMCPServer("limited", "/path/to/server", models.DiscoveryClientConfig)
+			server.Configuration.MemoryMaxMB = 256
+
+			launchedIn := "unset"
+			pm := &MockProcessManager{
+				StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+					launchedIn = spec.Cgroup
+					return mockProcess(1234), nil
+				},
+			}
+
+			service := NewLifecycleService(pm, cacheDiscoveryService([]models.MCPServer{*server}, nil), &MockMonitoringService{}, nil)
+			service.SetResourceLimiter(tt.limiter)
+			defer service.StopAll()
+
+			// The server still starts, just without a cgroup
+			startManaged(t, service, server)
+			if launchedIn != "" {
+				t.Errorf("Expected a launch without cgroup, got %q", launchedIn)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package lifecycle
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// applyEmulation makes a launch spec match how the emulated client would launch the server
+// Clients pass on their whole environment unless stripped, and know nothing of extraPath
+func applyEmulation(spec *platform.LaunchSpec, emulation *models.LaunchEmulation) error {
+	spec.EnvMode = platform.EnvInherit
+	spec.EnvAllowlist = nil
+	spec.ExtraPath = nil
+	if emulation.StripEnv {
+		spec.EnvMode = platform.EnvAllowlist
+		spec.EnvAllowlist = platform.ClientInheritedEnv()
+	}
+
+	if emulation.LoginShellPath {
+		path, err := platform.LoginShellPath()
+		if err != nil {
+			return fmt.Errorf("cannot emulate %s: %w", emulation.Describe(), err)
+		}
+		// A PATH set in the server's configuration is passed by clients too
+		env := make(map[string]string, len(spec.Env)+1)
+		for key, value := range spec.Env {
+			env[key] = value
+		}
+		if _, set := env["PATH"]; !set {
+			env["PATH"] = path
+		}
+		spec.Env = env
+	}
+
+	if emulation.HomeCwd {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("cannot emulate %s: %w", emulation.Describe(), err)
+		}
+		spec.Dir = home
+	}
+	return nil
+}
+
+// LaunchCommand returns a shell command that launches the server exactly as StartServer would,
+// with its full environment, arguments and working directory, for reproducing failures by hand
+func (ls *LifecycleService) LaunchCommand(server *models.MCPServer) (string, error) {
+	if server == nil {
+		return "", fmt.Errorf("server cannot be nil")
+	}
+	spec, err := buildLaunchSpec(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid launch configuration: %w", err)
+	}
+	return platform.ShellCommand(spec)
+}
@@ -0,0 +1,96 @@
+package lifecycle
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+func TestBuildLaunchSpec_Emulation(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	server := models.NewMCPServer("test-server", "npx", models.DiscoveryClientConfig)
+	server.Configuration.WorkingDirectory = t.TempDir()
+	server.Configuration.EnvInheritance = models.EnvInheritNone
+	server.Configuration.ExtraPath = []string{"/opt/node/bin"}
+	server.Configuration.EnvironmentVariables = map[string]string{"API_KEY": "secret"}
+	server.Configuration.Emulation = &models.LaunchEmulation{Client: "Claude Desktop", StripEnv: true, HomeCwd: true}
+
+	spec, err := buildLaunchSpec(server)
+	if err != nil {
+		t.Fatalf("buildLaunchSpec failed: %v", err)
+	}
+
+	if spec.EnvMode != platform.EnvAllowlist || len(spec.EnvAllowlist) == 0 {
+		t.Errorf("Expected the client's default environment, got %s %v", spec.EnvMode, spec.EnvAllowlist)
+	}
+	if spec.Env["API_KEY"] != "secret" {
+		t.Error("Expected configured variables to be passed, as clients do")
+	}
+	if spec.ExtraPath != nil {
+		t.Errorf("Expected extraPath to be ignored, got %v", spec.ExtraPath)
+	}
+	if spec.Dir != home {
+		t.Errorf("Expected the home directory as cwd, got %q", spec.Dir)
+	}
+}
+
+func TestBuildLaunchSpec_EmulationLoginShellPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("login shells are not used on Windows")
+	}
+	t.Setenv("SHELL", "/bin/sh")
+
+	server := models.NewMCPServer("test-server", "npx", models.DiscoveryClientConfig)
+	server.Configuration.Emulation = &models.LaunchEmulation{LoginShellPath: true}
+
+	spec, err := buildLaunchSpec(server)
+	if err != nil {
+		t.Fatalf("buildLaunchSpec failed: %v", err)
+	}
+	if spec.Env["PATH"] == "" {
+		t.Error("Expected PATH from the login shell")
+	}
+
+	// A configured PATH wins, as it does in the client
+	server.Configuration.EnvironmentVariables = map[string]string{"PATH": "/configured/bin"}
+	spec, err = buildLaunchSpec(server)
+	if err != nil {
+		t.Fatalf("buildLaunchSpec failed: %v", err)
+	}
+	if spec.Env["PATH"] != "/configured/bin" {
+		t.Errorf("Expected the configured PATH, got %q", spec.Env["PATH"])
+	}
+	if server.Configuration.EnvironmentVariables["PATH"] != "/configured/bin" || len(server.Configuration.EnvironmentVariables) != 1 {
+		t.Error("The server configuration should not be modified")
+	}
+}
+
+func TestLifecycleService_LaunchCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX shell syntax expected")
+	}
+	service := NewLifecycleService(&MockProcessManager{}, &MockDiscoveryService{}, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("test-server", "/usr/bin/node", models.DiscoveryClientConfig)
+	server.Configuration.WorkingDirectory = t.TempDir()
+	server.Configuration.CommandLineArguments = []string{"server.js"}
+	server.Configuration.EnvInheritance = models.EnvInheritNone
+	server.Configuration.EnvironmentVariables = map[string]string{"API_KEY": "secret"}
+
+	command, err := service.LaunchCommand(server)
+	if err != nil {
+		t.Fatalf("LaunchCommand failed: %v", err)
+	}
+	for _, want := range []string{"cd " + server.Configuration.WorkingDirectory, "env -i", "API_KEY=secret", "/usr/bin/node server.js"} {
+		if !strings.Contains(command, want) {
+			t.Errorf("Expected %q in command:\n%s", want, command)
+		}
+	}
+}
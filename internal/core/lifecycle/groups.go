@@ -0,0 +1,113 @@
+package lifecycle
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+// StartGroup starts a group's servers, and the servers they require, in dependency order
+// Each level of the order starts only once the level before it is running and ready.
+// A server whose requirement failed is not started; its result names the requirement
+func (ls *LifecycleService) StartGroup(group models.ServerGroup, requires models.ServerRequirements) (*BatchResult, error) {
+	levels, err := requires.StartLevels(group.ServerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	run := ls.newBatchRun(BatchStart, countLevels(levels))
+	slog.Info("[GROUP] Starting group", "group", group.Name, "batchId", run.result.BatchID, "levels", len(levels))
+
+	ready := make(map[string]bool)
+	req := BatchRequest{Action: BatchStart}
+	for _, level := range levels {
+		results := run.run(level, DefaultBatchParallelism, func(id string) BatchServerResult {
+			for _, requiredID := range requires[id] {
+				if !ready[requiredID] {
+					return ls.blockedResult(id, "required server %s is not running", ls.serverName(requiredID))
+				}
+			}
+			return ls.batchServer(id, req)
+		})
+		for _, result := range results {
+			ready[result.ServerID] = result.Success
+		}
+	}
+
+	return run.finish(), nil
+}
+
+// StopGroup stops a group's servers in reverse dependency order
+// A server is only stopped once every group member that requires it has stopped; servers
+// outside the group are left alone, even if they require a group member
+func (ls *LifecycleService) StopGroup(group models.ServerGroup, requires models.ServerRequirements, force bool) (*BatchResult, error) {
+	levels, err := requires.StopLevels(group.ServerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invert the requirements between group members
+	member := make(map[string]bool, len(group.ServerIDs))
+	for _, id := range group.ServerIDs {
+		member[id] = true
+	}
+	requiredBy := make(map[string][]string)
+	for id, required := range requires {
+		if !member[id] {
+			continue
+		}
+		for _, requiredID := range required {
+			requiredBy[requiredID] = append(requiredBy[requiredID], id)
+		}
+	}
+
+	run := ls.newBatchRun(BatchStop, countLevels(levels))
+	slog.Info("[GROUP] Stopping group", "group", group.Name, "batchId", run.result.BatchID, "levels", len(levels))
+
+	stopped := make(map[string]bool)
+	req := BatchRequest{Action: BatchStop, Force: force}
+	for _, level := range levels {
+		results := run.run(level, DefaultBatchParallelism, func(id string) BatchServerResult {
+			for _, dependentID := range requiredBy[id] {
+				if !stopped[dependentID] {
+					return ls.blockedResult(id, "required by %s, which is still running", ls.serverName(dependentID))
+				}
+			}
+			return ls.batchServer(id, req)
+		})
+		for _, result := range results {
+			stopped[result.ServerID] = result.Success
+		}
+	}
+
+	return run.finish(), nil
+}
+
+// blockedResult is the result for a server left alone because of its dependencies
+func (ls *LifecycleService) blockedResult(serverID, format string, args ...interface{}) BatchServerResult {
+	result := BatchServerResult{ServerID: serverID, Error: fmt.Sprintf(format, args...)}
+	if server, exists := ls.cachedServer(serverID); exists {
+		result.Name = server.Name
+		result.State = server.Status.State
+	}
+	slog.Warn("[GROUP] Skipping server", "serverId", serverID, "serverName", result.Name, "reason", result.Error)
+	return result
+}
+
+// serverName returns a server's name for messages, falling back to its ID
+func (ls *LifecycleService) serverName(serverID string) string {
+	if server, exists := ls.cachedServer(serverID); exists && server.Name != "" {
+		return server.Name
+	}
+	return serverID
+}
+
+// countLevels returns the number of servers across all levels
+func countLevels(levels [][]string) int {
+	total := 0
+	for _, level := range levels {
+		total += len(level)
+	}
+	return total
+}
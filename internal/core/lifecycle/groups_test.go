@@ -0,0 +1,149 @@
+package lifecycle
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// groupServers returns HTTP servers named after their role in a dependency chain
+func groupServers(names ...string) []models.MCPServer {
+	servers := make([]models.MCPServer, 0, len(names))
+	for _, name := range names {
+		server := models.NewMCPServer(name, "/path/to/"+name, models.DiscoveryClientConfig)
+		server.Transport = models.TransportHTTP
+		servers = append(servers, *server)
+	}
+	return servers
+}
+
+func TestLifecycleService_StartGroup(t *testing.T) {
+	servers := groupServers("db", "reporting", "dashboard")
+	db, reporting, dashboard := servers[0], servers[1], servers[2]
+
+	var mu sync.Mutex
+	var launched []string
+	running := make(map[string]bool)
+	ds := cacheDiscoveryService(servers, func(cache map[string]models.MCPServer) {
+		mu.Lock()
+		defer mu.Unlock()
+		for id, server := range cache {
+			running[id] = server.Status.State == models.StatusRunning
+		}
+	})
+
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			// Each server's requirements must already be ready when it launches
+			if spec.Command == reporting.InstallationPath && !running[db.ID] {
+				t.Error("reporting launched before db was running")
+			}
+			launched = append(launched, spec.Command)
+			return mockProcess(1234), nil
+		},
+	}
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	// db is not a group member, but reporting requires it
+	group := models.ServerGroup{Name: "reports", ServerIDs: []string{reporting.ID}}
+	requires := models.ServerRequirements{reporting.ID: {db.ID}, dashboard.ID: {reporting.ID}}
+
+	result, err := service.StartGroup(group, requires)
+	if err != nil {
+		t.Fatalf("StartGroup failed: %v", err)
+	}
+	if result.Succeeded != 2 || result.Failed != 0 {
+		t.Fatalf("Expected 2 servers started, got %+v", result.Results)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{db.InstallationPath, reporting.InstallationPath}
+	if fmt.Sprint(launched) != fmt.Sprint(want) {
+		t.Errorf("Expected launch order %v, got %v", want, launched)
+	}
+	if running[dashboard.ID] {
+		t.Error("dashboard requires a member but is not in the group and should not start")
+	}
+}
+
+func TestLifecycleService_StartGroup_RequirementFails(t *testing.T) {
+	servers := groupServers("db", "reporting")
+	db, reporting := servers[0], servers[1]
+
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			if spec.Command == db.InstallationPath {
+				return nil, fmt.Errorf("executable not found")
+			}
+			t.Error("reporting should not launch without db")
+			return mockProcess(1234), nil
+		},
+	}
+
+	service := NewLifecycleService(pm, cacheDiscoveryService(servers, nil), &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	group := models.ServerGroup{Name: "reports", ServerIDs: []string{db.ID, reporting.ID}}
+	result, err := service.StartGroup(group, models.ServerRequirements{reporting.ID: {db.ID}})
+	if err != nil {
+		t.Fatalf("StartGroup failed: %v", err)
+	}
+	if result.Failed != 2 {
+		t.Fatalf("Expected both servers to fail, got %+v", result.Results)
+	}
+	if blocked := result.Results[1]; blocked.ServerID != reporting.ID || blocked.Error != "required server db is not running" {
+		t.Errorf("Expected reporting to name its failed requirement, got %+v", blocked)
+	}
+}
+
+func TestLifecycleService_StopGroup(t *testing.T) {
+	servers := groupServers("db", "reporting")
+	db, reporting := servers[0], servers[1]
+
+	var mu sync.Mutex
+	var stopped []int
+	pids := map[string]int{db.InstallationPath: 1001, reporting.InstallationPath: 1002}
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			return mockProcess(pids[spec.Command]), nil
+		},
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, pid)
+			return nil
+		},
+	}
+
+	ds := cacheDiscoveryService(servers, nil)
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	group := models.ServerGroup{Name: "reports", ServerIDs: []string{db.ID, reporting.ID}}
+	requires := models.ServerRequirements{reporting.ID: {db.ID}}
+	if result, err := service.StartGroup(group, requires); err != nil || result.Failed != 0 {
+		t.Fatalf("StartGroup failed: %v %+v", err, result)
+	}
+
+	result, err := service.StopGroup(group, requires, false)
+	if err != nil {
+		t.Fatalf("StopGroup failed: %v", err)
+	}
+	if result.Succeeded != 2 {
+		t.Fatalf("Expected both servers to stop, got %+v", result.Results)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(stopped) != 2 || stopped[0] != 1002 || stopped[1] != 1001 {
+		t.Errorf("Expected reporting (1002) to stop before db (1001), got %v", stopped)
+	}
+}
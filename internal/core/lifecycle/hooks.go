@@ -0,0 +1,189 @@
+package lifecycle
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// defaultHookTimeout applies when a hook has no positive Timeout
+const defaultHookTimeout = 60 * time.Second
+
+// hookTimeout returns how long a hook may run before it is killed
+func hookTimeout(hook *models.Hook) time.Duration {
+	if hook.Timeout > 0 {
+		return time.Duration(hook.Timeout) * time.Second
+	}
+	return defaultHookTimeout
+}
+
+// runHook runs the server's hook for a stage, if one is configured, and waits for it
+// The hook's output goes to the server's log buffer, each line prefixed with the stage.
+// Returns an error if the hook could not be launched, exited non-zero, or was killed
+// after its timeout or because ctx was cancelled
+func (ls *LifecycleService) runHook(ctx context.Context, server *models.MCPServer, stage models.HookStage) error {
+	hook := server.Configuration.Hooks.Get(stage)
+	if hook == nil {
+		return nil
+	}
+
+	// Hooks see the same environment and working directory as the server, but are the
+	// user's own commands and run outside its sandbox
+	unsandboxed := *server
+	unsandboxed.Configuration.Sandbox = nil
+	spec, err := buildLaunchSpec(&unsandboxed)
+	if err != nil {
+		return fmt.Errorf("invalid launch configuration: %w", err)
+	}
+	spec.Command = hook.Command
+	spec.Args = hook.Args
+	spec.Stdin = platform.StdinNull
+	spec.ProcessGroup = platform.ProcessGroupNew
+
+	timeout := hookTimeout(hook)
+	slog.Info("[HOOK] Running hook", "serverId", server.ID, "serverName", server.Name, "stage", stage, "command", hook.Command, "args", hook.Args, "timeout", timeout)
+	ls.publishLog(server.ID, models.LogInfo, fmt.Sprintf("Running %s hook: %s", stage, hook.Command))
+
+	startedAt := time.Now()
+	proc, err := ls.processManager.StartWithOutput(spec)
+	if err != nil {
+		err = fmt.Errorf("%s hook failed to start: %w", stage, err)
+		ls.publishLog(server.ID, models.LogError, err.Error())
+		return err
+	}
+
+	captured := ls.captureHookOutput(server.ID, stage, proc)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = ls.awaitHook(ctx, proc)
+	if err != nil && ctx.Err() != nil {
+		// Kill the hook and anything it started
+		if _, stopErr := ls.processManager.Stop(proc.PID, false, 0); stopErr != nil {
+			slog.Error("[HOOK] Failed to kill hook", "serverId", server.ID, "stage", stage, "pid", proc.PID, "error", stopErr)
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("%s hook timed out after %s", stage, timeout)
+		} else {
+			err = fmt.Errorf("%s hook cancelled", stage)
+		}
+	}
+	// A process the hook left running may hold its output open
+	drained := make(chan struct{})
+	go func() {
+		captured.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(exitCollectTimeout):
+	}
+
+	elapsed := time.Since(startedAt)
+	if err != nil {
+		slog.Warn("[HOOK] Hook failed", "serverId", server.ID, "serverName", server.Name, "stage", stage, "elapsed", elapsed, "error", err)
+		ls.publishLog(server.ID, models.LogError, err.Error())
+		return err
+	}
+
+	slog.Info("[HOOK] Hook finished", "serverId", server.ID, "serverName", server.Name, "stage", stage, "elapsed", elapsed)
+	ls.publishLog(server.ID, models.LogSuccess, fmt.Sprintf("%s hook completed in %s", stage, elapsed.Round(time.Millisecond)))
+	return nil
+}
+
+// awaitHook waits for a hook process to exit, returning an error for a non-zero exit
+// Processes without a wait handle are polled
+func (ls *LifecycleService) awaitHook(ctx context.Context, proc *platform.ManagedProcess) error {
+	if proc.Exited == nil {
+		ticker := time.NewTicker(startupPollInterval)
+		defer ticker.Stop()
+		for ls.processManager.IsRunning(proc.PID) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-proc.Exited:
+	}
+
+	exit := toProcessExit(proc.Exit)
+	if exit != nil && !exit.Clean() {
+		return fmt.Errorf("hook %s", exit.Describe())
+	}
+	return nil
+}
+
+// captureHookOutput copies a hook's output into the server's log buffer
+// The returned WaitGroup is done once both pipes are drained
+func (ls *LifecycleService) captureHookOutput(serverID string, stage models.HookStage, proc *platform.ManagedProcess) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	for _, reader := range []io.ReadCloser{proc.Stdout, proc.Stderr} {
+		if reader == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(reader io.ReadCloser) {
+			defer wg.Done()
+			defer reader.Close()
+			if ls.monitoringService == nil {
+				io.Copy(io.Discard, reader)
+				return
+			}
+			prefixed := prefixLines(reader, fmt.Sprintf("[%s] ", stage))
+			defer prefixed.Close()
+			ls.monitoringService.CaptureOutput(context.Background(), serverID, prefixed)
+		}(reader)
+	}
+	return &wg
+}
+
+// prefixLines returns a reader yielding r's lines with prefix prepended
+// Closing the returned reader discards the rest of r
+func prefixLines(r io.Reader, prefix string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineLength)
+		for scanner.Scan() {
+			if _, err := fmt.Fprintf(pw, "%s%s\n", prefix, scanner.Text()); err != nil {
+				break
+			}
+		}
+		err := scanner.Err()
+		// Keep draining so an overlong line does not block the writer
+		io.Copy(io.Discard, r)
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// runHookLogged runs a hook whose failure does not change the outcome of the operation
+func (ls *LifecycleService) runHookLogged(ctx context.Context, server *models.MCPServer, stage models.HookStage) {
+	if err := ls.runHook(ctx, server, stage); err != nil {
+		slog.Warn("[HOOK] Continuing despite hook failure", "serverId", server.ID, "serverName", server.Name, "stage", stage)
+	}
+}
+
+// publishLog publishes a lifecycle log entry for a server
+func (ls *LifecycleService) publishLog(serverID string, severity models.LogSeverity, message string) {
+	if ls.eventBus == nil {
+		return
+	}
+	ls.eventBus.Publish(events.ServerLogEntryEvent(serverID, models.NewLogEntry(severity, serverID, message)))
+}
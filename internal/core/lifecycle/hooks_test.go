@@ -0,0 +1,179 @@
+package lifecycle
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// exitedProcess returns a process that has already exited with the given code and output
+func exitedProcess(pid, exitCode int, output string) *platform.ManagedProcess {
+	exited := make(chan struct{})
+	close(exited)
+	return &platform.ManagedProcess{
+		PID:    pid,
+		Stdout: io.NopCloser(strings.NewReader(output)),
+		Stderr: io.NopCloser(strings.NewReader("")),
+		Exited: exited,
+		Exit:   &platform.ExitInfo{ExitCode: exitCode, ExitedAt: time.Now()},
+	}
+}
+
+// hookServer returns an HTTP server with every hook configured
+func hookServer() *models.MCPServer {
+	server := models.NewMCPServer("hooked", "/path/to/server", models.DiscoveryClientConfig)
+	server.Transport = models.TransportHTTP
+	server.Configuration.EnvironmentVariables = map[string]string{"API_TOKEN": "secret"}
+	server.Configuration.Hooks = &models.ServerHooks{
+		PreStart:  &models.Hook{Command: "pre-start"},
+		PostStart: &models.Hook{Command: "post-start"},
+		PreStop:   &models.Hook{Command: "pre-stop"},
+		PostStop:  &models.Hook{Command: "post-stop"},
+	}
+	return server
+}
+
+func TestLifecycleService_HooksRunAroundStartAndStop(t *testing.T) {
+	server := hookServer()
+
+	var mu sync.Mutex
+	var order []string
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, spec.Command)
+			if spec.Command == server.InstallationPath {
+				return mockProcess(1234), nil
+			}
+			if spec.Env["API_TOKEN"] != "secret" {
+				t.Errorf("Hook %s should see the server's environment", spec.Command)
+			}
+			return exitedProcess(2000, 0, "output of "+spec.Command+"\n"), nil
+		},
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, "stop")
+			return nil
+		},
+	}
+
+	var logMu sync.Mutex
+	var logged []string
+	ms := &MockMonitoringService{
+		CaptureOutputFunc: func(ctx context.Context, serverID string, reader io.Reader) {
+			scanner := bufio.NewScanner(reader)
+			for scanner.Scan() {
+				logMu.Lock()
+				logged = append(logged, scanner.Text())
+				logMu.Unlock()
+			}
+		},
+	}
+
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+	service := NewLifecycleService(pm, ds, ms, nil)
+	defer service.StopAll()
+
+	startManaged(t, service, server)
+
+	// The post-start hook runs on the server's queue once it is ready
+	if err := service.StopServer(server, false, 5); err != nil {
+		t.Fatalf("StopServer failed: %v", err)
+	}
+
+	mu.Lock()
+	want := []string{"pre-start", server.InstallationPath, "post-start", "pre-stop", "stop", "post-stop"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected order %v, got %v", want, order)
+	}
+	mu.Unlock()
+
+	logMu.Lock()
+	defer logMu.Unlock()
+	found := false
+	for _, line := range logged {
+		if line == "[pre-start] output of pre-start" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected hook output in the log buffer with a stage prefix, got %v", logged)
+	}
+}
+
+func TestLifecycleService_PreStartHookFailureAbortsStart(t *testing.T) {
+	server := hookServer()
+
+	launched := false
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			switch spec.Command {
+			case "pre-start":
+				return exitedProcess(2000, 3, "migration failed\n"), nil
+			case server.InstallationPath:
+				launched = true
+			}
+			return exitedProcess(2001, 0, ""), nil
+		},
+	}
+
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	err := service.StartServer(server)
+	if err == nil || !strings.Contains(err.Error(), "pre-start hook failed") {
+		t.Fatalf("Expected the start to fail on the pre-start hook, got %v", err)
+	}
+	if launched {
+		t.Error("Server must not launch after a failed pre-start hook")
+	}
+
+	cached, _ := ds.GetServerByID(server.ID)
+	if cached.Status.State != models.StatusError || !strings.Contains(cached.Status.ErrorMessage, "exit code 3") {
+		t.Errorf("Expected error state naming the hook's exit code, got %s (%s)", cached.Status.State, cached.Status.ErrorMessage)
+	}
+}
+
+func TestLifecycleService_HookTimeout(t *testing.T) {
+	server := hookServer()
+	server.Configuration.Hooks.PreStart.Timeout = 1
+
+	var mu sync.Mutex
+	var killed []int
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			// The hook never exits on its own
+			return &platform.ManagedProcess{PID: 2000, Exited: make(chan struct{})}, nil
+		},
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			killed = append(killed, pid)
+			return nil
+		},
+	}
+
+	service := NewLifecycleService(pm, cacheDiscoveryService([]models.MCPServer{*server}, nil), &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	err := service.StartServer(server)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("Expected the pre-start hook to time out, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(killed) != 1 || killed[0] != 2000 {
+		t.Errorf("Expected the hook process to be killed, got %v", killed)
+	}
+}
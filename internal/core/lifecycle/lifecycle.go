@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,21 +16,41 @@ import (
 	"github.com/Positronikal/MCPManager/internal/platform"
 )
 
+const (
+	// exitCollectTimeout bounds how long a stop waits for the stopped process to be reaped
+	exitCollectTimeout = time.Second
+
+	// defaultStdinCloseTimeout applies when a server has no positive StdinCloseTimeout
+	defaultStdinCloseTimeout = 5 * time.Second
+)
+
 // LifecycleService manages server lifecycle operations (start, stop, restart)
+// Operations on one server run one at a time through its command queue; the
+// discovery cache owns server state and each operation starts from the cached copy
 type LifecycleService struct {
 	processManager    platform.ProcessManager
 	discoveryService  DiscoveryService  // Interface for cache synchronization
 	monitoringService MonitoringService // Interface for log capture
 	eventBus          *events.EventBus
 	mu                sync.RWMutex
-	monitors          map[string]chan struct{}      // serverID -> stop channel for monitor
-	validatorStop     chan struct{}                 // stop channel for PID validator
-	captureContexts   map[string]context.CancelFunc // serverID -> cancel function for output capture
+	monitors          map[string]chan struct{}            // serverID -> stop channel for monitor
+	validatorStop     chan struct{}                       // stop channel for PID validator
+	captureContexts   map[string]context.CancelFunc       // serverID -> cancel function for output capture
+	processes         map[string]*platform.ManagedProcess // serverID -> process launched by this service
+	journal           TransitionJournal                   // records state transitions (optional)
+	supervisor        *Supervisor                         // restarts crashed servers (RestartOnCrash)
+	queuesMu          sync.Mutex
+	queues            map[string]*serverQueue                 // serverID -> command queue serializing operations
+	processName       func(pid int) string                    // Looks up a process name to recognize re-adopted processes
+	limiter           ResourceLimiter                         // places launched servers in their own cgroup (optional)
+	listeners         func() ([]platform.NetstatEntry, error) // Lists open sockets to find port conflicts
+	outputDir         string                                  // Directory of the servers' output files; empty = pipes
 }
 
 // DiscoveryService interface for cache updates (avoid circular dependency)
 type DiscoveryService interface {
-	UpdateServer(server *models.MCPServer)
+	UpdateServerState(server *models.MCPServer)
+	GetServerByID(serverID string) (*models.MCPServer, bool)
 	GetCachedServers() []models.MCPServer
 }
 
@@ -36,6 +59,19 @@ type MonitoringService interface {
 	CaptureOutput(ctx context.Context, serverID string, reader io.Reader)
 }
 
+// TransitionJournal interface for persisting state transitions (avoid circular dependency)
+type TransitionJournal interface {
+	AppendTransition(transition models.StateTransition) error
+}
+
+// ResourceLimiter places launched servers in resource-limited groups (avoid circular dependency)
+// platform.CgroupManager implements it on Linux
+type ResourceLimiter interface {
+	Available() (bool, string)
+	Create(name string, limits platform.ResourceLimits) (string, error)
+	Remove(name string) error
+}
+
 // NewLifecycleService creates a new lifecycle service
 func NewLifecycleService(
 	processManager platform.ProcessManager,
@@ -51,7 +87,12 @@ func NewLifecycleService(
 		monitors:          make(map[string]chan struct{}),
 		validatorStop:     make(chan struct{}),
 		captureContexts:   make(map[string]context.CancelFunc),
+		processes:         make(map[string]*platform.ManagedProcess),
+		queues:            make(map[string]*serverQueue),
+		processName:       platform.ProcessName,
+		listeners:         func() ([]platform.NetstatEntry, error) { return platform.GetNetstat(nil) },
 	}
+	ls.supervisor = NewSupervisor(DefaultRestartPolicy(), eventBus, ls.restartCrashedServer)
 
 	// Start periodic PID validator for discovered processes
 	go ls.runPIDValidator()
@@ -59,13 +100,51 @@ func NewLifecycleService(
 	return ls
 }
 
+// SetJournal sets where state transitions are recorded; nil disables journaling
+func (ls *LifecycleService) SetJournal(journal TransitionJournal) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.journal = journal
+}
+
+// SetOutputDir sets the directory in which launched servers write their output, one file per
+// server, instead of pipes to the manager; a server without pipes can be left running on exit
+// Servers reading stdin from the manager keep their pipes. An empty dir selects pipes
+func (ls *LifecycleService) SetOutputDir(dir string) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.outputDir = dir
+}
+
+// SetResourceLimiter sets how launched servers are placed in cgroups; nil disables it
+func (ls *LifecycleService) SetResourceLimiter(limiter ResourceLimiter) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.limiter = limiter
+}
+
 // StartServer starts an MCP server
 // Validates state, transitions to starting, launches process, and begins monitoring
+// A user-initiated start clears any crash history and quarantine for the server
+// server is not modified once StartServer returns: later changes, such as the server becoming
+// ready, are published through the discovery cache and events
 func (ls *LifecycleService) StartServer(server *models.MCPServer) error {
 	if server == nil {
 		return fmt.Errorf("server cannot be nil")
 	}
 
+	return ls.do(server.ID, commandStart, func(ctx context.Context) error {
+		ls.refresh(server)
+		ls.supervisor.Reset(server.ID)
+		server.Status.Quarantined = false
+
+		return ls.startServer(ctx, server)
+	})
+}
+
+// startServer launches the server process without touching supervisor state
+// Shared by StartServer, RestartServer and supervisor-driven restarts; runs on the server's queue
+func (ls *LifecycleService) startServer(ctx context.Context, server *models.MCPServer) error {
 	// Validate current state
 	if server.Status.State != models.StatusStopped && server.Status.State != models.StatusError {
 		return fmt.Errorf("server must be in stopped or error state to start, current state: %s", server.Status.State)
@@ -76,9 +155,35 @@ func (ls *LifecycleService) StartServer(server *models.MCPServer) error {
 		return fmt.Errorf("server installation path is missing")
 	}
 
+	// Inputs the client would have prompted for must be set in the configuration first
+	if missing := server.MissingInputs(); len(missing) > 0 {
+		ids := make([]string, len(missing))
+		for i, input := range missing {
+			ids[i] = input.ID
+		}
+		return fmt.Errorf("server requires user input: %s", strings.Join(ids, ", "))
+	}
+
+	// Build the launch specification from the configuration
+	spec, err := buildLaunchSpec(server)
+	if err != nil {
+		return fmt.Errorf("invalid launch configuration: %w", err)
+	}
+
+	// Prepare readiness detection before anything is launched
+	prober, err := newProber(server)
+	if err != nil {
+		return fmt.Errorf("invalid readiness probe: %w", err)
+	}
+
+	// A stop submitted while this start was in flight wins
+	if ctx.Err() != nil {
+		return ErrStartCancelled
+	}
+
 	// Transition to starting state
 	oldState := server.Status.State
-	if err := server.Status.TransitionTo(models.StatusStarting, "Starting server"); err != nil {
+	if err := ls.transition(server, models.StatusStarting, "Starting server", nil); err != nil {
 		return fmt.Errorf("failed to transition to starting state: %w", err)
 	}
 
@@ -88,36 +193,110 @@ func (ls *LifecycleService) StartServer(server *models.MCPServer) error {
 		ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusStarting))
 	}
 
-	// Extract command and arguments
-	cmd := server.InstallationPath
-	args := server.Configuration.CommandLineArguments
+	// A failing pre-start hook aborts the start
+	if err := ls.runHook(ctx, server, models.HookPreStart); err != nil {
+		if ctx.Err() != nil {
+			ls.transition(server, models.StatusStopped, "Start cancelled during pre-start hook", nil)
+			if ls.discoveryService != nil {
+				ls.discoveryService.UpdateServerState(server)
+			}
+			if ls.eventBus != nil {
+				ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, models.StatusStarting, models.StatusStopped))
+			}
+			return ErrStartCancelled
+		}
+
+		ls.transition(server, models.StatusError, fmt.Sprintf("Pre-start hook failed: %v", err), nil)
+
+		// Synchronously update discovery cache (BUG-001 fix)
+		if ls.discoveryService != nil {
+			ls.discoveryService.UpdateServerState(server)
+		}
+
+		if ls.eventBus != nil {
+			ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, models.StatusStarting, models.StatusError))
+		}
+		return fmt.Errorf("pre-start hook failed: %w", err)
+	}
+
+	// Assign a ${PORT} placeholder, or make sure the configured port is free
+	port, err := ls.preparePort(server, &spec)
+	if err != nil {
+		return ls.failLaunch(server, err)
+	}
+	if err := bindProbePort(prober, port); err != nil {
+		return ls.failLaunch(server, err)
+	}
 
 	// Log command and args for debugging
-	slog.Info("[PROCESS] Starting process", "serverId", server.ID, "command", cmd, "args", args, "argsCount", len(args))
-	for i, arg := range args {
+	slog.Info("[PROCESS] Starting process", "serverId", server.ID, "command", spec.Command, "args", spec.Args, "argsCount", len(spec.Args),
+		"dir", spec.Dir, "envMode", spec.EnvMode, "stdin", spec.Stdin, "sandboxed", spec.Sandbox != nil)
+	for i, arg := range spec.Args {
 		slog.Info("[PROCESS] Argument", "index", i, "value", arg)
 	}
+	if emulation := server.Configuration.Emulation; emulation != nil {
+		ls.publishLog(server.ID, models.LogInfo, fmt.Sprintf("Launching as %s would", emulation.Describe()))
+	}
 
-	// Use environment variables from configuration
-	env := server.Configuration.EnvironmentVariables
+	// Give the process tree its own cgroup where available
+	spec.Cgroup = ls.prepareCgroup(server)
+	spec.OutputFile = ls.prepareOutputFile(server, spec)
 
 	// Start the process with output capture
-	pid, stdout, stderr, err := ls.processManager.StartWithOutput(cmd, args, env)
+	proc, err := ls.processManager.StartWithOutput(spec)
+	if err != nil && spec.Cgroup != "" {
+		slog.Warn("[CGROUP] Launch into cgroup failed, retrying without it", "serverId", server.ID, "cgroup", spec.Cgroup, "error", err)
+		ls.publishLog(server.ID, models.LogWarning, fmt.Sprintf("Could not launch %s in its cgroup, resource limits are not applied: %v", server.Name, err))
+		ls.removeCgroup(server.ID)
+		spec.Cgroup = ""
+		proc, err = ls.processManager.StartWithOutput(spec)
+	}
 	if err != nil {
-		// Transition to error state
-		server.Status.TransitionTo(models.StatusError, fmt.Sprintf("Failed to start: %v", err))
-		if ls.eventBus != nil {
-			ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, models.StatusStarting, models.StatusError))
-		}
-		return fmt.Errorf("failed to start process: %w", err)
+		return ls.failLaunch(server, err)
 	}
 
 	// Update server with PID
-	server.SetPID(pid)
+	server.SetPID(proc.PID)
+	server.Launcher = managerLauncher()
+	server.Sandbox = sandboxProfile(spec.Sandbox)
+	if port != 0 {
+		server.Port = &port
+	}
+	ls.supervisor.RecordStart(server.ID)
+	stdout, stderr := proc.Stdout, proc.Stderr
+
+	// Report what the sandbox refused as it shows up in the output
+	if spec.Sandbox != nil {
+		slog.Info("[SANDBOX] Server launched in sandbox", "serverId", server.ID, "serverName", server.Name,
+			"readPaths", spec.Sandbox.ReadPaths, "writePaths", spec.Sandbox.WritePaths, "network", spec.Sandbox.Network)
+		watch := ls.newSandboxWatch(server)
+		stdout = watch.watch(stdout)
+		stderr = watch.watch(stderr)
+	}
+
+	// Keep the process handle: it holds the stdin pipe open and reports the exit
+	ls.mu.Lock()
+	if previous, exists := ls.processes[server.ID]; exists && previous.Stdin != nil {
+		previous.Stdin.Close()
+	}
+	ls.processes[server.ID] = proc
+	ls.mu.Unlock()
 
 	// Synchronously update discovery cache (BUG-001 fix)
 	if ls.discoveryService != nil {
-		ls.discoveryService.UpdateServer(server)
+		ls.discoveryService.UpdateServerState(server)
+	}
+
+	// A log readiness probe watches output on its way to the log buffer
+	if logProbe, ok := prober.(*logProber); ok {
+		stdout = logProbe.watch(stdout)
+		stderr = logProbe.watch(stderr)
+
+		// Without log capture nobody reads the pipes, so drain them here
+		if ls.monitoringService == nil {
+			go io.Copy(io.Discard, stdout)
+			go io.Copy(io.Discard, stderr)
+		}
 	}
 
 	// Start capturing output if monitoring service is available
@@ -125,6 +304,10 @@ func (ls *LifecycleService) StartServer(server *models.MCPServer) error {
 		// Create context for output capture
 		ctx, cancel := context.WithCancel(context.Background())
 		ls.mu.Lock()
+		if previous, exists := ls.captureContexts[server.ID]; exists {
+			// Release capture left over from a crashed previous run
+			previous()
+		}
 		ls.captureContexts[server.ID] = cancel
 		ls.mu.Unlock()
 
@@ -142,18 +325,68 @@ func (ls *LifecycleService) StartServer(server *models.MCPServer) error {
 	}
 
 	// Start monitoring the process
-	ls.startMonitoring(server)
+	ls.startMonitoring(server, proc, prober)
 
 	return nil
 }
 
+// prepareOutputFile returns the file the server writes its output to, or "" for pipes
+// Servers whose stdin is a pipe from the manager stop with it anyway, so they keep pipes
+func (ls *LifecycleService) prepareOutputFile(server *models.MCPServer, spec platform.LaunchSpec) string {
+	ls.mu.RLock()
+	dir := ls.outputDir
+	ls.mu.RUnlock()
+
+	if dir == "" || spec.Stdin == platform.StdinPipe {
+		return ""
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		slog.Warn("[PROCESS] Cannot create output directory, using pipes", "serverId", server.ID, "dir", dir, "error", err)
+		return ""
+	}
+	return filepath.Join(dir, server.ID+".log")
+}
+
+// failLaunch moves a starting server whose process could not be launched to the error state
+func (ls *LifecycleService) failLaunch(server *models.MCPServer, err error) error {
+	// Transition to error state
+	ls.transition(server, models.StatusError, fmt.Sprintf("Failed to start: %v", err), nil)
+
+	// Synchronously update discovery cache (BUG-001 fix)
+	if ls.discoveryService != nil {
+		ls.discoveryService.UpdateServerState(server)
+	}
+
+	if ls.eventBus != nil {
+		ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, models.StatusStarting, models.StatusError))
+	}
+
+	// Undo whatever the pre-start hook set up
+	if server.Configuration.Hooks.Get(models.HookPreStart) != nil {
+		ls.runHookLogged(context.Background(), server, models.HookPostStop)
+	}
+	return fmt.Errorf("failed to start process: %w", err)
+}
+
 // StopServer stops an MCP server
 // If graceful is true, attempts graceful shutdown before forcing termination
+// A start or restart of the server that has not finished yet is cancelled
 func (ls *LifecycleService) StopServer(server *models.MCPServer, force bool, timeout int) error {
 	if server == nil {
 		return fmt.Errorf("server cannot be nil")
 	}
 
+	// A user-initiated stop cancels any pending crash restart
+	ls.supervisor.Cancel(server.ID)
+
+	return ls.do(server.ID, commandStop, func(ctx context.Context) error {
+		ls.refresh(server)
+		return ls.stopServer(server, force, timeout)
+	})
+}
+
+// stopServer stops the server process; runs on the server's queue
+func (ls *LifecycleService) stopServer(server *models.MCPServer, force bool, timeout int) error {
 	slog := slog.With("serverId", server.ID, "serverName", server.Name)
 	slog.Info("StopServer: Starting stop operation")
 
@@ -183,12 +416,13 @@ func (ls *LifecycleService) StopServer(server *models.MCPServer, force bool, tim
 		slog.Warn("StopServer: Process is not running", "pid", pid)
 		// Process already dead, just update state
 		oldState := server.Status.State
-		server.Status.TransitionTo(models.StatusStopped, "Process not running")
-		server.PID = nil
+		exit := ls.releaseProcess(server.ID, 0)
+		ls.transition(server, models.StatusStopped, "Process not running", exit)
+		server.ClearPID()
 
 		// Synchronously update discovery cache (BUG-001 fix)
 		if ls.discoveryService != nil {
-			ls.discoveryService.UpdateServer(server)
+			ls.discoveryService.UpdateServerState(server)
 			slog.Info("StopServer: Cache synchronized (process was not running)")
 		}
 
@@ -200,56 +434,145 @@ func (ls *LifecycleService) StopServer(server *models.MCPServer, force bool, tim
 				fmt.Sprintf("Server %s was already stopped (PID %d not found)", server.Name, pid),
 			)
 			ls.eventBus.Publish(events.ServerLogEntryEvent(server.ID, logEntry))
-			ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusStopped))
+			ls.eventBus.Publish(events.ServerExitedStatusEvent(server.ID, oldState, models.StatusStopped, exit))
 		}
+
+		ls.runHookLogged(context.Background(), server, models.HookPostStop)
 		return nil
 	}
 
-	// Stop the process
+	// The pre-stop hook runs while the server is still up; a forced stop skips it
+	if !force {
+		ls.runHookLogged(context.Background(), server, models.HookPreStop)
+	}
+
+	// Stage 1: an MCP server exits cleanly once its stdin reaches EOF
+	stdinStage := false
+	if !force {
+		stdinStage = ls.closeStdin(server)
+	}
+
+	// Stages 2 and 3: SIGTERM, wait for timeout seconds, SIGKILL
+	// After a clean exit this only sweeps up processes the server left behind
 	graceful := !force
 	slog.Info("StopServer: Calling process manager Stop", "pid", pid, "graceful", graceful)
-	if err := ls.processManager.Stop(pid, graceful, timeout); err != nil {
+	report, err := ls.processManager.Stop(pid, graceful, timeout)
+	if report != nil && stdinStage {
+		report.Stage = platform.StageStdin
+	}
+	if report != nil {
+		slog.Info("StopServer: Shutdown stage that ended the process", "pid", pid, "stage", report.Stage)
+		for _, p := range report.Processes {
+			slog.Info("StopServer: Process tree member", "pid", p.PID, "ppid", p.PPID, "name", p.Name, "escaped", p.Escaped, "outcome", p.Outcome)
+		}
+	}
+	if err != nil {
 		slog.Error("StopServer: Process manager Stop failed", "pid", pid, "error", err)
+
+		// Surface which processes survived
+		if ls.eventBus != nil {
+			logEntry := models.NewLogEntry(
+				models.LogError,
+				server.ID,
+				fmt.Sprintf("Failed to stop server %s (PID: %d); process tree: %s", server.Name, pid, report.Summary()),
+			)
+			logEntry.Metadata["stopReport"] = report
+			ls.eventBus.Publish(events.ServerLogEntryEvent(server.ID, logEntry))
+		}
 		return fmt.Errorf("failed to stop process %d: %w", pid, err)
 	}
 
-	slog.Info("StopServer: Process stopped successfully", "pid", pid)
+	slog.Info("StopServer: Process stopped successfully", "pid", pid, "processes", len(report.Processes))
 
 	// Publish log entry for stop operation (Issue 1 fix)
 	if ls.eventBus != nil {
 		logEntry := models.NewLogEntry(
 			models.LogInfo,
 			server.ID,
-			fmt.Sprintf("Server %s stopped successfully (PID: %d)", server.Name, pid),
+			fmt.Sprintf("Server %s stopped successfully (PID: %d, %s); process tree: %s", server.Name, pid, describeStopStage(report.Stage), report.Summary()),
 		)
+		logEntry.Metadata["stopReport"] = report
+		logEntry.Metadata["stopStage"] = report.Stage
 		ls.eventBus.Publish(events.ServerLogEntryEvent(server.ID, logEntry))
 	}
 
+	// Collect the exit status of the stopped process
+	exit := ls.releaseProcess(server.ID, exitCollectTimeout)
+	if exit != nil {
+		slog.Info("StopServer: Process exit collected", "exitCode", exit.ExitCode, "signal", exit.Signal)
+	}
+
 	// Transition to stopped state
 	oldState := server.Status.State
-	if err := server.Status.TransitionTo(models.StatusStopped, "Server stopped"); err != nil {
+	if err := ls.transition(server, models.StatusStopped, "Server stopped", exit); err != nil {
 		slog.Error("StopServer: Failed to transition to stopped state", "error", err)
 		return fmt.Errorf("failed to transition to stopped state: %w", err)
 	}
 
 	// Clear PID
-	server.PID = nil
+	server.ClearPID()
 
 	// Synchronously update discovery cache (BUG-001 fix)
 	if ls.discoveryService != nil {
-		ls.discoveryService.UpdateServer(server)
+		ls.discoveryService.UpdateServerState(server)
 		slog.Info("StopServer: Cache synchronized with stopped state")
 	}
 
 	// Publish status changed event
 	if ls.eventBus != nil {
-		ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusStopped))
+		ls.eventBus.Publish(events.ServerExitedStatusEvent(server.ID, oldState, models.StatusStopped, exit))
 	}
 
+	ls.runHookLogged(context.Background(), server, models.HookPostStop)
+
 	slog.Info("StopServer: Stop operation completed successfully")
 	return nil
 }
 
+// closeStdin closes the stdin pipe held for a server and waits for its process to exit
+// Returns true if the process exited within the server's StdinCloseTimeout; false if
+// this service holds no stdin pipe or cannot observe the exit
+func (ls *LifecycleService) closeStdin(server *models.MCPServer) bool {
+	ls.mu.RLock()
+	proc, exists := ls.processes[server.ID]
+	ls.mu.RUnlock()
+	if !exists || proc.Stdin == nil || proc.Exited == nil {
+		return false
+	}
+
+	timeout := defaultStdinCloseTimeout
+	if server.Configuration.StdinCloseTimeout > 0 {
+		timeout = time.Duration(server.Configuration.StdinCloseTimeout) * time.Second
+	}
+
+	slog.Info("StopServer: Closing stdin", "serverId", server.ID, "pid", proc.PID, "timeout", timeout)
+	proc.Stdin.Close()
+
+	select {
+	case <-proc.Exited:
+		return true
+	case <-time.After(timeout):
+		slog.Info("StopServer: Process did not exit after stdin was closed", "serverId", server.ID, "pid", proc.PID)
+		return false
+	}
+}
+
+// describeStopStage returns how a stopped process ended, for log messages
+func describeStopStage(stage platform.StopStage) string {
+	switch stage {
+	case platform.StageStdin:
+		return "exited after stdin was closed"
+	case platform.StageTerminate:
+		return "exited after the termination signal"
+	case platform.StageKill:
+		return "force killed"
+	case platform.StageNotRunning:
+		return "had already exited"
+	default:
+		return "stopped"
+	}
+}
+
 // RestartServer restarts an MCP server
 // Implements restart as stop + start
 func (ls *LifecycleService) RestartServer(server *models.MCPServer) error {
@@ -257,33 +580,45 @@ func (ls *LifecycleService) RestartServer(server *models.MCPServer) error {
 		return fmt.Errorf("server cannot be nil")
 	}
 
-	// Check transport type - stdio servers cannot be restarted directly
-	if server.Transport == models.TransportStdio {
-		return fmt.Errorf("cannot restart stdio transport servers directly; they must be restarted through their MCP client")
-	}
+	ls.supervisor.Cancel(server.ID)
 
-	// Stop the server (graceful with 10s timeout)
-	if err := ls.StopServer(server, false, 10); err != nil {
-		return fmt.Errorf("failed to stop server during restart: %w", err)
-	}
+	return ls.do(server.ID, commandRestart, func(ctx context.Context) error {
+		ls.refresh(server)
 
-	// Wait for stopped state
-	// (StopServer should have already transitioned to stopped)
-	if server.Status.State != models.StatusStopped {
-		return fmt.Errorf("server not in stopped state after stop: %s", server.Status.State)
-	}
+		// A stdio server's pipes belong to the process that launched it, so only
+		// servers launched by this service can be restarted in place
+		if server.Transport == models.TransportStdio && !ls.ownsProcess(server.ID) {
+			if server.Launcher != nil && !server.Launcher.Manager {
+				return fmt.Errorf("cannot restart stdio server %s: it was launched by %s and must be restarted through that MCP client", server.Name, server.Launcher.Describe())
+			}
+			return fmt.Errorf("cannot restart stdio server %s: it was not launched by MCP Manager and must be restarted through its MCP client", server.Name)
+		}
 
-	// Start the server
-	if err := ls.StartServer(server); err != nil {
-		return fmt.Errorf("failed to start server during restart: %w", err)
-	}
+		// Stop the server gracefully within its configured shutdown timeout
+		if err := ls.stopServer(server, false, server.Configuration.ShutdownTimeout); err != nil {
+			return fmt.Errorf("failed to stop server during restart: %w", err)
+		}
 
-	return nil
+		// Wait for stopped state
+		// (stopServer should have already transitioned to stopped)
+		if server.Status.State != models.StatusStopped {
+			return fmt.Errorf("server not in stopped state after stop: %s", server.Status.State)
+		}
+
+		// Start the server
+		ls.supervisor.Reset(server.ID)
+		server.Status.Quarantined = false
+		if err := ls.startServer(ctx, server); err != nil {
+			return fmt.Errorf("failed to start server during restart: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // startMonitoring begins monitoring a server process
-// Monitors for process exit and transitions to error if process dies within 5 seconds
-func (ls *LifecycleService) startMonitoring(server *models.MCPServer) {
+// Waits for readiness and transitions to error if the process dies within 5 seconds
+func (ls *LifecycleService) startMonitoring(server *models.MCPServer, proc *platform.ManagedProcess, prober Prober) {
 	ls.mu.Lock()
 	defer ls.mu.Unlock()
 
@@ -296,8 +631,11 @@ func (ls *LifecycleService) startMonitoring(server *models.MCPServer) {
 	stopChan := make(chan struct{})
 	ls.monitors[server.ID] = stopChan
 
+	// The monitor gets its own copy: the caller's server is not touched once the start returns
+	launched := *server
+
 	// Start monitoring goroutine
-	go ls.monitorProcess(server, stopChan)
+	go ls.monitorProcess(&launched, proc, proc.PID, prober, stopChan)
 }
 
 // stopMonitoring stops monitoring a server process
@@ -311,21 +649,27 @@ func (ls *LifecycleService) stopMonitoring(serverID string) {
 	}
 }
 
-// monitorProcess monitors a server process for unexpected exits
-func (ls *LifecycleService) monitorProcess(server *models.MCPServer, stopChan chan struct{}) {
-	if server.PID == nil {
-		return
-	}
-
-	pid := *server.PID
+// monitorProcess monitors a server process for readiness and unexpected exits
+// The server stays in starting until the readiness probe succeeds or StartupTimeout expires
+// Exits are observed through the process's wait handle; only processes without one are polled
+// launched is the monitor's copy of the server, which only the handlers on the server's queue modify
+func (ls *LifecycleService) monitorProcess(launched *models.MCPServer, proc *platform.ManagedProcess, pid int, prober Prober, stopChan chan struct{}) {
+	serverID := launched.ID
 	startTime := time.Now()
 
-	// Check process every 100ms
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
+	exited := proc.Exited
+	var poll <-chan time.Time
+	if exited == nil {
+		// Check process every 100ms
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
 
-	// Track if we've transitioned to running
-	transitionedToRunning := false
+	// Probe for readiness in the background, bounded by the startup timeout
+	readyCtx, cancelReady := context.WithTimeout(context.Background(), startupTimeout(launched))
+	defer cancelReady()
+	ready := awaitReadiness(readyCtx, prober, probeInterval(launched))
 
 	for {
 		select {
@@ -333,71 +677,411 @@ func (ls *LifecycleService) monitorProcess(server *models.MCPServer, stopChan ch
 			// Monitoring stopped
 			return
 
-		case <-ticker.C:
+		case err := <-ready:
+			ready = nil
+			ls.submit(serverID, commandMonitor, func(ctx context.Context) error {
+				ls.handleReadiness(launched, proc, pid, stopChan, startTime, err)
+				return nil
+			})
+
+		case <-exited:
+			// Process has exited and been reaped
+			ls.submitExit(launched, pid, stopChan, time.Since(startTime), toProcessExit(proc.Exit))
+			return
+
+		case <-poll:
 			// Check if process is still running
 			if !ls.processManager.IsRunning(pid) {
 				// Process has exited
-				elapsed := time.Since(startTime)
-
-				if elapsed < 5*time.Second {
-					// Process exited too quickly - transition to error
-					oldState := server.Status.State
-					slog.Error("[MONITOR] Process crashed (exited too quickly)", "serverId", server.ID, "serverName", server.Name, "elapsed", elapsed, "pid", pid)
-					server.Status.TransitionTo(models.StatusError, "Process exited unexpectedly")
-					server.PID = nil
-
-					// Synchronously update discovery cache (BUG-001 fix)
-					if ls.discoveryService != nil {
-						ls.discoveryService.UpdateServer(server)
-					}
-
-					if ls.eventBus != nil {
-						slog.Info("[EVENT] Publishing server.status.changed (crashed)", "serverId", server.ID, "oldState", oldState, "newState", models.StatusError)
-						ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusError))
-					}
-				} else {
-					// Process exited after running for a while - transition to stopped
-					oldState := server.Status.State
-					server.Status.TransitionTo(models.StatusStopped, "Process exited")
-					server.PID = nil
-
-					// Synchronously update discovery cache (BUG-001 fix)
-					if ls.discoveryService != nil {
-						ls.discoveryService.UpdateServer(server)
-					}
-
-					if ls.eventBus != nil {
-						ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusStopped))
-					}
-				}
-
-				// Stop monitoring
-				ls.stopMonitoring(server.ID)
+				ls.submitExit(launched, pid, stopChan, time.Since(startTime), nil)
 				return
 			}
+		}
+	}
+}
+
+// handleReadiness acts on the readiness result reported by a monitor
+// Runs on the server's queue, so a stop or restart may have superseded the monitor
+func (ls *LifecycleService) handleReadiness(launched *models.MCPServer, proc *platform.ManagedProcess, pid int, stopChan chan struct{}, startTime time.Time, probeErr error) {
+	if !ls.isMonitoring(launched.ID, stopChan) {
+		return
+	}
+
+	server := ls.monitoredServer(launched)
+	if server.Status.State != models.StatusStarting {
+		return
+	}
+
+	// The process may have died while the last probe was in flight
+	if exit := proc.ExitInfo(); exit != nil || (proc.Exited == nil && !ls.processManager.IsRunning(pid)) {
+		ls.releaseMonitor(server.ID, stopChan)
+		ls.handleProcessExit(server, pid, time.Since(startTime), toProcessExit(exit))
+		return
+	}
+
+	if probeErr != nil {
+		ls.releaseMonitor(server.ID, stopChan)
+		ls.handleStartupTimeout(server, pid, probeErr)
+		return
+	}
 
-			// If still in starting state and process is alive, transition to running
-			if !transitionedToRunning && server.Status.State == models.StatusStarting {
-				// Wait at least 500ms before transitioning to running
-				if time.Since(startTime) >= 500*time.Millisecond {
-					oldState := server.Status.State
-					server.Status.TransitionTo(models.StatusRunning, "Server started successfully")
-
-					// Synchronously update discovery cache (BUG-001 fix)
-					if ls.discoveryService != nil {
-						ls.discoveryService.UpdateServer(server)
-					}
-
-					if ls.eventBus != nil {
-						slog.Info("[EVENT] Publishing server.status.changed (monitor)", "serverId", server.ID, "oldState", oldState, "newState", models.StatusRunning)
-						ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusRunning))
-					}
-
-					transitionedToRunning = true
-				}
+	oldState := server.Status.State
+	ls.transition(server, models.StatusRunning, "Server started successfully", nil)
+	slog.Info("[MONITOR] Server ready", "serverId", server.ID, "serverName", server.Name, "elapsed", time.Since(startTime))
+
+	// Synchronously update discovery cache (BUG-001 fix)
+	if ls.discoveryService != nil {
+		ls.discoveryService.UpdateServerState(server)
+	}
+
+	if ls.eventBus != nil {
+		slog.Info("[EVENT] Publishing server.status.changed (monitor)", "serverId", server.ID, "oldState", oldState, "newState", models.StatusRunning)
+		ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusRunning))
+	}
+
+	ls.runHookLogged(context.Background(), server, models.HookPostStart)
+}
+
+// submitExit queues handling of an exit observed by a monitor
+// The exit is ignored if the monitor was stopped in the meantime (the process was stopped on purpose)
+func (ls *LifecycleService) submitExit(launched *models.MCPServer, pid int, stopChan chan struct{}, elapsed time.Duration, exit *models.ProcessExit) {
+	ls.submit(launched.ID, commandMonitor, func(ctx context.Context) error {
+		if !ls.releaseMonitor(launched.ID, stopChan) {
+			return nil
+		}
+		ls.handleProcessExit(ls.monitoredServer(launched), pid, elapsed, exit)
+		return nil
+	})
+}
+
+// monitoredServer returns the server a monitor's handler acts on: a copy of the cached server,
+// or the monitor's own copy if the server is not cached
+// Handlers publish their changes through the discovery cache, never through the server
+// passed to StartServer, which belongs to the caller once the start returns
+func (ls *LifecycleService) monitoredServer(launched *models.MCPServer) *models.MCPServer {
+	if cached, exists := ls.cachedServer(launched.ID); exists {
+		return cached
+	}
+	return launched
+}
+
+// handleStartupTimeout moves a server that never became ready to the error state
+// The unready process is stopped so it does not linger without a managed PID
+func (ls *LifecycleService) handleStartupTimeout(server *models.MCPServer, pid int, probeErr error) {
+	reason := fmt.Sprintf("Server did not become ready within %s: %v", startupTimeout(server), probeErr)
+	slog.Error("[MONITOR] Startup timed out", "serverId", server.ID, "serverName", server.Name, "pid", pid, "error", probeErr)
+
+	if _, err := ls.processManager.Stop(pid, true, server.Configuration.ShutdownTimeout); err != nil {
+		slog.Error("[MONITOR] Failed to stop unready process", "serverId", server.ID, "pid", pid, "error", err)
+	}
+
+	oldState := server.Status.State
+	exit := ls.releaseProcess(server.ID, exitCollectTimeout)
+	ls.transition(server, models.StatusError, reason, exit)
+	server.ClearPID()
+
+	// A server that cannot become ready is handled like a crash
+	ls.supervisor.HandleCrash(server)
+
+	// Synchronously update discovery cache (BUG-001 fix)
+	if ls.discoveryService != nil {
+		ls.discoveryService.UpdateServerState(server)
+	}
+
+	if ls.eventBus != nil {
+		logEntry := models.NewLogEntry(models.LogError, server.ID, reason)
+		ls.eventBus.Publish(events.ServerLogEntryEvent(server.ID, logEntry))
+		ls.eventBus.Publish(events.ServerExitedStatusEvent(server.ID, oldState, models.StatusError, exit))
+	}
+
+	ls.runHookLogged(context.Background(), server, models.HookPostStop)
+}
+
+// releaseMonitor removes a monitor's registration if it is still the active one
+// A supervisor restart may already have registered a new monitor for the server
+// Returns false if the monitor was no longer active
+func (ls *LifecycleService) releaseMonitor(serverID string, stopChan chan struct{}) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if current, exists := ls.monitors[serverID]; exists && current == stopChan {
+		close(stopChan)
+		delete(ls.monitors, serverID)
+		return true
+	}
+	return false
+}
+
+// isMonitoring returns true if stopChan belongs to the server's active monitor
+func (ls *LifecycleService) isMonitoring(serverID string, stopChan chan struct{}) bool {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	current, exists := ls.monitors[serverID]
+	return exists && current == stopChan
+}
+
+// refresh loads the authoritative runtime state (status, PID, launcher, sandbox and port) of a server from the
+// discovery cache into the caller's copy; servers that are not cached are left as they are
+func (ls *LifecycleService) refresh(server *models.MCPServer) {
+	if ls.discoveryService == nil {
+		return
+	}
+	if cached, exists := ls.discoveryService.GetServerByID(server.ID); exists {
+		server.Status = cached.Status
+		server.PID = cached.PID
+		server.Launcher = cached.Launcher
+		server.Sandbox = cached.Sandbox
+		server.Port = cached.Port
+	}
+}
+
+// ownsProcess returns true if the server's current process was launched (or re-adopted) by this service
+func (ls *LifecycleService) ownsProcess(serverID string) bool {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	_, exists := ls.processes[serverID]
+	return exists
+}
+
+// managerLauncher describes this process as the launcher of the servers it starts
+func managerLauncher() *models.ServerLauncher {
+	return &models.ServerLauncher{PID: os.Getpid(), Name: "MCP Manager", Manager: true}
+}
+
+// handleProcessExit transitions a server whose process exited without being asked to
+// Early exits are always crashes. Later exits are crashes when the exit status shows a
+// failure (non-zero code or a signal); without an exit status, any unexpected exit of a
+// server with RestartOnCrash is a crash. Crashes are handed to the supervisor
+func (ls *LifecycleService) handleProcessExit(server *models.MCPServer, pid int, elapsed time.Duration, exit *models.ProcessExit) {
+	oldState := server.Status.State
+
+	crashed := elapsed < 5*time.Second
+	if !crashed {
+		if exit != nil {
+			crashed = !exit.Clean()
+		} else {
+			crashed = server.Configuration.RestartOnCrash
+		}
+	}
+
+	if !crashed {
+		// Process exited after running for a while - transition to stopped
+		ls.transition(server, models.StatusStopped, "Process exited", exit)
+		server.ClearPID()
+		ls.releaseProcess(server.ID, 0)
+
+		// Synchronously update discovery cache (BUG-001 fix)
+		if ls.discoveryService != nil {
+			ls.discoveryService.UpdateServerState(server)
+		}
+
+		if ls.eventBus != nil {
+			ls.eventBus.Publish(events.ServerExitedStatusEvent(server.ID, oldState, models.StatusStopped, exit))
+		}
+
+		ls.runHookLogged(context.Background(), server, models.HookPostStop)
+		return
+	}
+
+	reason := "Process exited unexpectedly"
+	if elapsed >= 5*time.Second {
+		reason = fmt.Sprintf("Process exited unexpectedly after %s", elapsed.Round(time.Second))
+	}
+	if exit != nil {
+		reason = fmt.Sprintf("%s (%s)", reason, exit.Describe())
+	}
+
+	exitStatus := "unknown"
+	if exit != nil {
+		exitStatus = exit.Describe()
+	}
+	slog.Error("[MONITOR] Process crashed", "serverId", server.ID, "serverName", server.Name, "elapsed", elapsed, "pid", pid, "exit", exitStatus)
+	ls.transition(server, models.StatusError, reason, exit)
+	server.ClearPID()
+	ls.releaseProcess(server.ID, 0)
+
+	// Let the supervisor decide on a restart before the cache is synchronized,
+	// so that a quarantine is visible together with the error state
+	ls.supervisor.HandleCrash(server)
+
+	// Synchronously update discovery cache (BUG-001 fix)
+	if ls.discoveryService != nil {
+		ls.discoveryService.UpdateServerState(server)
+	}
+
+	if ls.eventBus != nil {
+		slog.Info("[EVENT] Publishing server.status.changed (crashed)", "serverId", server.ID, "oldState", oldState, "newState", models.StatusError)
+		ls.eventBus.Publish(events.ServerExitedStatusEvent(server.ID, oldState, models.StatusError, exit))
+	}
+
+	ls.runHookLogged(context.Background(), server, models.HookPostStop)
+}
+
+// restartCrashedServer is invoked by the supervisor when a scheduled restart fires
+// The restart is queued like a user start, so a stop submitted meanwhile cancels it
+// crashed is the server as it was when it crashed; the cached server is restarted instead,
+// with any configuration changed since
+func (ls *LifecycleService) restartCrashedServer(crashed *models.MCPServer) {
+	ls.submit(crashed.ID, commandStart, func(ctx context.Context) error {
+		server := crashed
+		if cached, exists := ls.cachedServer(crashed.ID); exists {
+			server = cached
+		}
+		ls.refresh(server)
+		if server.Status.State != models.StatusError {
+			// The user intervened while the restart was pending
+			slog.Info("[SUPERVISOR] Skipping restart, server is no longer in error state", "serverId", server.ID, "state", server.Status.State)
+			return nil
+		}
+
+		slog.Info("[SUPERVISOR] Restarting crashed server", "serverId", server.ID, "serverName", server.Name)
+		err := ls.startServer(ctx, server)
+		if err != nil && err != ErrStartCancelled {
+			slog.Error("[SUPERVISOR] Restart failed", "serverId", server.ID, "error", err)
+
+			// A failed launch counts as another crash
+			ls.supervisor.HandleCrash(server)
+			if ls.discoveryService != nil {
+				ls.discoveryService.UpdateServerState(server)
 			}
 		}
+		return err
+	})
+}
+
+// transition moves a server to newState and records the change in the journal
+// Call it before clearing the PID so the record names the process involved
+// exit, if known, becomes the server's LastExit
+func (ls *LifecycleService) transition(server *models.MCPServer, newState models.StatusState, reason string, exit *models.ProcessExit) error {
+	oldState := server.Status.State
+	if err := server.Status.TransitionTo(newState, reason); err != nil {
+		return err
+	}
+	if exit != nil {
+		server.Status.LastExit = exit
+	}
+
+	record := models.StateTransition{
+		ServerID:  server.ID,
+		OldState:  oldState,
+		NewState:  newState,
+		Reason:    reason,
+		Timestamp: server.Status.LastStateChange,
+		Exit:      exit,
+	}
+	if server.PID != nil {
+		pid := *server.PID
+		record.PID = &pid
+	}
+
+	ls.record(record)
+	return nil
+}
+
+// record appends a state transition to the journal, if one is set
+func (ls *LifecycleService) record(transition models.StateTransition) {
+	ls.mu.RLock()
+	journal := ls.journal
+	ls.mu.RUnlock()
+	if journal == nil {
+		return
+	}
+
+	if err := journal.AppendTransition(transition); err != nil {
+		slog.Warn("[JOURNAL] Failed to record state transition", "serverId", transition.ServerID, "newState", transition.NewState, "error", err)
+	}
+}
+
+// releaseProcess forgets a server's process handle and closes its stdin pipe
+// It waits up to timeout for the process to be reaped and returns its exit status,
+// or nil if the process was not launched by this service or has not exited in time
+func (ls *LifecycleService) releaseProcess(serverID string, timeout time.Duration) *models.ProcessExit {
+	ls.mu.Lock()
+	proc, exists := ls.processes[serverID]
+	delete(ls.processes, serverID)
+	ls.mu.Unlock()
+
+	if !exists {
+		return nil
+	}
+	if proc.Stdin != nil {
+		proc.Stdin.Close()
+	}
+	defer ls.removeCgroup(serverID)
+	if proc.Exited == nil {
+		return nil
+	}
+
+	select {
+	case <-proc.Exited:
+		return toProcessExit(proc.Exit)
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+// toProcessExit converts the process manager's exit information to the model
+func toProcessExit(info *platform.ExitInfo) *models.ProcessExit {
+	if info == nil {
+		return nil
+	}
+	return &models.ProcessExit{
+		ExitCode:   info.ExitCode,
+		Signal:     info.Signal,
+		UserTime:   info.UserTime,
+		SystemTime: info.SystemTime,
+		MaxRSS:     info.MaxRSS,
+		ExitedAt:   info.ExitedAt,
+	}
+}
+
+// buildLaunchSpec translates a server's configuration into a launch specification
+func buildLaunchSpec(server *models.MCPServer) (platform.LaunchSpec, error) {
+	cfg := server.Configuration
+
+	umask, err := cfg.UmaskValue()
+	if err != nil {
+		return platform.LaunchSpec{}, err
+	}
+
+	stdin := platform.StdinMode(cfg.Stdin)
+	if stdin == "" {
+		stdin = platform.StdinNull
+		// stdio servers read MCP messages from stdin and exit on EOF
+		if server.Transport == models.TransportStdio {
+			stdin = platform.StdinPipe
+		}
+	}
+
+	spec := platform.LaunchSpec{
+		Command:      server.InstallationPath,
+		Args:         cfg.CommandLineArguments,
+		Dir:          cfg.WorkingDirectory,
+		Env:          cfg.EnvironmentVariables,
+		EnvMode:      platform.EnvMode(cfg.EnvInheritance),
+		EnvAllowlist: cfg.EnvAllowlist,
+		ExtraPath:    cfg.ExtraPath,
+		Umask:        umask,
+		Stdin:        stdin,
+		ProcessGroup: platform.ProcessGroupNew,
+	}
+
+	if cfg.Sandbox != nil {
+		spec.Sandbox, err = sandboxSpec(cfg.Sandbox)
+		if err != nil {
+			return platform.LaunchSpec{}, err
+		}
 	}
+
+	if cfg.Emulation != nil {
+		if err := applyEmulation(&spec, cfg.Emulation); err != nil {
+			return platform.LaunchSpec{}, err
+		}
+	}
+
+	return spec, spec.Validate()
 }
 
 // stopOutputCapture stops capturing output for a server
@@ -456,21 +1140,35 @@ func (ls *LifecycleService) validateAllPIDs() {
 		// Validate PID still exists
 		pid := *server.PID
 		if !ls.processManager.IsRunning(pid) {
-			// PID is stale - process died
-			slog.Info("[VALIDATOR] Detected stale PID", "serverId", server.ID, "serverName", server.Name, "pid", pid, "previousState", server.Status.State)
+			ls.submit(server.ID, commandValidate, func(ctx context.Context) error {
+				ls.invalidatePID(&server, pid)
+				return nil
+			})
+		}
+	}
+}
 
-			oldState := server.Status.State
-			server.Status.TransitionTo(models.StatusStopped, "Process no longer running")
-			server.PID = nil
+// invalidatePID moves a server whose unmonitored process died to stopped
+// Runs on the server's queue; does nothing if an earlier command already changed the PID
+func (ls *LifecycleService) invalidatePID(server *models.MCPServer, pid int) {
+	ls.refresh(server)
+	if server.PID == nil || *server.PID != pid || ls.processManager.IsRunning(pid) {
+		return
+	}
 
-			// Update discovery cache
-			ls.discoveryService.UpdateServer(&server)
+	// PID is stale - process died
+	slog.Info("[VALIDATOR] Detected stale PID", "serverId", server.ID, "serverName", server.Name, "pid", pid, "previousState", server.Status.State)
 
-			// Publish status changed event
-			if ls.eventBus != nil {
-				ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusStopped))
-			}
-		}
+	oldState := server.Status.State
+	ls.transition(server, models.StatusStopped, "Process no longer running", nil)
+	server.ClearPID()
+
+	// Update discovery cache
+	ls.discoveryService.UpdateServerState(server)
+
+	// Publish status changed event
+	if ls.eventBus != nil {
+		ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusStopped))
 	}
 }
 
@@ -484,6 +1182,9 @@ func (ls *LifecycleService) StopAll() {
 		close(ls.validatorStop)
 	}
 
+	// Cancel pending crash restarts
+	ls.supervisor.Close()
+
 	// Stop all monitors
 	for _, stopChan := range ls.monitors {
 		close(stopChan)
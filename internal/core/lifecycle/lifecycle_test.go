@@ -10,36 +10,43 @@ import (
 
 	"github.com/Positronikal/MCPManager/internal/core/events"
 	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
 )
 
 // MockProcessManager is a mock implementation of ProcessManager for testing
 type MockProcessManager struct {
-	StartFunc           func(cmd string, args []string, env map[string]string) (int, error)
-	StartWithOutputFunc func(cmd string, args []string, env map[string]string) (int, io.ReadCloser, io.ReadCloser, error)
+	StartFunc           func(spec platform.LaunchSpec) (int, error)
+	StartWithOutputFunc func(spec platform.LaunchSpec) (*platform.ManagedProcess, error)
 	StopFunc            func(pid int, graceful bool, timeout int) error
 	IsRunningFunc       func(pid int) bool
 }
 
-func (m *MockProcessManager) Start(cmd string, args []string, env map[string]string) (int, error) {
+func (m *MockProcessManager) Start(spec platform.LaunchSpec) (int, error) {
 	if m.StartFunc != nil {
-		return m.StartFunc(cmd, args, env)
+		return m.StartFunc(spec)
 	}
 	return 1234, nil
 }
 
-func (m *MockProcessManager) StartWithOutput(cmd string, args []string, env map[string]string) (int, io.ReadCloser, io.ReadCloser, error) {
+func (m *MockProcessManager) StartWithOutput(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
 	if m.StartWithOutputFunc != nil {
-		return m.StartWithOutputFunc(cmd, args, env)
+		return m.StartWithOutputFunc(spec)
 	}
 	// Return mock readers (empty)
-	return 1234, io.NopCloser(strings.NewReader("")), io.NopCloser(strings.NewReader("")), nil
+	proc := mockProcess(1234)
+	proc.OutputFile = spec.OutputFile
+	return proc, nil
 }
 
-func (m *MockProcessManager) Stop(pid int, graceful bool, timeout int) error {
+func (m *MockProcessManager) Stop(pid int, graceful bool, timeout int) (*platform.StopReport, error) {
+	report := &platform.StopReport{
+		PID:       pid,
+		Processes: []platform.ProcessTermination{{PID: pid, Outcome: platform.OutcomeExited}},
+	}
 	if m.StopFunc != nil {
-		return m.StopFunc(pid, graceful, timeout)
+		return report, m.StopFunc(pid, graceful, timeout)
 	}
-	return nil
+	return report, nil
 }
 
 func (m *MockProcessManager) IsRunning(pid int) bool {
@@ -49,16 +56,33 @@ func (m *MockProcessManager) IsRunning(pid int) bool {
 	return true
 }
 
+// mockProcess returns a ManagedProcess with empty output
+func mockProcess(pid int) *platform.ManagedProcess {
+	return &platform.ManagedProcess{
+		PID:    pid,
+		Stdout: io.NopCloser(strings.NewReader("")),
+		Stderr: io.NopCloser(strings.NewReader("")),
+	}
+}
+
 // MockDiscoveryService is a mock implementation of DiscoveryService for testing (BUG-001 fix)
 type MockDiscoveryService struct {
-	UpdateServerFunc     func(server *models.MCPServer)
-	GetCachedServersFunc func() []models.MCPServer
+	UpdateServerStateFunc func(server *models.MCPServer)
+	GetServerByIDFunc     func(serverID string) (*models.MCPServer, bool)
+	GetCachedServersFunc  func() []models.MCPServer
+}
+
+func (m *MockDiscoveryService) UpdateServerState(server *models.MCPServer) {
+	if m.UpdateServerStateFunc != nil {
+		m.UpdateServerStateFunc(server)
+	}
 }
 
-func (m *MockDiscoveryService) UpdateServer(server *models.MCPServer) {
-	if m.UpdateServerFunc != nil {
-		m.UpdateServerFunc(server)
+func (m *MockDiscoveryService) GetServerByID(serverID string) (*models.MCPServer, bool) {
+	if m.GetServerByIDFunc != nil {
+		return m.GetServerByIDFunc(serverID)
 	}
+	return nil, false
 }
 
 func (m *MockDiscoveryService) GetCachedServers() []models.MCPServer {
@@ -109,7 +133,7 @@ func TestNewLifecycleService(t *testing.T) {
 
 func TestLifecycleService_StartServer(t *testing.T) {
 	pm := &MockProcessManager{
-		StartFunc: func(cmd string, args []string, env map[string]string) (int, error) {
+		StartFunc: func(spec platform.LaunchSpec) (int, error) {
 			return 1234, nil
 		},
 	}
@@ -148,6 +172,48 @@ func TestLifecycleService_StartServer(t *testing.T) {
 	service.StopAll()
 }
 
+func TestLifecycleService_StartServer_LaunchSpec(t *testing.T) {
+	var launched platform.LaunchSpec
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			launched = spec
+			return mockProcess(1234), nil
+		},
+	}
+
+	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	workDir := t.TempDir()
+	server := models.NewMCPServer("test-server", "./server", models.DiscoveryClientConfig)
+	server.Transport = models.TransportStdio
+	server.Configuration.WorkingDirectory = workDir
+	server.Configuration.EnvInheritance = models.EnvInheritAllowlist
+	server.Configuration.EnvAllowlist = []string{"HOME"}
+	server.Configuration.ExtraPath = []string{"/opt/node/bin"}
+	server.Configuration.Umask = "027"
+
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+
+	if launched.Command != "./server" || launched.Dir != workDir {
+		t.Errorf("Expected command and working directory to be passed through, got %q in %q", launched.Command, launched.Dir)
+	}
+	if launched.EnvMode != platform.EnvAllowlist || len(launched.EnvAllowlist) != 1 {
+		t.Errorf("Expected allowlist environment, got %s %v", launched.EnvMode, launched.EnvAllowlist)
+	}
+	if launched.Umask == nil || *launched.Umask != 0o027 {
+		t.Error("Expected umask 027")
+	}
+	if launched.Stdin != platform.StdinPipe {
+		t.Errorf("Expected stdin pipe for stdio server by default, got %s", launched.Stdin)
+	}
+	if launched.ProcessGroup != platform.ProcessGroupNew {
+		t.Errorf("Expected new process group, got %s", launched.ProcessGroup)
+	}
+}
+
 func TestLifecycleService_StartServer_InvalidState(t *testing.T) {
 	pm := &MockProcessManager{}
 	eventBus := events.NewEventBus()
@@ -183,6 +249,26 @@ func TestLifecycleService_StartServer_MissingConfiguration(t *testing.T) {
 	}
 }
 
+func TestLifecycleService_StartServer_RequiresUserInput(t *testing.T) {
+	pm := &MockProcessManager{}
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+
+	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, eventBus)
+
+	// A VS Code server whose token VS Code would have prompted for
+	server := models.NewMCPServer("github", "npx", models.DiscoveryVSCode)
+	server.RequiredInputs = []models.ServerInput{{ID: "github-token", Env: []string{"GITHUB_TOKEN"}}}
+
+	err := service.StartServer(server)
+	if err == nil || !strings.Contains(err.Error(), "requires user input: github-token") {
+		t.Fatalf("Expected the missing input to be reported, got %v", err)
+	}
+	if server.Status.State != models.StatusStopped {
+		t.Errorf("Expected the server to stay stopped, got %s", server.Status.State)
+	}
+}
+
 func TestLifecycleService_StopServer(t *testing.T) {
 	stopCalled := false
 	pm := &MockProcessManager{
@@ -234,6 +320,38 @@ func TestLifecycleService_StopServer(t *testing.T) {
 	}
 }
 
+func TestLifecycleService_StopServer_ReportsProcessTree(t *testing.T) {
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	logEvents := eventBus.Subscribe(events.EventServerLogEntry)
+
+	service := NewLifecycleService(&MockProcessManager{}, &MockDiscoveryService{}, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	pid := 1234
+	server.PID = &pid
+	server.Status.State = models.StatusRunning
+
+	if err := service.StopServer(server, false, 10); err != nil {
+		t.Fatalf("StopServer should not error: %v", err)
+	}
+
+	select {
+	case event := <-logEvents:
+		message, _ := event.Data["message"].(string)
+		if !strings.Contains(message, "process tree: 1234 exited") {
+			t.Errorf("Expected process tree report in message, got %q", message)
+		}
+		metadata, _ := event.Data["metadata"].(map[string]interface{})
+		if _, ok := metadata["stopReport"].(*platform.StopReport); !ok {
+			t.Error("Expected stop report in log entry metadata")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for stop log entry")
+	}
+}
+
 func TestLifecycleService_StopServer_Force(t *testing.T) {
 	gracefulUsed := false
 	pm := &MockProcessManager{
@@ -286,8 +404,8 @@ func TestLifecycleService_StopServer_InvalidState(t *testing.T) {
 
 func TestLifecycleService_RestartServer(t *testing.T) {
 	pm := &MockProcessManager{
-		StartWithOutputFunc: func(cmd string, args []string, env map[string]string) (int, io.ReadCloser, io.ReadCloser, error) {
-			return 5678, io.NopCloser(strings.NewReader("")), io.NopCloser(strings.NewReader("")), nil
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			return mockProcess(5678), nil
 		},
 		StopFunc: func(pid int, graceful bool, timeout int) error {
 			return nil
@@ -331,9 +449,65 @@ func TestLifecycleService_RestartServer(t *testing.T) {
 	service.StopAll()
 }
 
+func TestLifecycleService_RestartServer_StdioLaunchedByManager(t *testing.T) {
+	nextPID := 1234
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			nextPID++
+			return mockProcess(nextPID), nil
+		},
+	}
+
+	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("stdio-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Transport = models.TransportStdio
+
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer failed: %v", err)
+	}
+	if server.Launcher == nil || !server.Launcher.Manager {
+		t.Fatalf("Expected MCP Manager to be recorded as launcher, got %+v", server.Launcher)
+	}
+
+	if err := service.RestartServer(server); err != nil {
+		t.Fatalf("Restart of a stdio server launched by the manager should succeed: %v", err)
+	}
+	if server.PID == nil || *server.PID != 1236 {
+		t.Errorf("Expected restarted process 1236, got %v", server.PID)
+	}
+}
+
+func TestLifecycleService_RestartServer_StdioLaunchedByClient(t *testing.T) {
+	pm := &MockProcessManager{
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			t.Error("A client's stdio server must not be stopped by a restart")
+			return nil
+		},
+	}
+
+	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("stdio-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Transport = models.TransportStdio
+	server.Status.State = models.StatusRunning
+	server.SetPID(4321)
+	server.Launcher = &models.ServerLauncher{PID: 4242, Name: "Claude"}
+
+	err := service.RestartServer(server)
+	if err == nil {
+		t.Fatal("Restart of a client's stdio server should be refused")
+	}
+	if !strings.Contains(err.Error(), "Claude (PID 4242)") {
+		t.Errorf("Error should name the owning client process, got %v", err)
+	}
+}
+
 func TestLifecycleService_MonitorProcess_TransitionToRunning(t *testing.T) {
 	pm := &MockProcessManager{
-		StartFunc: func(cmd string, args []string, env map[string]string) (int, error) {
+		StartFunc: func(spec platform.LaunchSpec) (int, error) {
 			return 1234, nil
 		},
 		IsRunningFunc: func(pid int) bool {
@@ -344,14 +518,15 @@ func TestLifecycleService_MonitorProcess_TransitionToRunning(t *testing.T) {
 	eventBus := events.NewEventBus()
 	defer eventBus.Close()
 
-	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, eventBus)
+	// Create and start server
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, eventBus)
 
 	// Subscribe to status change events
 	eventChan := eventBus.Subscribe(events.EventServerStatusChanged)
 
-	// Create and start server
-	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
-
 	err := service.StartServer(server)
 	if err != nil {
 		t.Fatalf("StartServer should not error: %v", err)
@@ -378,8 +553,9 @@ func TestLifecycleService_MonitorProcess_TransitionToRunning(t *testing.T) {
 	}
 
 	// Verify server is in running state
-	if server.Status.State != models.StatusRunning {
-		t.Errorf("Expected running state, got %s", server.Status.State)
+	cached, _ := ds.GetServerByID(server.ID)
+	if cached.Status.State != models.StatusRunning {
+		t.Errorf("Expected running state, got %s", cached.Status.State)
 	}
 
 	// Clean up
@@ -391,7 +567,7 @@ func TestLifecycleService_MonitorProcess_EarlyExit(t *testing.T) {
 	processRunning := true
 
 	pm := &MockProcessManager{
-		StartFunc: func(cmd string, args []string, env map[string]string) (int, error) {
+		StartFunc: func(spec platform.LaunchSpec) (int, error) {
 			return 1234, nil
 		},
 		IsRunningFunc: func(pid int) bool {
@@ -404,14 +580,15 @@ func TestLifecycleService_MonitorProcess_EarlyExit(t *testing.T) {
 	eventBus := events.NewEventBus()
 	defer eventBus.Close()
 
-	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, eventBus)
+	// Create and start server
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, eventBus)
 
 	// Subscribe to status change events
 	eventChan := eventBus.Subscribe(events.EventServerStatusChanged)
 
-	// Create and start server
-	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
-
 	err := service.StartServer(server)
 	if err != nil {
 		t.Fatalf("StartServer should not error: %v", err)
@@ -444,19 +621,291 @@ func TestLifecycleService_MonitorProcess_EarlyExit(t *testing.T) {
 	}
 
 	// Verify server is in error state
-	if server.Status.State != models.StatusError {
-		t.Errorf("Expected error state, got %s", server.Status.State)
+	cached, _ := ds.GetServerByID(server.ID)
+	if cached.Status.State != models.StatusError {
+		t.Errorf("Expected error state, got %s", cached.Status.State)
 	}
 
 	// Verify PID was cleared
-	if server.PID != nil {
+	if cached.PID != nil {
 		t.Error("PID should be cleared on error")
 	}
 }
 
+func TestLifecycleService_MonitorProcess_RecordsExit(t *testing.T) {
+	exited := make(chan struct{})
+	proc := mockProcess(1234)
+	proc.Exited = exited
+
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			return proc, nil
+		},
+		IsRunningFunc: func(pid int) bool {
+			t.Error("Processes with a wait handle should not be polled")
+			return true
+		},
+	}
+
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	eventChan := eventBus.Subscribe(events.EventServerStatusChanged)
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+
+	proc.Exit = &platform.ExitInfo{ExitCode: -1, Signal: "SIGSEGV", UserTime: 1500 * time.Millisecond, MaxRSS: 4096}
+	close(exited)
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-eventChan:
+			if event.Data["newState"] != models.StatusError {
+				continue
+			}
+			if event.Data["signal"] != "SIGSEGV" || event.Data["exitCode"] != -1 {
+				t.Errorf("Expected exit status in event, got %v", event.Data)
+			}
+			if event.Data["userTimeMs"] != int64(1500) || event.Data["maxRss"] != uint64(4096) {
+				t.Errorf("Expected resource usage in event, got %v", event.Data)
+			}
+			cached, _ := ds.GetServerByID(server.ID)
+			if cached.Status.LastExit == nil || cached.Status.LastExit.Signal != "SIGSEGV" {
+				t.Errorf("Expected last exit to be recorded, got %+v", cached.Status.LastExit)
+			}
+			if !strings.Contains(cached.Status.ErrorMessage, "signal SIGSEGV") {
+				t.Errorf("Expected signal in error message, got %q", cached.Status.ErrorMessage)
+			}
+			return
+		case <-timeout:
+			t.Fatal("Timeout waiting for error state transition")
+		}
+	}
+}
+
+func TestLifecycleService_StopServer_RecordsExit(t *testing.T) {
+	exited := make(chan struct{})
+	proc := mockProcess(1234)
+	proc.Exited = exited
+
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			return proc, nil
+		},
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			proc.Exit = &platform.ExitInfo{ExitCode: 0}
+			close(exited)
+			return nil
+		},
+	}
+
+	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+	if err := service.StopServer(server, false, 10); err != nil {
+		t.Fatalf("StopServer should not error: %v", err)
+	}
+
+	if server.Status.LastExit == nil || !server.Status.LastExit.Clean() {
+		t.Errorf("Expected clean exit to be recorded, got %+v", server.Status.LastExit)
+	}
+}
+
+// closeNotifier is a stdin pipe that runs onClose when the manager closes it
+type closeNotifier struct {
+	once    sync.Once
+	onClose func()
+}
+
+func (c *closeNotifier) Write(p []byte) (int, error) { return len(p), nil }
+
+func (c *closeNotifier) Close() error {
+	c.once.Do(c.onClose)
+	return nil
+}
+
+func TestLifecycleService_StopServer_ClosesStdinFirst(t *testing.T) {
+	exited := make(chan struct{})
+	proc := mockProcess(1234)
+	proc.Exited = exited
+	proc.Stdin = &closeNotifier{onClose: func() {
+		proc.Exit = &platform.ExitInfo{ExitCode: 0}
+		close(exited)
+	}}
+
+	stopCalls := 0
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			return proc, nil
+		},
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			stopCalls++
+			return nil
+		},
+	}
+
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	logEvents := eventBus.Subscribe(events.EventServerLogEntry)
+
+	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("stdio-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Transport = models.TransportStdio
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+	if err := service.StopServer(server, false, 10); err != nil {
+		t.Fatalf("StopServer should not error: %v", err)
+	}
+
+	if stopCalls != 1 {
+		t.Errorf("Expected the process tree to be swept once after the stdin stage, got %d calls", stopCalls)
+	}
+	if server.Status.LastExit == nil || !server.Status.LastExit.Clean() {
+		t.Errorf("Expected clean exit after stdin was closed, got %+v", server.Status.LastExit)
+	}
+
+	for {
+		select {
+		case event := <-logEvents:
+			metadata, _ := event.Data["metadata"].(map[string]interface{})
+			if stage, ok := metadata["stopStage"]; ok {
+				if stage != platform.StageStdin {
+					t.Errorf("Expected stdin stage to be logged, got %v", stage)
+				}
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected a log entry recording the stop stage")
+		}
+	}
+}
+
+func TestLifecycleService_RestartServer_UsesShutdownTimeout(t *testing.T) {
+	var stopTimeout int
+	pm := &MockProcessManager{
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			stopTimeout = timeout
+			return nil
+		},
+	}
+
+	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Configuration.ShutdownTimeout = 3
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+	if err := service.RestartServer(server); err != nil {
+		t.Fatalf("RestartServer should not error: %v", err)
+	}
+
+	if stopTimeout != 3 {
+		t.Errorf("Expected restart to stop within ShutdownTimeout (3), got %d", stopTimeout)
+	}
+}
+
+// recordingJournal collects journaled transitions in memory
+type recordingJournal struct {
+	mu          sync.Mutex
+	transitions []models.StateTransition
+}
+
+func (j *recordingJournal) AppendTransition(transition models.StateTransition) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.transitions = append(j.transitions, transition)
+	return nil
+}
+
+func TestLifecycleService_JournalsTransitions(t *testing.T) {
+	exited := make(chan struct{})
+	proc := mockProcess(1234)
+	proc.Exited = exited
+
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			return proc, nil
+		},
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			proc.Exit = &platform.ExitInfo{ExitCode: -1, Signal: "SIGTERM"}
+			close(exited)
+			return nil
+		},
+	}
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+
+	journal := &recordingJournal{}
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	service.SetJournal(journal)
+	defer service.StopAll()
+
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		cached, _ := ds.GetServerByID(server.ID)
+		if cached.Status.State == models.StatusRunning {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected running state, got %s", cached.Status.State)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := service.StopServer(server, false, 10); err != nil {
+		t.Fatalf("StopServer should not error: %v", err)
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	want := []models.StatusState{models.StatusStarting, models.StatusRunning, models.StatusStopped}
+	if len(journal.transitions) != len(want) {
+		t.Fatalf("Expected %d journaled transitions, got %+v", len(want), journal.transitions)
+	}
+	for i, state := range want {
+		if journal.transitions[i].NewState != state || journal.transitions[i].ServerID != server.ID {
+			t.Errorf("Transition %d: expected %s, got %+v", i, state, journal.transitions[i])
+		}
+	}
+
+	stop := journal.transitions[2]
+	if stop.PID == nil || *stop.PID != 1234 {
+		t.Errorf("Stop transition should name the stopped PID, got %v", stop.PID)
+	}
+	if stop.Exit == nil || stop.Exit.Signal != "SIGTERM" {
+		t.Errorf("Stop transition should carry the exit status, got %+v", stop.Exit)
+	}
+	if stop.Reason != "Server stopped" {
+		t.Errorf("Unexpected reason %q", stop.Reason)
+	}
+}
+
 func TestLifecycleService_StopAll(t *testing.T) {
 	pm := &MockProcessManager{
-		StartFunc: func(cmd string, args []string, env map[string]string) (int, error) {
+		StartFunc: func(spec platform.LaunchSpec) (int, error) {
 			return 1234, nil
 		},
 	}
@@ -497,7 +946,7 @@ func TestLifecycleService_StopAll(t *testing.T) {
 
 func TestLifecycleService_EventsPublished(t *testing.T) {
 	pm := &MockProcessManager{
-		StartFunc: func(cmd string, args []string, env map[string]string) (int, error) {
+		StartFunc: func(spec platform.LaunchSpec) (int, error) {
 			return 1234, nil
 		},
 		StopFunc: func(pid int, graceful bool, timeout int) error {
@@ -0,0 +1,224 @@
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// portPlaceholder in a server's arguments or environment is replaced with a free port at launch
+const portPlaceholder = models.PortPlaceholder
+
+// portEnvHints mark a *_PORT variable as the server's own listening port rather than,
+// say, a database it connects to
+var portEnvHints = []string{"MCP", "HTTP", "SSE", "SERVER", "LISTEN"}
+
+// preparePort settles the port a server will listen on before it is launched
+// A ${PORT} placeholder is replaced with a free port. Otherwise the port is detected from
+// the arguments and environment of HTTP and SSE servers and must not be in use.
+// Returns 0 if the server has no known port
+func (ls *LifecycleService) preparePort(server *models.MCPServer, spec *platform.LaunchSpec) (int, error) {
+	if usesPortPlaceholder(spec) {
+		port, err := freePort()
+		if err != nil {
+			return 0, fmt.Errorf("failed to allocate a port: %w", err)
+		}
+		substitutePort(spec, port)
+		slog.Info("[PORT] Assigned free port", "serverId", server.ID, "serverName", server.Name, "port", port)
+		return port, nil
+	}
+
+	// stdio servers do not listen; a port in their configuration belongs to something else
+	if server.Transport == models.TransportStdio {
+		return 0, nil
+	}
+
+	port := detectPort(spec.Args, spec.Env)
+	if port == 0 {
+		return 0, nil
+	}
+	if err := ls.checkPortFree(port); err != nil {
+		slog.Warn("[PORT] Port conflict", "serverId", server.ID, "serverName", server.Name, "port", port, "error", err)
+		return 0, err
+	}
+	return port, nil
+}
+
+// checkPortFree returns an error naming the process listening on port, if any
+func (ls *LifecycleService) checkPortFree(port int) error {
+	if ls.listeners != nil {
+		if entries, err := ls.listeners(); err == nil {
+			for _, entry := range entries {
+				if !isTCPListener(entry) {
+					continue
+				}
+				if listening, ok := addressPort(entry.LocalAddress); ok && listening == port {
+					return fmt.Errorf("port %d is already in use by %s", port, ls.describeProcess(entry.PID))
+				}
+			}
+		}
+	}
+
+	// netstat only lists listeners it can attribute to a process, so try binding as well
+	// Permission errors mean a privileged port, not a conflict
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return nil
+		}
+		return fmt.Errorf("port %d is already in use", port)
+	}
+	listener.Close()
+	return nil
+}
+
+// describeProcess names a listening process, preferring the managed server it belongs to
+func (ls *LifecycleService) describeProcess(pid int) string {
+	if ls.discoveryService != nil {
+		for _, server := range ls.discoveryService.GetCachedServers() {
+			if server.PID != nil && *server.PID == pid {
+				return fmt.Sprintf("server %s (PID %d)", server.Name, pid)
+			}
+		}
+	}
+	if ls.processName != nil {
+		if name := ls.processName(pid); name != "" {
+			return fmt.Sprintf("%s (PID %d)", name, pid)
+		}
+	}
+	return fmt.Sprintf("PID %d", pid)
+}
+
+// isTCPListener reports whether a netstat entry is a listening TCP socket
+func isTCPListener(entry platform.NetstatEntry) bool {
+	state := strings.ToUpper(entry.State)
+	return strings.EqualFold(entry.Protocol, "TCP") && (state == "LISTEN" || state == "LISTENING")
+}
+
+// addressPort returns the port of a netstat local address
+// Linux and Windows separate it with a colon ("0.0.0.0:8080", "[::]:8080"), macOS with a dot ("*.8080")
+func addressPort(address string) (int, bool) {
+	i := strings.LastIndexAny(address, ":.")
+	if i < 0 {
+		return 0, false
+	}
+	return parsePort(address[i+1:])
+}
+
+// detectPort finds the port a server listens on from its arguments, then its environment
+// Recognized arguments are --port, -p and *-port flags with a port number, and --host,
+// --listen, --bind, --address and --addr flags with a host:port value, either as the next
+// argument or after "=". Recognized variables are PORT and *_PORT names containing MCP,
+// HTTP, SSE, SERVER or LISTEN. Returns 0 if no port is found
+func detectPort(args []string, env map[string]string) int {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		if !hasValue {
+			if i+1 >= len(args) {
+				continue
+			}
+			value = args[i+1]
+		}
+
+		flag := strings.ToLower(strings.TrimLeft(name, "-"))
+		switch {
+		case flag == "p" || flag == "port" || strings.HasSuffix(flag, "-port") || strings.HasSuffix(flag, "_port"):
+			if port, ok := parsePort(value); ok {
+				return port
+			}
+		case flag == "host" || flag == "listen" || flag == "bind" || flag == "address" || flag == "addr":
+			if _, portValue, err := net.SplitHostPort(value); err == nil {
+				if port, ok := parsePort(portValue); ok {
+					return port
+				}
+			}
+		}
+	}
+
+	if port, ok := parsePort(env["PORT"]); ok {
+		return port
+	}
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		upper := strings.ToUpper(key)
+		if !strings.HasSuffix(upper, "_PORT") {
+			continue
+		}
+		for _, hint := range portEnvHints {
+			if strings.Contains(upper, hint) {
+				if port, ok := parsePort(env[key]); ok {
+					return port
+				}
+				break
+			}
+		}
+	}
+	return 0
+}
+
+// parsePort parses a TCP port number
+func parsePort(value string) (int, bool) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, false
+	}
+	return port, true
+}
+
+// usesPortPlaceholder reports whether the spec asks for an assigned port
+func usesPortPlaceholder(spec *platform.LaunchSpec) bool {
+	for _, arg := range spec.Args {
+		if strings.Contains(arg, portPlaceholder) {
+			return true
+		}
+	}
+	for _, value := range spec.Env {
+		if strings.Contains(value, portPlaceholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// substitutePort replaces ${PORT} in the spec's arguments and environment
+// The spec shares its slices with the server configuration, so both are copied
+func substitutePort(spec *platform.LaunchSpec, port int) {
+	value := strconv.Itoa(port)
+
+	args := make([]string, len(spec.Args))
+	for i, arg := range spec.Args {
+		args[i] = strings.ReplaceAll(arg, portPlaceholder, value)
+	}
+	spec.Args = args
+
+	env := make(map[string]string, len(spec.Env))
+	for key, v := range spec.Env {
+		env[key] = strings.ReplaceAll(v, portPlaceholder, value)
+	}
+	spec.Env = env
+}
+
+// freePort asks the kernel for a port that is free on every interface
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,205 @@
+package lifecycle
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+func TestDetectPort(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want int
+	}{
+		{"port flag", []string{"server.js", "--port", "8080"}, nil, 8080},
+		{"port flag with equals", []string{"--port=3001"}, nil, 3001},
+		{"short flag", []string{"-p", "9000"}, nil, 9000},
+		{"prefixed flag", []string{"--http-port", "8931"}, nil, 8931},
+		{"listen address", []string{"--listen", "127.0.0.1:7000"}, nil, 7000},
+		{"host with port", []string{"--host=0.0.0.0:7001"}, nil, 7001},
+		{"non-numeric value", []string{"-p", "/srv/data"}, nil, 0},
+		{"arguments before environment", []string{"--port", "8080"}, map[string]string{"PORT": "9090"}, 8080},
+		{"PORT variable", nil, map[string]string{"PORT": "9090"}, 9090},
+		{"server port variable", nil, map[string]string{"MCP_SERVER_PORT": "8765"}, 8765},
+		{"unrelated port variable", nil, map[string]string{"POSTGRES_PORT": "5432"}, 0},
+		{"none", []string{"--verbose"}, map[string]string{"API_KEY": "x"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectPort(tt.args, tt.env); got != tt.want {
+				t.Errorf("Expected port %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAddressPort(t *testing.T) {
+	for address, want := range map[string]int{
+		"0.0.0.0:8080":   8080,
+		"[::]:8080":      8080,
+		":::8080":        8080,
+		"*.8080":         8080,
+		"127.0.0.1.8080": 8080,
+	} {
+		if got, ok := addressPort(address); !ok || got != want {
+			t.Errorf("%s: expected %d, got %d (%v)", address, want, got, ok)
+		}
+	}
+	if _, ok := addressPort("0.0.0.0:*"); ok {
+		t.Error("Expected a wildcard port not to parse")
+	}
+}
+
+func TestLifecycleService_StartServer_PortPlaceholder(t *testing.T) {
+	server := models.NewMCPServer("http-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Transport = models.TransportHTTP
+	server.Configuration.CommandLineArguments = []string{"--port", portPlaceholder}
+	server.Configuration.EnvironmentVariables = map[string]string{"PUBLIC_URL": "http://localhost:" + portPlaceholder + "/mcp"}
+
+	var mu sync.Mutex
+	var launched platform.LaunchSpec
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			launched = spec
+			return mockProcess(1234), nil
+		},
+	}
+
+	service := NewLifecycleService(pm, cacheDiscoveryService([]models.MCPServer{*server}, nil), &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	startManaged(t, service, server)
+
+	if server.Port == nil {
+		t.Fatal("Expected the assigned port to be recorded on the server")
+	}
+	port := strconv.Itoa(*server.Port)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if launched.Args[1] != port {
+		t.Errorf("Expected --port %s, got %v", port, launched.Args)
+	}
+	if want := "http://localhost:" + port + "/mcp"; launched.Env["PUBLIC_URL"] != want {
+		t.Errorf("Expected PUBLIC_URL %s, got %s", want, launched.Env["PUBLIC_URL"])
+	}
+	if server.Configuration.CommandLineArguments[1] != portPlaceholder {
+		t.Error("The configuration must keep its placeholder for the next launch")
+	}
+}
+
+func TestLifecycleService_StartServer_PortPlaceholderProbe(t *testing.T) {
+	server := models.NewMCPServer("http-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Transport = models.TransportHTTP
+	server.Configuration.CommandLineArguments = []string{"--port", portPlaceholder}
+	server.Configuration.StartupTimeout = 2
+	server.Configuration.ReadinessProbe = &models.ReadinessProbe{Type: models.ReadinessTCP, Address: "127.0.0.1:" + portPlaceholder, Interval: 50}
+
+	// The server listens on the port it was given
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			listener, err := net.Listen("tcp", "127.0.0.1:"+spec.Args[1])
+			if err != nil {
+				return nil, err
+			}
+			t.Cleanup(func() { listener.Close() })
+			return mockProcess(1234), nil
+		},
+	}
+
+	service := NewLifecycleService(pm, cacheDiscoveryService([]models.MCPServer{*server}, nil), &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	startManaged(t, service, server)
+
+	if server.Configuration.ReadinessProbe.Address != "127.0.0.1:"+portPlaceholder {
+		t.Error("The readiness probe must keep its placeholder for the next launch")
+	}
+}
+
+func TestBindProbePort(t *testing.T) {
+	prober := &httpProber{url: "http://localhost:" + portPlaceholder + "/health"}
+	if err := bindProbePort(prober, 8123); err != nil {
+		t.Fatalf("bindProbePort failed: %v", err)
+	}
+	if prober.url != "http://localhost:8123/health" {
+		t.Errorf("Expected the assigned port in the URL, got %s", prober.url)
+	}
+
+	// Without a port the placeholder cannot be resolved
+	if err := bindProbePort(&tcpProber{address: "127.0.0.1:" + portPlaceholder}, 0); err == nil {
+		t.Error("Expected an error for a placeholder probe without a port")
+	}
+	// Probes with a fixed target need no port
+	if err := bindProbePort(&tcpProber{address: "127.0.0.1:8080"}, 0); err != nil {
+		t.Errorf("Unexpected error for a fixed probe: %v", err)
+	}
+}
+
+func TestLifecycleService_StartServer_PortConflict(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		name      string
+		listeners []platform.NetstatEntry
+		want      string
+	}{
+		{
+			name:      "listener found by netstat",
+			listeners: []platform.NetstatEntry{{Protocol: "TCP", LocalAddress: fmt.Sprintf("0.0.0.0:%d", port), State: "LISTEN", PID: 4321}},
+			want:      fmt.Sprintf("port %d is already in use by node (PID 4321)", port),
+		},
+		{
+			name: "listener netstat cannot attribute",
+			want: fmt.Sprintf("port %d is already in use", port),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := models.NewMCPServer("http-server", "/path/to/server", models.DiscoveryClientConfig)
+			server.Transport = models.TransportHTTP
+			server.Configuration.CommandLineArguments = []string{"--port", strconv.Itoa(port)}
+
+			launched := false
+			pm := &MockProcessManager{
+				StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+					launched = true
+					return mockProcess(1234), nil
+				},
+			}
+
+			service := NewLifecycleService(pm, cacheDiscoveryService([]models.MCPServer{*server}, nil), &MockMonitoringService{}, nil)
+			defer service.StopAll()
+			service.listeners = func() ([]platform.NetstatEntry, error) { return tt.listeners, nil }
+			service.processName = func(pid int) string { return "node" }
+
+			err := service.StartServer(server)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Expected error %q, got %v", tt.want, err)
+			}
+			if launched {
+				t.Error("A server whose port is taken must not be launched")
+			}
+			if server.Status.State != models.StatusError || !strings.Contains(server.Status.ErrorMessage, tt.want) {
+				t.Errorf("Expected the conflict in the error state, got %s: %s", server.Status.State, server.Status.ErrorMessage)
+			}
+		})
+	}
+}
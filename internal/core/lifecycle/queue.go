@@ -0,0 +1,120 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrStartCancelled is returned by a start or restart that was superseded by a stop
+var ErrStartCancelled = errors.New("start cancelled by a stop request")
+
+// commandKind identifies what a queued lifecycle command does
+type commandKind string
+
+const (
+	commandStart    commandKind = "start"
+	commandStop     commandKind = "stop"
+	commandRestart  commandKind = "restart"
+	commandValidate commandKind = "validate" // PID validation of processes we did not launch
+	commandMonitor  commandKind = "monitor"  // Readiness and exit handling reported by a monitor
+	commandAdopt    commandKind = "adopt"    // Re-adoption of a process detached at the last shutdown
+)
+
+// cancelledByStop returns true for commands that a later stop makes pointless
+func (k commandKind) cancelledByStop() bool {
+	return k == commandStart || k == commandRestart
+}
+
+// command is one queued lifecycle operation for a server
+type command struct {
+	kind   commandKind
+	ctx    context.Context
+	cancel context.CancelFunc
+	run    func(ctx context.Context) error
+	done   chan error // Receives the result exactly once
+}
+
+// serverQueue runs the commands of one server one at a time, in submission order
+// A worker goroutine exists only while commands are pending
+type serverQueue struct {
+	mu      sync.Mutex
+	pending []*command
+	current *command
+	running bool
+}
+
+// submit queues a command for a server and returns a channel receiving its result
+// A stop cancels the server's in-flight start or restart and drops queued ones
+func (ls *LifecycleService) submit(serverID string, kind commandKind, run func(ctx context.Context) error) <-chan error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := &command{
+		kind:   kind,
+		ctx:    ctx,
+		cancel: cancel,
+		run:    run,
+		done:   make(chan error, 1),
+	}
+
+	ls.queuesMu.Lock()
+	q, exists := ls.queues[serverID]
+	if !exists {
+		q = &serverQueue{}
+		ls.queues[serverID] = q
+	}
+	ls.queuesMu.Unlock()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if kind == commandStop {
+		if q.current != nil && q.current.kind.cancelledByStop() {
+			q.current.cancel()
+		}
+
+		kept := q.pending[:0]
+		for _, pending := range q.pending {
+			if pending.kind.cancelledByStop() {
+				pending.cancel()
+				pending.done <- ErrStartCancelled
+				continue
+			}
+			kept = append(kept, pending)
+		}
+		q.pending = kept
+	}
+
+	q.pending = append(q.pending, cmd)
+	if !q.running {
+		q.running = true
+		go q.drain()
+	}
+
+	return cmd.done
+}
+
+// drain runs queued commands until the queue is empty
+func (q *serverQueue) drain() {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.current = nil
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		cmd := q.pending[0]
+		q.pending = q.pending[1:]
+		q.current = cmd
+		q.mu.Unlock()
+
+		err := cmd.run(cmd.ctx)
+		cmd.cancel()
+		cmd.done <- err
+	}
+}
+
+// do queues a command and waits for its result
+func (ls *LifecycleService) do(serverID string, kind commandKind, run func(ctx context.Context) error) error {
+	return <-ls.submit(serverID, kind, run)
+}
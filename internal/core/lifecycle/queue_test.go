@@ -0,0 +1,147 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+func TestQueue_RunsCommandsInOrder(t *testing.T) {
+	service := NewLifecycleService(&MockProcessManager{}, &MockDiscoveryService{}, nil, nil)
+	defer service.StopAll()
+
+	var mu sync.Mutex
+	var order []int
+	var results []<-chan error
+	for i := 0; i < 5; i++ {
+		i := i
+		results = append(results, service.submit("server-1", commandValidate, func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, i)
+			return nil
+		}))
+	}
+	for _, result := range results {
+		<-result
+	}
+
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("Expected commands to run in submission order, got %v", order)
+		}
+	}
+}
+
+func TestQueue_StopCancelsStarts(t *testing.T) {
+	service := NewLifecycleService(&MockProcessManager{}, &MockDiscoveryService{}, nil, nil)
+	defer service.StopAll()
+
+	// An in-flight start that waits for cancellation
+	started := make(chan struct{})
+	inFlight := service.submit("server-1", commandStart, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ErrStartCancelled
+	})
+	<-started
+
+	// A start queued behind it never runs
+	queued := service.submit("server-1", commandStart, func(ctx context.Context) error {
+		t.Error("Queued start should have been dropped by the stop")
+		return nil
+	})
+
+	stopped := false
+	stop := service.submit("server-1", commandStop, func(ctx context.Context) error {
+		stopped = true
+		return nil
+	})
+
+	for name, result := range map[string]<-chan error{"in-flight": inFlight, "queued": queued} {
+		select {
+		case err := <-result:
+			if !errors.Is(err, ErrStartCancelled) {
+				t.Errorf("Expected %s start to be cancelled, got %v", name, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timeout waiting for %s start", name)
+		}
+	}
+
+	if err := <-stop; err != nil || !stopped {
+		t.Errorf("Stop should run after the cancelled start, err=%v", err)
+	}
+}
+
+func TestLifecycleService_ConcurrentStarts(t *testing.T) {
+	service := NewLifecycleService(&MockProcessManager{}, &MockDiscoveryService{}, nil, nil)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- service.StartServer(server)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	failures := 0
+	for err := range errs {
+		if err != nil {
+			failures++
+		}
+	}
+	if failures != 1 {
+		t.Errorf("Expected exactly one of two concurrent starts to be rejected, got %d failures", failures)
+	}
+}
+
+func TestLifecycleService_OperationsStartFromCache(t *testing.T) {
+	// The cache says the server is already running, the caller's copy is stale
+	cached := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	cached.Status.State = models.StatusRunning
+	pid := 4321
+	cached.PID = &pid
+
+	var stoppedPID int
+	pm := &MockProcessManager{
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			stoppedPID = pid
+			return nil
+		},
+	}
+	ds := &MockDiscoveryService{
+		GetServerByIDFunc: func(serverID string) (*models.MCPServer, bool) {
+			serverCopy := *cached
+			return &serverCopy, serverID == cached.ID
+		},
+	}
+
+	service := NewLifecycleService(pm, ds, nil, nil)
+	defer service.StopAll()
+
+	stale := *cached
+	stale.Status.State = models.StatusStopped
+	stale.PID = nil
+
+	if err := service.StartServer(&stale); err == nil {
+		t.Error("Start should be rejected because the cached server is running")
+	}
+	if err := service.StopServer(&stale, false, 10); err != nil {
+		t.Fatalf("Stop should use the cached state, got %v", err)
+	}
+	if stoppedPID != pid {
+		t.Errorf("Expected cached PID %d to be stopped, got %d", pid, stoppedPID)
+	}
+}
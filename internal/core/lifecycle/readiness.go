@@ -0,0 +1,415 @@
+package lifecycle
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+const (
+	// defaultStartupTimeout applies when a server has no positive StartupTimeout
+	defaultStartupTimeout = 30 * time.Second
+
+	// defaultProbeInterval is the delay between readiness attempts
+	defaultProbeInterval = 100 * time.Millisecond
+
+	// probeAttemptTimeout bounds a single network readiness attempt
+	probeAttemptTimeout = 2 * time.Second
+
+	// settleDelay is how long a process without a readiness probe must stay up
+	settleDelay = 500 * time.Millisecond
+
+	// maxLogLineLength bounds the partial line buffered by the log probe
+	maxLogLineLength = 64 * 1024
+)
+
+// Prober decides whether a starting server is ready
+type Prober interface {
+	// Probe performs a single readiness attempt; nil means the server is ready
+	Probe(ctx context.Context) error
+}
+
+// newProber creates the prober for a server's configured readiness probe
+// Servers without a probe are considered ready once the process has stayed up briefly
+func newProber(server *models.MCPServer) (Prober, error) {
+	probe := server.Configuration.ReadinessProbe
+	if probe == nil {
+		return &settleProber{since: time.Now(), delay: settleDelay}, nil
+	}
+
+	if err := probe.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch probe.Type {
+	case models.ReadinessLog:
+		return newLogProber(regexp.MustCompile(probe.Pattern)), nil
+	case models.ReadinessTCP:
+		return &tcpProber{address: probe.Address}, nil
+	case models.ReadinessHTTP:
+		return &httpProber{url: probe.URL, client: &http.Client{}}, nil
+	case models.ReadinessMCP:
+		return &mcpProber{url: probe.URL, client: &http.Client{}}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported readiness probe type: %s", probe.Type)
+}
+
+// bindProbePort points a tcp, http or mcp probe whose target uses ${PORT} at the port
+// the server was given. A probe naming ${PORT} fails if the server has no port
+func bindProbePort(prober Prober, port int) error {
+	var target *string
+	switch p := prober.(type) {
+	case *tcpProber:
+		target = &p.address
+	case *httpProber:
+		target = &p.url
+	case *mcpProber:
+		target = &p.url
+	default:
+		return nil
+	}
+
+	if !strings.Contains(*target, portPlaceholder) {
+		return nil
+	}
+	if port == 0 {
+		return fmt.Errorf("readiness probe %s uses %s but the server has no port", *target, portPlaceholder)
+	}
+	*target = strings.ReplaceAll(*target, portPlaceholder, strconv.Itoa(port))
+	return nil
+}
+
+// probeInterval returns the configured delay between readiness attempts
+func probeInterval(server *models.MCPServer) time.Duration {
+	if probe := server.Configuration.ReadinessProbe; probe != nil && probe.Interval > 0 {
+		return time.Duration(probe.Interval) * time.Millisecond
+	}
+	return defaultProbeInterval
+}
+
+// startupTimeout returns how long a server may stay in the starting state
+func startupTimeout(server *models.MCPServer) time.Duration {
+	if server.Configuration.StartupTimeout > 0 {
+		return time.Duration(server.Configuration.StartupTimeout) * time.Second
+	}
+	return defaultStartupTimeout
+}
+
+// awaitReadiness probes until the prober succeeds or ctx is done
+// The returned channel receives nil on success, or the most informative failure once ctx is done
+func awaitReadiness(ctx context.Context, prober Prober, interval time.Duration) <-chan error {
+	result := make(chan error, 1)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var lastErr error
+		for {
+			err := prober.Probe(ctx)
+			if err == nil {
+				result <- nil
+				return
+			}
+			// Keep the previous reason when the attempt only failed because ctx expired
+			if ctx.Err() == nil || lastErr == nil {
+				lastErr = err
+			}
+
+			select {
+			case <-ctx.Done():
+				result <- lastErr
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return result
+}
+
+// settleProber reports ready once the process has been up for a fixed delay
+// The monitor loop separately detects the process dying during that time
+type settleProber struct {
+	since time.Time
+	delay time.Duration
+}
+
+func (p *settleProber) Probe(ctx context.Context) error {
+	if time.Since(p.since) < p.delay {
+		return fmt.Errorf("process has not been up for %s yet", p.delay)
+	}
+	return nil
+}
+
+// logProber reports ready once any output line matches a regular expression
+type logProber struct {
+	pattern *regexp.Regexp
+	matched chan struct{}
+	once    sync.Once
+}
+
+func newLogProber(pattern *regexp.Regexp) *logProber {
+	return &logProber{
+		pattern: pattern,
+		matched: make(chan struct{}),
+	}
+}
+
+func (p *logProber) Probe(ctx context.Context) error {
+	select {
+	case <-p.matched:
+		return nil
+	default:
+		return fmt.Errorf("no output line matched %q", p.pattern.String())
+	}
+}
+
+// watch returns a reader that feeds everything read from r through the matcher
+// Each stream gets its own line buffer so stdout and stderr lines never interleave
+func (p *logProber) watch(r io.ReadCloser) io.ReadCloser {
+	return &watchedReader{
+		Reader: io.TeeReader(r, &lineMatcher{probe: p}),
+		Closer: r,
+	}
+}
+
+// watchedReader pairs a tee'd reader with the original stream's Close
+type watchedReader struct {
+	io.Reader
+	io.Closer
+}
+
+// lineMatcher splits written output into lines and matches each against the probe pattern
+type lineMatcher struct {
+	probe *logProber
+	line  []byte
+	done  bool
+}
+
+func (m *lineMatcher) Write(b []byte) (int, error) {
+	if m.done {
+		return len(b), nil
+	}
+
+	m.line = append(m.line, b...)
+	for {
+		i := bytes.IndexByte(m.line, '\n')
+		if i < 0 {
+			break
+		}
+		if m.match(m.line[:i]) {
+			return len(b), nil
+		}
+		m.line = m.line[i+1:]
+	}
+
+	// A very long line without newline is matched as-is and then dropped
+	if len(m.line) > maxLogLineLength {
+		m.match(m.line)
+		m.line = nil
+	}
+
+	return len(b), nil
+}
+
+func (m *lineMatcher) match(line []byte) bool {
+	if !m.probe.pattern.Match(bytes.TrimRight(line, "\r")) {
+		return false
+	}
+	m.probe.once.Do(func() { close(m.probe.matched) })
+	m.done = true
+	m.line = nil
+	return true
+}
+
+// tcpProber reports ready once the address accepts a TCP connection
+type tcpProber struct {
+	address string
+}
+
+func (p *tcpProber) Probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, probeAttemptTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return fmt.Errorf("port %s not accepting connections: %w", p.address, err)
+	}
+	conn.Close()
+	return nil
+}
+
+// httpProber reports ready once a GET returns 200 OK
+type httpProber struct {
+	url    string
+	client *http.Client
+}
+
+func (p *httpProber) Probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, probeAttemptTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid readiness URL: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("readiness request to %s failed: %w", p.url, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("readiness request to %s returned status %d", p.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// mcpProtocolVersion is the protocol version offered in readiness handshakes
+const mcpProtocolVersion = "2025-06-18"
+
+// mcpInitializeRequest is the JSON-RPC initialize request sent by the MCP prober
+var mcpInitializeRequest = map[string]interface{}{
+	"jsonrpc": "2.0",
+	"id":      1,
+	"method":  "initialize",
+	"params": map[string]interface{}{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo": map[string]interface{}{
+			"name":    "mcpmanager-readiness",
+			"version": "1.0.0",
+		},
+	},
+}
+
+// jsonRPCResponse is the subset of a JSON-RPC response the prober inspects
+type jsonRPCResponse struct {
+	Result *struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// mcpProber reports ready once the server answers an MCP initialize request
+// over the Streamable HTTP transport (JSON or SSE response)
+type mcpProber struct {
+	url    string
+	client *http.Client
+}
+
+func (p *mcpProber) Probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, probeAttemptTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(mcpInitializeRequest)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid readiness URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("MCP initialize request to %s failed: %w", p.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("MCP initialize returned status %d", resp.StatusCode)
+	}
+
+	// End the session we opened so the server does not keep it around
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		defer p.closeSession(sessionID)
+	}
+
+	payload, err := readJSONRPCPayload(resp)
+	if err != nil {
+		return fmt.Errorf("MCP initialize response unreadable: %w", err)
+	}
+
+	var rpc jsonRPCResponse
+	if err := json.Unmarshal(payload, &rpc); err != nil {
+		return fmt.Errorf("MCP initialize response is not JSON-RPC: %w", err)
+	}
+	if rpc.Error != nil {
+		return fmt.Errorf("MCP initialize failed: %s (code %d)", rpc.Error.Message, rpc.Error.Code)
+	}
+	if rpc.Result == nil || rpc.Result.ProtocolVersion == "" {
+		return fmt.Errorf("MCP initialize response has no protocolVersion")
+	}
+
+	return nil
+}
+
+// closeSession terminates a Streamable HTTP session opened by a probe
+func (p *mcpProber) closeSession(sessionID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeAttemptTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, p.url, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Mcp-Session-Id", sessionID)
+
+	if resp, err := p.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// readJSONRPCPayload extracts the JSON-RPC message from a plain JSON or SSE response
+func readJSONRPCPayload(resp *http.Response) ([]byte, error) {
+	body := io.LimitReader(resp.Body, 1024*1024)
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return io.ReadAll(body)
+	}
+
+	// Use the data of the first SSE event
+	var data []string
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" && len(data) > 0 {
+			break
+		}
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data = append(data, strings.TrimPrefix(value, " "))
+		}
+	}
+	if len(data) == 0 {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("no SSE data event received")
+	}
+
+	return []byte(strings.Join(data, "\n")), nil
+}
@@ -0,0 +1,301 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+func TestLogProber_MatchesAcrossWrites(t *testing.T) {
+	probe := newLogProber(regexp.MustCompile(`^listening on port \d+$`))
+	matcher := &lineMatcher{probe: probe}
+
+	matcher.Write([]byte("booting\nlisten"))
+	if err := probe.Probe(context.Background()); err == nil {
+		t.Fatal("Partial line should not match")
+	}
+
+	matcher.Write([]byte("ing on port 8080\r\nmore output\n"))
+	if err := probe.Probe(context.Background()); err != nil {
+		t.Errorf("Expected match once the line completes, got %v", err)
+	}
+}
+
+func TestLogProber_WatchPassesOutputThrough(t *testing.T) {
+	probe := newLogProber(regexp.MustCompile(`ready`))
+	reader := probe.watch(io.NopCloser(strings.NewReader("starting\nserver ready\n")))
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if string(data) != "starting\nserver ready\n" {
+		t.Errorf("Output should be passed through unchanged, got %q", data)
+	}
+	if err := probe.Probe(context.Background()); err != nil {
+		t.Errorf("Expected match, got %v", err)
+	}
+}
+
+func TestTCPProber(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+
+	probe := &tcpProber{address: address}
+	if err := probe.Probe(context.Background()); err != nil {
+		t.Errorf("Expected open port to be ready, got %v", err)
+	}
+
+	listener.Close()
+	if err := probe.Probe(context.Background()); err == nil {
+		t.Error("Expected closed port not to be ready")
+	}
+}
+
+func TestHTTPProber(t *testing.T) {
+	var mu sync.Mutex
+	status := http.StatusServiceUnavailable
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	probe := &httpProber{url: ts.URL, client: ts.Client()}
+	if err := probe.Probe(context.Background()); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Expected status 503 failure, got %v", err)
+	}
+
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	if err := probe.Probe(context.Background()); err != nil {
+		t.Errorf("Expected 200 to be ready, got %v", err)
+	}
+}
+
+func TestMCPProber(t *testing.T) {
+	initializeResult := `{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-06-18","capabilities":{},"serverInfo":{"name":"test","version":"1"}}}`
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "json response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, initializeResult)
+			},
+		},
+		{
+			name: "sse response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprintf(w, "event: message\ndata: %s\n\n", initializeResult)
+			},
+		},
+		{
+			name: "json-rpc error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"not initialized"}}`)
+			},
+			wantErr: "not initialized",
+		},
+		{
+			name: "not an mcp endpoint",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantErr: "status 404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(tt.handler)
+			defer ts.Close()
+
+			probe := &mcpProber{url: ts.URL, client: ts.Client()}
+			err := probe.Probe(context.Background())
+
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected handshake to succeed, got %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMCPProber_ClosesSession(t *testing.T) {
+	deleted := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted <- r.Header.Get("Mcp-Session-Id")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Mcp-Session-Id", "probe-session")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-06-18"}}`)
+	}))
+	defer ts.Close()
+
+	probe := &mcpProber{url: ts.URL, client: ts.Client()}
+	if err := probe.Probe(context.Background()); err != nil {
+		t.Fatalf("Expected handshake to succeed, got %v", err)
+	}
+
+	select {
+	case id := <-deleted:
+		if id != "probe-session" {
+			t.Errorf("Expected probe session to be closed, got %q", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Probe session was not closed")
+	}
+}
+
+func TestAwaitReadiness_TimeoutKeepsReason(t *testing.T) {
+	probe := newLogProber(regexp.MustCompile(`never`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := <-awaitReadiness(ctx, probe, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "no output line matched") {
+		t.Errorf("Expected probe failure reason, got %v", err)
+	}
+}
+
+func TestLifecycleService_ReadinessLogProbe(t *testing.T) {
+	stdoutReader, stdoutWriter := io.Pipe()
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			proc := mockProcess(1234)
+			proc.Stdout = stdoutReader
+			return proc, nil
+		},
+	}
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Configuration.ReadinessProbe = &models.ReadinessProbe{Type: models.ReadinessLog, Pattern: "server ready", Interval: 10}
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+
+	service := NewLifecycleService(pm, ds, nil, nil)
+	defer service.StopAll()
+
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+
+	// Without the ready line the server must stay starting past the old 500ms heuristic
+	time.Sleep(700 * time.Millisecond)
+	if cached, _ := ds.GetServerByID(server.ID); cached.Status.State != models.StatusStarting {
+		t.Fatalf("Expected starting before the ready line, got %s", cached.Status.State)
+	}
+
+	go stdoutWriter.Write([]byte("loading tools\nserver ready\n"))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		cached, _ := ds.GetServerByID(server.ID)
+		if cached.Status.State == models.StatusRunning {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected running after the ready line, got %s", cached.Status.State)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stdoutWriter.Close()
+}
+
+func TestLifecycleService_StartupTimeout(t *testing.T) {
+	var mu sync.Mutex
+	stopped := []int{}
+	pm := &MockProcessManager{
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, pid)
+			return nil
+		},
+	}
+
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	statusEvents := eventBus.Subscribe(events.EventServerStatusChanged)
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Configuration.StartupTimeout = 1
+	server.Configuration.ReadinessProbe = &models.ReadinessProbe{Type: models.ReadinessTCP, Address: "127.0.0.1:1", Interval: 50}
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+
+	timeout := time.After(3 * time.Second)
+	for {
+		select {
+		case event := <-statusEvents:
+			if event.Data["newState"] != models.StatusError {
+				continue
+			}
+			cached, _ := ds.GetServerByID(server.ID)
+			if !strings.Contains(cached.Status.ErrorMessage, "did not become ready within 1s") {
+				t.Errorf("Expected startup timeout reason, got %q", cached.Status.ErrorMessage)
+			}
+			if cached.PID != nil {
+				t.Error("PID should be cleared after startup timeout")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(stopped) != 1 || stopped[0] != 1234 {
+				t.Errorf("Expected unready process 1234 to be stopped, got %v", stopped)
+			}
+			return
+		case <-timeout:
+			cached, _ := ds.GetServerByID(server.ID)
+			t.Fatalf("Timeout waiting for error state, state is %s", cached.Status.State)
+		}
+	}
+}
+
+func TestLifecycleService_StartServer_InvalidReadinessProbe(t *testing.T) {
+	service := NewLifecycleService(&MockProcessManager{}, &MockDiscoveryService{}, nil, nil)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Configuration.ReadinessProbe = &models.ReadinessProbe{Type: models.ReadinessLog, Pattern: "("}
+
+	if err := service.StartServer(server); err == nil {
+		t.Error("Expected error for invalid readiness probe")
+	}
+	if server.Status.State != models.StatusStopped {
+		t.Errorf("Server should remain stopped, got %s", server.Status.State)
+	}
+}
@@ -0,0 +1,133 @@
+package lifecycle
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// maxSandboxViolations bounds how many blocked operations are reported per run
+const maxSandboxViolations = 20
+
+// sandboxViolationPattern matches output lines describing an operation the sandbox refused
+// Landlock denials surface as EACCES or EPERM, and a disabled network as ENETUNREACH
+var sandboxViolationPattern = regexp.MustCompile(`(?i)\b(EPERM|EACCES|ENETUNREACH|operation not permitted|permission denied|network is unreachable)\b`)
+
+// sandboxSpec converts a sandbox profile for the launcher, expanding ~ to the home directory
+func sandboxSpec(profile *models.SandboxProfile) (*platform.SandboxSpec, error) {
+	readPaths, err := expandHomePaths(profile.ReadPaths)
+	if err != nil {
+		return nil, err
+	}
+	writePaths, err := expandHomePaths(profile.WritePaths)
+	if err != nil {
+		return nil, err
+	}
+	return &platform.SandboxSpec{ReadPaths: readPaths, WritePaths: writePaths, Network: profile.Network}, nil
+}
+
+// expandHomePaths replaces a leading ~ in each path with the user's home directory
+func expandHomePaths(paths []string) ([]string, error) {
+	expanded := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path == "~" || strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("cannot expand sandbox path %s: %w", path, err)
+			}
+			path = filepath.Join(home, path[1:])
+		}
+		expanded = append(expanded, path)
+	}
+	return expanded, nil
+}
+
+// sandboxProfile describes the sandbox a server was launched in, for the server detail
+func sandboxProfile(spec *platform.SandboxSpec) *models.SandboxProfile {
+	if spec == nil {
+		return nil
+	}
+	return &models.SandboxProfile{ReadPaths: spec.ReadPaths, WritePaths: spec.WritePaths, Network: spec.Network}
+}
+
+// sandboxWatch reports operations a sandboxed server was refused, as they show up in its output
+// Every stream of one run shares the report limit
+type sandboxWatch struct {
+	ls         *LifecycleService
+	serverID   string
+	serverName string
+
+	mu       sync.Mutex
+	reported int
+}
+
+// newSandboxWatch starts watching a sandboxed run of a server
+func (ls *LifecycleService) newSandboxWatch(server *models.MCPServer) *sandboxWatch {
+	return &sandboxWatch{ls: ls, serverID: server.ID, serverName: server.Name}
+}
+
+// watch returns a reader that reports violations in everything read from r
+func (w *sandboxWatch) watch(r io.ReadCloser) io.ReadCloser {
+	return &watchedReader{
+		Reader: io.TeeReader(r, &violationScanner{watch: w}),
+		Closer: r,
+	}
+}
+
+// report logs one line of output that looks like a sandbox violation
+func (w *sandboxWatch) report(line string) {
+	w.mu.Lock()
+	w.reported++
+	count := w.reported
+	w.mu.Unlock()
+
+	switch {
+	case count <= maxSandboxViolations:
+		slog.Warn("[SANDBOX] Operation refused", "serverId", w.serverID, "serverName", w.serverName, "output", line)
+		w.ls.publishLog(w.serverID, models.LogWarning, fmt.Sprintf("Sandbox refused an operation: %s", line))
+	case count == maxSandboxViolations+1:
+		w.ls.publishLog(w.serverID, models.LogWarning, fmt.Sprintf("Further sandbox violations of %s are not reported", w.serverName))
+	}
+}
+
+// violationScanner splits written output into lines and reports those matching the violation pattern
+type violationScanner struct {
+	watch *sandboxWatch
+	line  []byte
+}
+
+func (s *violationScanner) Write(b []byte) (int, error) {
+	s.line = append(s.line, b...)
+	for {
+		i := bytes.IndexByte(s.line, '\n')
+		if i < 0 {
+			break
+		}
+		s.scan(s.line[:i])
+		s.line = s.line[i+1:]
+	}
+
+	// A very long line without newline is scanned as-is and then dropped
+	if len(s.line) > maxLogLineLength {
+		s.scan(s.line)
+		s.line = nil
+	}
+
+	return len(b), nil
+}
+
+func (s *violationScanner) scan(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if sandboxViolationPattern.Match(line) {
+		s.watch.report(string(line))
+	}
+}
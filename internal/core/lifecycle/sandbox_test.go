@@ -0,0 +1,97 @@
+package lifecycle
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+func TestSandboxSpec_ExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	spec, err := sandboxSpec(&models.SandboxProfile{
+		ReadPaths:  []string{"~/.config/server", "/opt/data"},
+		WritePaths: []string{"~"},
+		Network:    true,
+	})
+	if err != nil {
+		t.Fatalf("sandboxSpec failed: %v", err)
+	}
+
+	if want := []string{filepath.Join(home, ".config/server"), "/opt/data"}; !reflect.DeepEqual(spec.ReadPaths, want) {
+		t.Errorf("Expected read paths %v, got %v", want, spec.ReadPaths)
+	}
+	if want := []string{home}; !reflect.DeepEqual(spec.WritePaths, want) {
+		t.Errorf("Expected write paths %v, got %v", want, spec.WritePaths)
+	}
+	if !spec.Network {
+		t.Error("Expected network access to be kept")
+	}
+}
+
+func TestSandboxWatch_ReportsViolations(t *testing.T) {
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	logEvents := eventBus.Subscribe(events.EventServerLogEntry)
+
+	service := NewLifecycleService(&MockProcessManager{}, &MockDiscoveryService{}, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("sandboxed", "/path/to/server", models.DiscoveryClientConfig)
+	output := "listening\n" +
+		"Error: EACCES: permission denied, open '/home/user/.ssh/id_rsa'\n" +
+		"fetch failed: connect ENETUNREACH 140.82.112.3:443\r\n" +
+		"done\n"
+
+	watch := service.newSandboxWatch(server)
+	read, err := io.ReadAll(watch.watch(io.NopCloser(strings.NewReader(output))))
+	if err != nil || string(read) != output {
+		t.Fatalf("Expected output to pass through unchanged, got %q (%v)", read, err)
+	}
+
+	var messages []string
+	for len(logEvents) > 0 {
+		event := <-logEvents
+		messages = append(messages, event.Data["message"].(string))
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 violations, got %v", messages)
+	}
+	if !strings.Contains(messages[0], "id_rsa") || !strings.HasSuffix(messages[1], "140.82.112.3:443") {
+		t.Errorf("Unexpected violation reports: %v", messages)
+	}
+}
+
+func TestSandboxWatch_LimitsReports(t *testing.T) {
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	logEvents := eventBus.Subscribe(events.EventServerLogEntry)
+
+	service := NewLifecycleService(&MockProcessManager{}, &MockDiscoveryService{}, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+
+	server := models.NewMCPServer("sandboxed", "/path/to/server", models.DiscoveryClientConfig)
+	watch := service.newSandboxWatch(server)
+
+	// Both streams of a run share the limit
+	line := strings.Repeat("mkdir: cannot create directory '/var/cache/x': Operation not permitted\n", maxSandboxViolations)
+	io.ReadAll(watch.watch(io.NopCloser(strings.NewReader(line))))
+	io.ReadAll(watch.watch(io.NopCloser(strings.NewReader(line))))
+
+	if len(logEvents) != maxSandboxViolations+1 {
+		t.Fatalf("Expected %d reports and a notice, got %d", maxSandboxViolations, len(logEvents))
+	}
+	var last *events.Event
+	for len(logEvents) > 0 {
+		last = <-logEvents
+	}
+	if message := last.Data["message"].(string); !strings.Contains(message, "not reported") {
+		t.Errorf("Expected a notice that further violations are not reported, got %q", message)
+	}
+}
@@ -0,0 +1,199 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// Shutdown applies the shutdown policy to every server this service launched
+// ShutdownStop stops them gracefully, each within its configured ShutdownTimeout, and
+// returns nil. ShutdownDetach leaves them running and returns the PID registry entries
+// the next launch needs to re-adopt them; servers still attached to MCP Manager through
+// pipes, such as stdio servers, are stopped all the same. Call StopAll afterwards to release monitors
+func (ls *LifecycleService) Shutdown(policy models.ShutdownPolicy) []models.DetachedServer {
+	ids := ls.ManagedServerIDs()
+	var detached []models.DetachedServer
+	if policy == models.ShutdownDetach {
+		detached, ids = ls.detachServers(ids)
+	}
+
+	slog.Info("[SHUTDOWN] Stopping managed servers", "count", len(ids))
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		server, exists := ls.cachedServer(id)
+		if !exists {
+			slog.Warn("[SHUTDOWN] Managed server is not in the discovery cache, leaving it running", "serverId", id)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ls.StopServer(server, false, server.Configuration.ShutdownTimeout); err != nil {
+				slog.Error("[SHUTDOWN] Failed to stop server", "serverId", server.ID, "serverName", server.Name, "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return detached
+}
+
+// detachServers builds the PID registry entries for servers left running on exit
+// Only servers that write their output to a file can be detached. The manager's end of a
+// pipe closes when it exits: a stdio server exits on EOF of its stdin, and a server writing
+// to an output pipe is killed by SIGPIPE. Their IDs are returned to be stopped instead
+func (ls *LifecycleService) detachServers(ids []string) ([]models.DetachedServer, []string) {
+	detached := make([]models.DetachedServer, 0, len(ids))
+	var attached []string
+	for _, id := range ids {
+		ls.mu.RLock()
+		proc, exists := ls.processes[id]
+		ls.mu.RUnlock()
+		if !exists || proc.ExitInfo() != nil {
+			continue
+		}
+
+		server, cached := ls.cachedServer(id)
+		if proc.Stdin != nil || (cached && server.Transport == models.TransportStdio) {
+			slog.Info("[SHUTDOWN] Stopping server instead of detaching it: its stdin is a pipe from MCP Manager, which closes on exit",
+				"serverId", id, "pid", proc.PID)
+			attached = append(attached, id)
+			continue
+		}
+		if proc.OutputFile == "" {
+			slog.Info("[SHUTDOWN] Stopping server instead of detaching it: its output goes to pipes read by MCP Manager, which close on exit",
+				"serverId", id, "pid", proc.PID)
+			attached = append(attached, id)
+			continue
+		}
+
+		entry := models.DetachedServer{
+			ServerID:     id,
+			PID:          proc.PID,
+			ProcessName:  ls.processName(proc.PID),
+			RunningSince: time.Now(),
+			DetachedAt:   time.Now(),
+			OutputFile:   proc.OutputFile,
+		}
+		if cached {
+			entry.Name = server.Name
+			entry.RunningSince = server.Status.LastStateChange
+			entry.Sandbox = server.Sandbox
+			entry.Port = server.Port
+		}
+		detached = append(detached, entry)
+	}
+
+	slog.Info("[SHUTDOWN] Detaching managed servers", "count", len(detached))
+	return detached, attached
+}
+
+// AdoptServers re-attaches servers detached at the last shutdown
+// Returns the IDs of the servers that are running again under this service
+func (ls *LifecycleService) AdoptServers(servers []models.MCPServer, detached []models.DetachedServer) []string {
+	byID := make(map[string]*models.MCPServer, len(servers))
+	for i := range servers {
+		byID[servers[i].ID] = &servers[i]
+	}
+
+	var adopted []string
+	for _, entry := range detached {
+		server, exists := byID[entry.ServerID]
+		if !exists {
+			slog.Warn("[ADOPT] Detached server is no longer discovered", "serverId", entry.ServerID, "serverName", entry.Name, "pid", entry.PID)
+			continue
+		}
+
+		err := ls.do(server.ID, commandAdopt, func(ctx context.Context) error {
+			ls.refresh(server)
+			return ls.adoptServer(server, entry)
+		})
+		if err != nil {
+			slog.Warn("[ADOPT] Could not re-adopt detached server", "serverId", server.ID, "serverName", server.Name, "pid", entry.PID, "error", err)
+			continue
+		}
+		adopted = append(adopted, server.ID)
+	}
+
+	return adopted
+}
+
+// adoptServer takes ownership of a detached process; runs on the server's queue
+// The server continues in the running state it had when it was detached, so the
+// journal only records a transition if the process ended while nobody watched it
+func (ls *LifecycleService) adoptServer(server *models.MCPServer, entry models.DetachedServer) error {
+	pid := entry.PID
+	if server.Status.State == models.StatusStarting ||
+		(server.Status.State == models.StatusRunning && server.PID != nil && *server.PID != pid) {
+		return fmt.Errorf("server is already %s", server.Status.State)
+	}
+
+	if !ls.processManager.IsRunning(pid) || (entry.ProcessName != "" && ls.processName(pid) != entry.ProcessName) {
+		ls.record(models.StateTransition{
+			ServerID:  server.ID,
+			OldState:  models.StatusRunning,
+			NewState:  models.StatusStopped,
+			Reason:    "Detached process exited while MCP Manager was not running",
+			Timestamp: time.Now(),
+			PID:       &pid,
+		})
+		return fmt.Errorf("detached process %d is no longer running", pid)
+	}
+
+	oldState := server.Status.State
+	server.PID = &pid
+	server.Launcher = managerLauncher()
+	server.Sandbox = entry.Sandbox
+	server.Port = entry.Port
+	server.Status.State = models.StatusRunning
+	server.Status.LastStateChange = entry.RunningSince
+	server.Status.ErrorMessage = ""
+
+	proc := &platform.ManagedProcess{PID: pid, OutputFile: entry.OutputFile}
+	ls.mu.Lock()
+	ls.processes[server.ID] = proc
+	ls.mu.Unlock()
+
+	ls.supervisor.Reset(server.ID)
+	ls.supervisor.RecordStart(server.ID)
+
+	// The server is already running, so the monitor only watches for its exit
+	ls.startMonitoring(server, proc, &settleProber{since: entry.RunningSince})
+
+	// Synchronously update discovery cache (BUG-001 fix)
+	if ls.discoveryService != nil {
+		ls.discoveryService.UpdateServerState(server)
+	}
+
+	slog.Info("[ADOPT] Re-adopted detached server", "serverId", server.ID, "serverName", server.Name, "pid", pid)
+	if ls.eventBus != nil {
+		logEntry := models.NewLogEntry(
+			models.LogInfo,
+			server.ID,
+			fmt.Sprintf("Re-adopted server %s left running at the last shutdown (PID: %d); earlier output is not available", server.Name, pid),
+		)
+		ls.eventBus.Publish(events.ServerLogEntryEvent(server.ID, logEntry))
+		if oldState != models.StatusRunning {
+			ls.eventBus.Publish(events.ServerStatusChangedEvent(server.ID, oldState, models.StatusRunning))
+		}
+	}
+
+	return nil
+}
+
+// cachedServer returns the discovery cache's copy of a server
+func (ls *LifecycleService) cachedServer(serverID string) (*models.MCPServer, bool) {
+	if ls.discoveryService == nil {
+		return nil, false
+	}
+	return ls.discoveryService.GetServerByID(serverID)
+}
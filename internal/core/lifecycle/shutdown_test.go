@@ -0,0 +1,258 @@
+package lifecycle
+
+import (
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// startManaged starts a server through the service and waits until it is running
+func startManaged(t *testing.T, service *LifecycleService, server *models.MCPServer) {
+	t.Helper()
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer failed: %v", err)
+	}
+	if status := service.awaitStartup(server); status.State != models.StatusRunning {
+		t.Fatalf("Expected server to be running, got %s", status.State)
+	}
+}
+
+func TestLifecycleService_Shutdown_Stop(t *testing.T) {
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	server.Configuration.ShutdownTimeout = 7
+
+	var mu sync.Mutex
+	var stopTimeout int
+	pm := &MockProcessManager{
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopTimeout = timeout
+			return nil
+		},
+	}
+
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	startManaged(t, service, server)
+
+	if detached := service.Shutdown(models.ShutdownStop); detached != nil {
+		t.Errorf("Stop policy should not detach servers, got %+v", detached)
+	}
+
+	mu.Lock()
+	if stopTimeout != 7 {
+		t.Errorf("Expected the server's ShutdownTimeout (7) to be used, got %d", stopTimeout)
+	}
+	mu.Unlock()
+
+	cached, _ := ds.GetServerByID(server.ID)
+	if cached.Status.State != models.StatusStopped {
+		t.Errorf("Expected server to be stopped, got %s", cached.Status.State)
+	}
+	if ids := service.ManagedServerIDs(); len(ids) != 0 {
+		t.Errorf("Expected no managed servers after shutdown, got %v", ids)
+	}
+}
+
+func TestLifecycleService_DetachAndAdopt(t *testing.T) {
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+
+	var mu sync.Mutex
+	alive := true
+	pm := &MockProcessManager{
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			t.Error("Detach policy must not stop servers")
+			return nil
+		},
+		IsRunningFunc: func(pid int) bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return alive
+		},
+	}
+
+	// First session: start and detach
+	first := NewLifecycleService(pm, cacheDiscoveryService([]models.MCPServer{*server}, nil), &MockMonitoringService{}, nil)
+	first.processName = func(pid int) string { return "server-bin" }
+	first.SetOutputDir(t.TempDir())
+	startManaged(t, first, server)
+
+	detached := first.Shutdown(models.ShutdownDetach)
+	first.StopAll()
+	if len(detached) != 1 || detached[0].PID != 1234 || detached[0].ProcessName != "server-bin" || detached[0].Name != "test-server" {
+		t.Fatalf("Expected one registry entry for PID 1234, got %+v", detached)
+	}
+	if filepath.Base(detached[0].OutputFile) != server.ID+".log" {
+		t.Errorf("Expected the registry entry to name the server's output file, got %q", detached[0].OutputFile)
+	}
+
+	// Second session: the freshly discovered server is stopped until adopted
+	fresh := *models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	ds := cacheDiscoveryService([]models.MCPServer{fresh}, nil)
+	journal := &recordingJournal{}
+	second := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	second.SetJournal(journal)
+	second.processName = func(pid int) string { return "server-bin" }
+	defer second.StopAll()
+
+	adopted := second.AdoptServers([]models.MCPServer{fresh}, detached)
+	if len(adopted) != 1 || adopted[0] != server.ID {
+		t.Fatalf("Expected server to be adopted, got %v", adopted)
+	}
+
+	cached, _ := ds.GetServerByID(server.ID)
+	if cached.Status.State != models.StatusRunning || cached.PID == nil || *cached.PID != 1234 {
+		t.Fatalf("Adopted server should be running with PID 1234, got %s", cached.Status.State)
+	}
+	if !cached.Status.LastStateChange.Equal(detached[0].RunningSince) {
+		t.Error("Uptime should continue from when the server started running")
+	}
+	if ids := second.ManagedServerIDs(); len(ids) != 1 {
+		t.Errorf("Adopted server should be managed again, got %v", ids)
+	}
+	journal.mu.Lock()
+	if len(journal.transitions) != 0 {
+		t.Error("Adoption of a live process should not journal a transition")
+	}
+	journal.mu.Unlock()
+
+	// The re-attached monitor notices the process going away
+	mu.Lock()
+	alive = false
+	mu.Unlock()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		cached, _ = ds.GetServerByID(server.ID)
+		if cached.Status.State != models.StatusRunning {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Monitor should detect the exit of the adopted process")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestLifecycleService_Shutdown_DetachStopsStdio(t *testing.T) {
+	httpServer := models.NewMCPServer("http-server", "/path/to/http", models.DiscoveryClientConfig)
+	httpServer.Transport = models.TransportHTTP
+	stdioServer := models.NewMCPServer("stdio-server", "/path/to/stdio", models.DiscoveryClientConfig)
+	stdioServer.Transport = models.TransportStdio
+
+	var mu sync.Mutex
+	var stopped []int
+	pids := map[string]int{"/path/to/http": 1234, "/path/to/stdio": 5678}
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			proc := mockProcess(pids[spec.Command])
+			proc.OutputFile = spec.OutputFile
+			if spec.Stdin == platform.StdinPipe {
+				_, proc.Stdin = io.Pipe()
+			}
+			return proc, nil
+		},
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, pid)
+			return nil
+		},
+	}
+
+	ds := cacheDiscoveryService([]models.MCPServer{*httpServer, *stdioServer}, nil)
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	service.processName = func(pid int) string { return "server-bin" }
+	service.SetOutputDir(t.TempDir())
+	defer service.StopAll()
+
+	startManaged(t, service, httpServer)
+	startManaged(t, service, stdioServer)
+
+	detached := service.Shutdown(models.ShutdownDetach)
+	if len(detached) != 1 || detached[0].ServerID != httpServer.ID || detached[0].PID != 1234 {
+		t.Fatalf("Expected only the http server to be detached, got %+v", detached)
+	}
+
+	mu.Lock()
+	if len(stopped) != 1 || stopped[0] != 5678 {
+		t.Errorf("Expected the stdio server to be stopped instead, got %v", stopped)
+	}
+	mu.Unlock()
+
+	cached, _ := ds.GetServerByID(stdioServer.ID)
+	if cached.Status.State != models.StatusStopped {
+		t.Errorf("Expected the stdio server to be stopped, got %s", cached.Status.State)
+	}
+	if ids := service.ManagedServerIDs(); len(ids) != 1 || ids[0] != httpServer.ID {
+		t.Errorf("Expected only the detached server to remain managed, got %v", ids)
+	}
+}
+
+func TestLifecycleService_Shutdown_DetachStopsPiped(t *testing.T) {
+	server := models.NewMCPServer("http-server", "/path/to/http", models.DiscoveryClientConfig)
+	server.Transport = models.TransportHTTP
+
+	var mu sync.Mutex
+	var stopped []int
+	pm := &MockProcessManager{
+		StopFunc: func(pid int, graceful bool, timeout int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, pid)
+			return nil
+		},
+	}
+
+	// Without an output directory the server's output goes to pipes
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+	startManaged(t, service, server)
+
+	if detached := service.Shutdown(models.ShutdownDetach); len(detached) != 0 {
+		t.Errorf("Expected a server writing to pipes not to be detached, got %+v", detached)
+	}
+	mu.Lock()
+	if len(stopped) != 1 || stopped[0] != 1234 {
+		t.Errorf("Expected the server to be stopped instead, got %v", stopped)
+	}
+	mu.Unlock()
+}
+
+func TestLifecycleService_AdoptServers_ReusedPID(t *testing.T) {
+	server := models.NewMCPServer("test-server", "/path/to/server", models.DiscoveryClientConfig)
+	ds := cacheDiscoveryService([]models.MCPServer{*server}, nil)
+	journal := &recordingJournal{}
+
+	// The PID is alive but now belongs to a different program
+	service := NewLifecycleService(&MockProcessManager{}, ds, &MockMonitoringService{}, nil)
+	service.SetJournal(journal)
+	service.processName = func(pid int) string { return "other-program" }
+	defer service.StopAll()
+
+	detached := []models.DetachedServer{{ServerID: server.ID, PID: 1234, ProcessName: "server-bin", RunningSince: time.Now().Add(-time.Hour)}}
+	if adopted := service.AdoptServers([]models.MCPServer{*server}, detached); len(adopted) != 0 {
+		t.Fatalf("A reused PID must not be adopted, got %v", adopted)
+	}
+
+	cached, _ := ds.GetServerByID(server.ID)
+	if cached.Status.State != models.StatusStopped || cached.PID != nil {
+		t.Errorf("Server should stay stopped, got %s", cached.Status.State)
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	recorded := journal.transitions
+	if len(recorded) != 1 || recorded[0].OldState != models.StatusRunning || recorded[0].NewState != models.StatusStopped {
+		t.Errorf("Expected the unobserved exit to be journaled, got %+v", recorded)
+	}
+}
@@ -0,0 +1,210 @@
+package lifecycle
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+const (
+	// DefaultStartupParallelism bounds concurrent starts when StartupOptions leaves it unset
+	DefaultStartupParallelism = 4
+
+	// startupPollInterval is how often a starting server is checked for readiness
+	startupPollInterval = 100 * time.Millisecond
+)
+
+// StartupReason explains why a server was selected to start at application launch
+type StartupReason string
+
+const (
+	StartupReasonAutoStart StartupReason = "autostart" // ServerConfiguration.AutoStart is set
+	StartupReasonRestore   StartupReason = "restore"   // Server was running at the last shutdown
+)
+
+// StartupOutcome is the result of starting one server at application launch
+type StartupOutcome string
+
+const (
+	StartupStarted StartupOutcome = "started" // Server reached the running state
+	StartupFailed  StartupOutcome = "failed"  // Launch failed, or the server crashed or timed out while starting
+	StartupSkipped StartupOutcome = "skipped" // Server was not started (see Error for why)
+)
+
+// StartupOptions selects the servers started at application launch
+type StartupOptions struct {
+	AutoStart   bool     // Start servers with AutoStart in their configuration (UserPreferences.AutoStartServers)
+	Restore     []string // IDs of servers that were running at the last shutdown
+	Parallelism int      // Maximum concurrent starts; <= 0 = DefaultStartupParallelism
+}
+
+// StartupResult is the per-server outcome of StartupServers
+type StartupResult struct {
+	ServerID string         `json:"serverId"`
+	Name     string         `json:"name,omitempty"`
+	Reason   StartupReason  `json:"reason"`
+	Outcome  StartupOutcome `json:"outcome"`
+	Error    string         `json:"error,omitempty"`
+	Duration time.Duration  `json:"duration"` // From the start request until the outcome was known
+}
+
+// StartupServers starts the servers selected by opts, at most opts.Parallelism at a time
+// Each start waits until the server is running or has failed, so a server that crashes
+// while starting frees its slot without holding up the others. Every outcome is published
+// as an event; results are returned in selection order
+func (ls *LifecycleService) StartupServers(servers []models.MCPServer, opts StartupOptions) []StartupResult {
+	type candidate struct {
+		id     string
+		server *models.MCPServer
+		reason StartupReason
+	}
+
+	byID := make(map[string]*models.MCPServer, len(servers))
+	for i := range servers {
+		byID[servers[i].ID] = &servers[i]
+	}
+
+	// Restored servers come first; a server selected for both reasons starts once
+	var candidates []candidate
+	selected := make(map[string]bool)
+	for _, id := range opts.Restore {
+		if selected[id] {
+			continue
+		}
+		selected[id] = true
+		candidates = append(candidates, candidate{id: id, server: byID[id], reason: StartupReasonRestore})
+	}
+	if opts.AutoStart {
+		for i := range servers {
+			server := &servers[i]
+			if !server.Configuration.AutoStart || selected[server.ID] {
+				continue
+			}
+			selected[server.ID] = true
+			candidates = append(candidates, candidate{id: server.ID, server: server, reason: StartupReasonAutoStart})
+		}
+	}
+
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultStartupParallelism
+	}
+
+	slog.Info("[STARTUP] Starting servers", "count", len(candidates), "parallelism", parallelism)
+
+	results := make([]StartupResult, len(candidates))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, c := range candidates {
+		wg.Add(1)
+		go func(i int, c candidate) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result := ls.startupServer(c.id, c.server, c.reason)
+			results[i] = result
+
+			if ls.eventBus != nil {
+				ls.eventBus.Publish(events.ServerStartupResultEvent(result.ServerID, string(result.Reason), string(result.Outcome), result.Error, result.Duration))
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	counts := make(map[StartupOutcome]int)
+	for _, result := range results {
+		counts[result.Outcome]++
+	}
+	slog.Info("[STARTUP] Startup complete", "started", counts[StartupStarted], "failed", counts[StartupFailed], "skipped", counts[StartupSkipped])
+
+	if ls.eventBus != nil {
+		ls.eventBus.Publish(events.StartupCompletedEvent(counts[StartupStarted], counts[StartupFailed], counts[StartupSkipped]))
+	}
+
+	return results
+}
+
+// startupServer starts one selected server and waits for the outcome
+// server is nil when a restored ID is no longer discovered
+func (ls *LifecycleService) startupServer(serverID string, server *models.MCPServer, reason StartupReason) (result StartupResult) {
+	result = StartupResult{ServerID: serverID, Reason: reason}
+	if server == nil {
+		result.Outcome = StartupSkipped
+		result.Error = "server is no longer discovered"
+		return result
+	}
+	result.Name = server.Name
+	ls.refresh(server)
+
+	if server.Status.State == models.StatusRunning || server.Status.State == models.StatusStarting {
+		result.Outcome = StartupSkipped
+		result.Error = fmt.Sprintf("server is already %s", server.Status.State)
+		return result
+	}
+
+	startedAt := time.Now()
+	defer func() { result.Duration = time.Since(startedAt) }()
+
+	if err := ls.StartServer(server); err != nil {
+		slog.Warn("[STARTUP] Failed to start server", "serverId", serverID, "serverName", server.Name, "error", err)
+		result.Outcome = StartupFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	status := ls.awaitStartup(server)
+	switch status.State {
+	case models.StatusRunning:
+		result.Outcome = StartupStarted
+	case models.StatusStarting:
+		result.Outcome = StartupFailed
+		result.Error = "server did not become ready in time"
+	default:
+		result.Outcome = StartupFailed
+		result.Error = status.ErrorMessage
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("server is %s", status.State)
+		}
+	}
+
+	slog.Info("[STARTUP] Server start finished", "serverId", serverID, "serverName", server.Name, "outcome", result.Outcome)
+	return result
+}
+
+// awaitStartup polls the discovery cache until a started server leaves the starting state
+// The monitor fails the server after StartupTimeout; the wait allows a little longer.
+// A server missing from the cache is reported as running, since its launch succeeded
+func (ls *LifecycleService) awaitStartup(server *models.MCPServer) models.ServerStatus {
+	deadline := time.Now().Add(startupTimeout(server) + exitCollectTimeout)
+	for {
+		if ls.discoveryService == nil {
+			return models.ServerStatus{State: models.StatusRunning}
+		}
+		cached, exists := ls.discoveryService.GetServerByID(server.ID)
+		if !exists {
+			return models.ServerStatus{State: models.StatusRunning}
+		}
+		if cached.Status.State != models.StatusStarting || time.Now().After(deadline) {
+			return cached.Status
+		}
+		time.Sleep(startupPollInterval)
+	}
+}
+
+// ManagedServerIDs returns the IDs of the servers whose processes this service launched
+// and still holds, which is the set worth restoring at the next application launch
+func (ls *LifecycleService) ManagedServerIDs() []string {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	ids := make([]string, 0, len(ls.processes))
+	for id := range ls.processes {
+		ids = append(ids, id)
+	}
+	return ids
+}
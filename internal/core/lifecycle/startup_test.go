@@ -0,0 +1,223 @@
+package lifecycle
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// cacheDiscoveryService is a MockDiscoveryService backed by an in-memory server cache
+func cacheDiscoveryService(servers []models.MCPServer, onUpdate func(cache map[string]models.MCPServer)) *MockDiscoveryService {
+	var mu sync.Mutex
+	cache := make(map[string]models.MCPServer)
+	for _, server := range servers {
+		cache[server.ID] = server
+	}
+
+	return &MockDiscoveryService{
+		UpdateServerStateFunc: func(server *models.MCPServer) {
+			mu.Lock()
+			defer mu.Unlock()
+			cached := cache[server.ID]
+			cached.Status = server.Status
+			cached.PID = server.PID
+			cached.Launcher = server.Launcher
+			cached.Sandbox = server.Sandbox
+			cached.Port = server.Port
+			cache[server.ID] = cached
+			if onUpdate != nil {
+				onUpdate(cache)
+			}
+		},
+		GetServerByIDFunc: func(serverID string) (*models.MCPServer, bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			cached, exists := cache[serverID]
+			return &cached, exists
+		},
+		GetCachedServersFunc: func() []models.MCPServer {
+			mu.Lock()
+			defer mu.Unlock()
+			servers := make([]models.MCPServer, 0, len(cache))
+			for _, server := range cache {
+				servers = append(servers, server)
+			}
+			return servers
+		},
+	}
+}
+
+func TestLifecycleService_StartupServers(t *testing.T) {
+	var servers []models.MCPServer
+	for i := 0; i < 4; i++ {
+		server := models.NewMCPServer(fmt.Sprintf("auto-%d", i), fmt.Sprintf("/path/to/auto-%d", i), models.DiscoveryClientConfig)
+		server.Transport = models.TransportHTTP
+		server.Configuration.AutoStart = true
+		servers = append(servers, *server)
+	}
+
+	failing := models.NewMCPServer("failing", "/path/to/failing", models.DiscoveryClientConfig)
+	failing.Transport = models.TransportHTTP
+	failing.Configuration.AutoStart = true
+	servers = append(servers, *failing)
+
+	stdio := models.NewMCPServer("stdio", "/path/to/stdio", models.DiscoveryClientConfig)
+	stdio.Transport = models.TransportStdio
+	stdio.Configuration.AutoStart = true
+	servers = append(servers, *stdio)
+
+	manual := models.NewMCPServer("manual", "/path/to/manual", models.DiscoveryClientConfig)
+	manual.Transport = models.TransportHTTP
+	servers = append(servers, *manual)
+
+	// Track how many servers are starting at once
+	var concurrencyMu sync.Mutex
+	maxStarting := 0
+	ds := cacheDiscoveryService(servers, func(cache map[string]models.MCPServer) {
+		starting := 0
+		for _, server := range cache {
+			if server.Status.State == models.StatusStarting {
+				starting++
+			}
+		}
+		concurrencyMu.Lock()
+		defer concurrencyMu.Unlock()
+		if starting > maxStarting {
+			maxStarting = starting
+		}
+	})
+
+	var stdioStdin platform.StdinMode
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			if spec.Command == failing.InstallationPath {
+				return nil, fmt.Errorf("executable not found")
+			}
+			if spec.Command == stdio.InstallationPath {
+				concurrencyMu.Lock()
+				stdioStdin = spec.Stdin
+				concurrencyMu.Unlock()
+			}
+			return mockProcess(1234), nil
+		},
+	}
+
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	resultEvents := eventBus.Subscribe(events.EventServerStartupResult)
+	completedEvents := eventBus.Subscribe(events.EventStartupCompleted)
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+
+	gone := "6f1c1e4e-3a1b-4c57-9d8e-2b0f5e7a9c10"
+	results := service.StartupServers(servers, StartupOptions{
+		AutoStart:   true,
+		Restore:     []string{manual.ID, gone},
+		Parallelism: 2,
+	})
+
+	want := map[string]StartupOutcome{
+		manual.ID:  StartupStarted,
+		gone:       StartupSkipped,
+		failing.ID: StartupFailed,
+		stdio.ID:   StartupStarted,
+	}
+	for i := 0; i < 4; i++ {
+		want[servers[i].ID] = StartupStarted
+	}
+
+	if len(results) != len(want) {
+		t.Fatalf("Expected %d results, got %d: %+v", len(want), len(results), results)
+	}
+	if results[0].ServerID != manual.ID || results[0].Reason != StartupReasonRestore {
+		t.Errorf("Expected restored servers first, got %+v", results[0])
+	}
+	for _, result := range results {
+		if result.Outcome != want[result.ServerID] {
+			t.Errorf("Server %s (%s): expected %s, got %s (%s)", result.Name, result.ServerID, want[result.ServerID], result.Outcome, result.Error)
+		}
+		// Started servers waited out the settle delay before they were ready
+		if result.Outcome == StartupStarted && result.Duration < settleDelay {
+			t.Errorf("Server %s: expected its start to take at least %s, got %s", result.Name, settleDelay, result.Duration)
+		}
+	}
+
+	concurrencyMu.Lock()
+	if maxStarting > 2 {
+		t.Errorf("Expected at most 2 servers starting at once, got %d", maxStarting)
+	}
+	// stdio servers start like any other, talking to MCP Manager over a stdin pipe
+	if stdioStdin != platform.StdinPipe {
+		t.Errorf("Expected the stdio server to start with a stdin pipe, got %q", stdioStdin)
+	}
+	concurrencyMu.Unlock()
+
+	for i := 0; i < len(want); i++ {
+		select {
+		case event := <-resultEvents:
+			if event.Data["outcome"] == string(StartupStarted) && event.Data["durationMs"].(int64) < settleDelay.Milliseconds() {
+				t.Errorf("Expected the start duration in the result event, got %v", event.Data)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected a startup result event per server, got %d", i)
+		}
+	}
+	select {
+	case event := <-completedEvents:
+		if event.Data["started"] != 6 || event.Data["failed"] != 1 || event.Data["skipped"] != 1 {
+			t.Errorf("Unexpected startup summary: %v", event.Data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a startup completed event")
+	}
+
+	ids := service.ManagedServerIDs()
+	if len(ids) != 6 {
+		t.Errorf("Expected 6 managed servers to restore next time, got %d", len(ids))
+	}
+}
+
+func TestLifecycleService_StartupServers_CrashDoesNotBlock(t *testing.T) {
+	crashing := models.NewMCPServer("crashing", "/path/to/crashing", models.DiscoveryClientConfig)
+	crashing.Transport = models.TransportHTTP
+	crashing.Configuration.AutoStart = true
+	healthy := models.NewMCPServer("healthy", "/path/to/healthy", models.DiscoveryClientConfig)
+	healthy.Transport = models.TransportHTTP
+	healthy.Configuration.AutoStart = true
+	servers := []models.MCPServer{*crashing, *healthy}
+
+	// The crashing server's process exits right after launch
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			if spec.Command == crashing.InstallationPath {
+				return mockProcess(1111), nil
+			}
+			return mockProcess(2222), nil
+		},
+		IsRunningFunc: func(pid int) bool {
+			return pid != 1111
+		},
+	}
+
+	service := NewLifecycleService(pm, cacheDiscoveryService(servers, nil), &MockMonitoringService{}, nil)
+	defer service.StopAll()
+
+	start := time.Now()
+	results := service.StartupServers(servers, StartupOptions{AutoStart: true, Parallelism: 1})
+
+	if results[0].Outcome != StartupFailed || results[0].Error == "" {
+		t.Errorf("Expected crashing server to fail with a reason, got %+v", results[0])
+	}
+	if results[1].Outcome != StartupStarted {
+		t.Errorf("Expected healthy server to start after the crash, got %+v", results[1])
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Crash should free its slot promptly, startup took %s", elapsed)
+	}
+}
@@ -0,0 +1,274 @@
+package lifecycle
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+// RestartPolicy controls how the supervisor restarts crashed servers
+type RestartPolicy struct {
+	InitialBackoff     time.Duration // Delay before the first restart attempt
+	MaxBackoff         time.Duration // Upper bound for the exponential backoff
+	BackoffMultiplier  float64       // Growth factor applied per consecutive attempt
+	Jitter             float64       // Random spread applied to each delay (0.2 = ±20%)
+	CrashLoopThreshold int           // Crashes within CrashLoopWindow that trigger quarantine
+	CrashLoopWindow    time.Duration // Sliding window used for crash-loop detection
+	StableUptime       time.Duration // Uptime after which the attempt counter is reset
+}
+
+// DefaultRestartPolicy returns the restart policy used by the lifecycle service
+func DefaultRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		InitialBackoff:     1 * time.Second,
+		MaxBackoff:         60 * time.Second,
+		BackoffMultiplier:  2.0,
+		Jitter:             0.2,
+		CrashLoopThreshold: 5,
+		CrashLoopWindow:    5 * time.Minute,
+		StableUptime:       2 * time.Minute,
+	}
+}
+
+// RestartAction describes what the supervisor decided to do after a crash
+type RestartAction string
+
+const (
+	RestartNone        RestartAction = "none"        // Restart disabled for this server
+	RestartScheduled   RestartAction = "scheduled"   // A restart was scheduled
+	RestartQuarantined RestartAction = "quarantined" // The server was quarantined
+)
+
+// CrashDecision is the outcome of Supervisor.HandleCrash
+type CrashDecision struct {
+	Action    RestartAction
+	Attempt   int
+	Delay     time.Duration
+	RestartAt time.Time
+	Reason    string
+}
+
+// supervisionState tracks crash history for a single server
+type supervisionState struct {
+	attempts    int         // consecutive restart attempts since the last stable run
+	crashes     []time.Time // crash timestamps inside the crash-loop window
+	lastStart   time.Time   // when the current process was launched
+	timer       *time.Timer // pending restart, if any
+	quarantined bool
+}
+
+// Supervisor restarts crashed servers that have RestartOnCrash enabled.
+// Restarts use exponential backoff with jitter; servers that crash too often
+// within the crash-loop window are quarantined until the user starts them again.
+type Supervisor struct {
+	policy   RestartPolicy
+	eventBus *events.EventBus
+	restart  func(server *models.MCPServer)
+	mu       sync.Mutex
+	states   map[string]*supervisionState
+	now      func() time.Time
+	random   func() float64
+}
+
+// NewSupervisor creates a new supervisor
+// restart is invoked (on its own goroutine) when a scheduled restart fires, with the server
+// as it was when it crashed; it should look up the server's current state before restarting
+func NewSupervisor(policy RestartPolicy, eventBus *events.EventBus, restart func(server *models.MCPServer)) *Supervisor {
+	return &Supervisor{
+		policy:   policy,
+		eventBus: eventBus,
+		restart:  restart,
+		states:   make(map[string]*supervisionState),
+		now:      time.Now,
+		random:   rand.Float64,
+	}
+}
+
+// state returns the supervision state for a server, creating it if needed
+// Caller must hold s.mu
+func (s *Supervisor) state(serverID string) *supervisionState {
+	st, exists := s.states[serverID]
+	if !exists {
+		st = &supervisionState{}
+		s.states[serverID] = st
+	}
+	return st
+}
+
+// RecordStart notes that a server process has just been launched
+func (s *Supervisor) RecordStart(serverID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state(serverID).lastStart = s.now()
+}
+
+// HandleCrash records a crash and decides whether to schedule a restart or
+// quarantine the server. The server's status is updated in place; the caller
+// is responsible for synchronizing the discovery cache.
+func (s *Supervisor) HandleCrash(server *models.MCPServer) CrashDecision {
+	cfg := server.Configuration
+	if !cfg.RestartOnCrash || cfg.MaxRestartAttempts <= 0 {
+		return CrashDecision{Action: RestartNone}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state(server.ID)
+	if st.quarantined {
+		return CrashDecision{Action: RestartQuarantined, Reason: "server is quarantined"}
+	}
+
+	now := s.now()
+
+	// A run that lasted long enough counts as stable, so start counting afresh
+	if !st.lastStart.IsZero() && now.Sub(st.lastStart) >= s.policy.StableUptime {
+		st.attempts = 0
+	}
+
+	// Record the crash and drop crashes that fell out of the window
+	st.crashes = append(st.crashes, now)
+	cutoff := now.Add(-s.policy.CrashLoopWindow)
+	kept := st.crashes[:0]
+	for _, t := range st.crashes {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	st.crashes = kept
+
+	if s.policy.CrashLoopThreshold > 0 && len(st.crashes) >= s.policy.CrashLoopThreshold {
+		reason := fmt.Sprintf("crash loop detected: %d crashes within %s", len(st.crashes), s.policy.CrashLoopWindow)
+		return s.quarantine(server, st, reason)
+	}
+
+	if st.attempts >= cfg.MaxRestartAttempts {
+		reason := fmt.Sprintf("giving up after %d restart attempts", st.attempts)
+		return s.quarantine(server, st, reason)
+	}
+
+	st.attempts++
+	delay := s.backoff(st.attempts)
+	restartAt := now.Add(delay)
+
+	if st.timer != nil {
+		st.timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		s.mu.Lock()
+		// A newer crash, a cancel or a reset may have superseded this timer while it fired
+		if s.states[server.ID] != st || st.timer != timer {
+			s.mu.Unlock()
+			return
+		}
+		st.timer = nil
+		s.mu.Unlock()
+		s.restart(server)
+	})
+	st.timer = timer
+
+	slog.Info("[SUPERVISOR] Restart scheduled", "serverId", server.ID, "serverName", server.Name,
+		"attempt", st.attempts, "maxAttempts", cfg.MaxRestartAttempts, "delay", delay)
+
+	if s.eventBus != nil {
+		s.eventBus.Publish(events.ServerRestartScheduledEvent(server.ID, st.attempts, cfg.MaxRestartAttempts, delay, restartAt))
+	}
+
+	return CrashDecision{
+		Action:    RestartScheduled,
+		Attempt:   st.attempts,
+		Delay:     delay,
+		RestartAt: restartAt,
+	}
+}
+
+// quarantine marks a server as quarantined and publishes the event
+// Caller must hold s.mu
+func (s *Supervisor) quarantine(server *models.MCPServer, st *supervisionState, reason string) CrashDecision {
+	st.quarantined = true
+	if st.timer != nil {
+		st.timer.Stop()
+		st.timer = nil
+	}
+
+	server.Status.Quarantined = true
+	server.Status.CrashRecoverable = false
+
+	slog.Warn("[SUPERVISOR] Server quarantined", "serverId", server.ID, "serverName", server.Name, "reason", reason)
+
+	if s.eventBus != nil {
+		s.eventBus.Publish(events.ServerQuarantinedEvent(server.ID, reason, len(st.crashes)))
+	}
+
+	return CrashDecision{Action: RestartQuarantined, Attempt: st.attempts, Reason: reason}
+}
+
+// backoff returns the jittered delay for the given attempt (1-based)
+func (s *Supervisor) backoff(attempt int) time.Duration {
+	delay := float64(s.policy.InitialBackoff) * math.Pow(s.policy.BackoffMultiplier, float64(attempt-1))
+	if delay > float64(s.policy.MaxBackoff) {
+		delay = float64(s.policy.MaxBackoff)
+	}
+
+	if s.policy.Jitter > 0 {
+		// Spread uniformly across [delay*(1-jitter), delay*(1+jitter)]
+		delay *= 1 + s.policy.Jitter*(2*s.random()-1)
+	}
+
+	return time.Duration(delay)
+}
+
+// Cancel stops any pending restart for a server
+func (s *Supervisor) Cancel(serverID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if st, exists := s.states[serverID]; exists && st.timer != nil {
+		st.timer.Stop()
+		st.timer = nil
+	}
+}
+
+// Reset clears all crash history and quarantine for a server
+// Called when the user explicitly starts a server
+func (s *Supervisor) Reset(serverID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if st, exists := s.states[serverID]; exists {
+		if st.timer != nil {
+			st.timer.Stop()
+		}
+		delete(s.states, serverID)
+	}
+}
+
+// IsQuarantined reports whether a server is currently quarantined
+func (s *Supervisor) IsQuarantined(serverID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, exists := s.states[serverID]
+	return exists && st.quarantined
+}
+
+// Close cancels all pending restarts
+func (s *Supervisor) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, st := range s.states {
+		if st.timer != nil {
+			st.timer.Stop()
+			st.timer = nil
+		}
+	}
+}
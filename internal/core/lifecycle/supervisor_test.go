@@ -0,0 +1,387 @@
+package lifecycle
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
+)
+
+// testRestartPolicy returns a fast policy without jitter for deterministic tests
+func testRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		InitialBackoff:     10 * time.Millisecond,
+		MaxBackoff:         40 * time.Millisecond,
+		BackoffMultiplier:  2.0,
+		Jitter:             0,
+		CrashLoopThreshold: 5,
+		CrashLoopWindow:    time.Minute,
+		StableUptime:       time.Minute,
+	}
+}
+
+// newSupervisedServer creates a server with crash restarts enabled
+func newSupervisedServer(maxAttempts int) *models.MCPServer {
+	server := models.NewMCPServer("supervised", "/path/to/server", models.DiscoveryClientConfig)
+	server.Configuration.RestartOnCrash = true
+	server.Configuration.MaxRestartAttempts = maxAttempts
+	return server
+}
+
+func TestSupervisor_Backoff(t *testing.T) {
+	policy := testRestartPolicy()
+	s := NewSupervisor(policy, nil, func(*models.MCPServer) {})
+
+	expected := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		40 * time.Millisecond, // capped at MaxBackoff
+	}
+	for i, want := range expected {
+		if got := s.backoff(i + 1); got != want {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestSupervisor_BackoffJitter(t *testing.T) {
+	policy := testRestartPolicy()
+	policy.Jitter = 0.5
+	s := NewSupervisor(policy, nil, func(*models.MCPServer) {})
+
+	s.random = func() float64 { return 0 }
+	if got := s.backoff(1); got != 5*time.Millisecond {
+		t.Errorf("Expected lower jitter bound 5ms, got %v", got)
+	}
+
+	s.random = func() float64 { return 1 }
+	if got := s.backoff(1); got != 15*time.Millisecond {
+		t.Errorf("Expected upper jitter bound 15ms, got %v", got)
+	}
+}
+
+func TestSupervisor_HandleCrash_RestartDisabled(t *testing.T) {
+	s := NewSupervisor(testRestartPolicy(), nil, func(*models.MCPServer) {
+		t.Error("restart should not be called")
+	})
+
+	server := models.NewMCPServer("plain", "/path/to/server", models.DiscoveryClientConfig)
+	decision := s.HandleCrash(server)
+
+	if decision.Action != RestartNone {
+		t.Errorf("Expected no restart, got %s", decision.Action)
+	}
+}
+
+func TestSupervisor_HandleCrash_SchedulesRestart(t *testing.T) {
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	scheduled := eventBus.Subscribe(events.EventServerRestartScheduled)
+
+	restarted := make(chan *models.MCPServer, 1)
+	s := NewSupervisor(testRestartPolicy(), eventBus, func(server *models.MCPServer) {
+		restarted <- server
+	})
+
+	server := newSupervisedServer(3)
+	decision := s.HandleCrash(server)
+
+	if decision.Action != RestartScheduled {
+		t.Fatalf("Expected restart to be scheduled, got %s", decision.Action)
+	}
+	if decision.Attempt != 1 {
+		t.Errorf("Expected attempt 1, got %d", decision.Attempt)
+	}
+
+	select {
+	case event := <-scheduled:
+		if event.Data["serverID"] != server.ID {
+			t.Error("Event should carry the server ID")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for restart scheduled event")
+	}
+
+	select {
+	case got := <-restarted:
+		if got != server {
+			t.Error("Restart should receive the crashed server")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for restart")
+	}
+}
+
+func TestSupervisor_Cancel(t *testing.T) {
+	policy := testRestartPolicy()
+	policy.InitialBackoff = 50 * time.Millisecond
+
+	var mu sync.Mutex
+	restarts := 0
+	s := NewSupervisor(policy, nil, func(*models.MCPServer) {
+		mu.Lock()
+		restarts++
+		mu.Unlock()
+	})
+
+	server := newSupervisedServer(3)
+	s.HandleCrash(server)
+	s.Cancel(server.ID)
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if restarts != 0 {
+		t.Errorf("Expected cancelled restart not to run, got %d restarts", restarts)
+	}
+}
+
+func TestSupervisor_SupersededTimer(t *testing.T) {
+	var mu sync.Mutex
+	restarts := 0
+	s := NewSupervisor(testRestartPolicy(), nil, func(*models.MCPServer) {
+		mu.Lock()
+		restarts++
+		mu.Unlock()
+	})
+
+	server := newSupervisedServer(3)
+	s.HandleCrash(server)
+
+	// Let the timer fire while the supervisor is busy replacing it, as a newer crash does
+	s.mu.Lock()
+	time.Sleep(50 * time.Millisecond)
+	st := s.states[server.ID]
+	newer := time.AfterFunc(time.Hour, func() {})
+	defer newer.Stop()
+	st.timer = newer
+	s.mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+
+	s.mu.Lock()
+	if st.timer != newer {
+		t.Error("Expected the superseded timer to leave the newer one in place")
+	}
+	s.mu.Unlock()
+	mu.Lock()
+	defer mu.Unlock()
+	if restarts != 0 {
+		t.Errorf("Expected the superseded timer not to restart the server, got %d restarts", restarts)
+	}
+}
+
+func TestSupervisor_MaxRestartAttempts(t *testing.T) {
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	quarantined := eventBus.Subscribe(events.EventServerQuarantined)
+
+	s := NewSupervisor(testRestartPolicy(), eventBus, func(*models.MCPServer) {})
+	server := newSupervisedServer(2)
+
+	for i := 1; i <= 2; i++ {
+		if decision := s.HandleCrash(server); decision.Action != RestartScheduled {
+			t.Fatalf("crash %d: expected restart, got %s", i, decision.Action)
+		}
+		s.Cancel(server.ID)
+	}
+
+	decision := s.HandleCrash(server)
+	if decision.Action != RestartQuarantined {
+		t.Fatalf("Expected quarantine after max attempts, got %s", decision.Action)
+	}
+	if !server.Status.Quarantined {
+		t.Error("Server status should be marked quarantined")
+	}
+	if server.Status.CrashRecoverable {
+		t.Error("Quarantined server should not be crash recoverable")
+	}
+	if !s.IsQuarantined(server.ID) {
+		t.Error("Supervisor should report the server as quarantined")
+	}
+
+	select {
+	case <-quarantined:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for quarantined event")
+	}
+
+	// Explicit reset clears the quarantine
+	s.Reset(server.ID)
+	if s.IsQuarantined(server.ID) {
+		t.Error("Reset should clear the quarantine")
+	}
+}
+
+func TestSupervisor_CrashLoopDetection(t *testing.T) {
+	policy := testRestartPolicy()
+	policy.CrashLoopThreshold = 3
+	s := NewSupervisor(policy, nil, func(*models.MCPServer) {})
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	server := newSupervisedServer(10)
+	for i := 0; i < 2; i++ {
+		s.RecordStart(server.ID)
+		s.HandleCrash(server)
+		s.Cancel(server.ID)
+		now = now.Add(time.Second)
+	}
+
+	s.RecordStart(server.ID)
+	decision := s.HandleCrash(server)
+	if decision.Action != RestartQuarantined {
+		t.Fatalf("Expected crash loop quarantine, got %s", decision.Action)
+	}
+	if !strings.Contains(decision.Reason, "crash loop") {
+		t.Errorf("Expected crash loop reason, got %q", decision.Reason)
+	}
+}
+
+func TestSupervisor_CrashLoopWindowExpires(t *testing.T) {
+	policy := testRestartPolicy()
+	policy.CrashLoopThreshold = 2
+	s := NewSupervisor(policy, nil, func(*models.MCPServer) {})
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	server := newSupervisedServer(10)
+	s.HandleCrash(server)
+	s.Cancel(server.ID)
+
+	// The first crash falls outside the window
+	now = now.Add(2 * policy.CrashLoopWindow)
+	decision := s.HandleCrash(server)
+	s.Cancel(server.ID)
+
+	if decision.Action != RestartScheduled {
+		t.Errorf("Expected restart once old crashes expire, got %s", decision.Action)
+	}
+}
+
+func TestSupervisor_StableUptimeResetsAttempts(t *testing.T) {
+	s := NewSupervisor(testRestartPolicy(), nil, func(*models.MCPServer) {})
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	server := newSupervisedServer(3)
+	s.RecordStart(server.ID)
+	s.HandleCrash(server)
+	s.Cancel(server.ID)
+
+	s.RecordStart(server.ID)
+	if decision := s.HandleCrash(server); decision.Attempt != 2 {
+		t.Fatalf("Expected attempt 2 after quick crash, got %d", decision.Attempt)
+	}
+	s.Cancel(server.ID)
+
+	// Run long enough to be considered stable
+	s.RecordStart(server.ID)
+	now = now.Add(2 * time.Minute)
+	decision := s.HandleCrash(server)
+	s.Cancel(server.ID)
+
+	if decision.Attempt != 1 {
+		t.Errorf("Expected attempt counter reset to 1 after stable uptime, got %d", decision.Attempt)
+	}
+}
+
+func TestLifecycleService_RestartOnCrash(t *testing.T) {
+	var mu sync.Mutex
+	starts := 0
+	alive := map[int]bool{}
+
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			starts++
+			pid := 1000 + starts
+			// The first process dies immediately, the restarted one stays up
+			alive[pid] = starts > 1
+			return mockProcess(pid), nil
+		},
+		IsRunningFunc: func(pid int) bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return alive[pid]
+		},
+	}
+
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	scheduled := eventBus.Subscribe(events.EventServerRestartScheduled)
+
+	service := NewLifecycleService(pm, &MockDiscoveryService{}, &MockMonitoringService{}, eventBus)
+	defer service.StopAll()
+	service.supervisor.policy = testRestartPolicy()
+
+	server := newSupervisedServer(3)
+	if err := service.StartServer(server); err != nil {
+		t.Fatalf("StartServer should not error: %v", err)
+	}
+
+	select {
+	case <-scheduled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for restart to be scheduled")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := starts
+		mu.Unlock()
+		if n >= 2 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Crashed server was not restarted")
+}
+
+func TestLifecycleService_RestartCrashedServer_UsesCachedServer(t *testing.T) {
+	crashed := newSupervisedServer(3)
+	crashed.Status.State = models.StatusError
+
+	// The configuration changed after the crash
+	cached := *crashed
+	cached.InstallationPath = "/path/to/updated-server"
+
+	launched := make(chan string, 1)
+	pm := &MockProcessManager{
+		StartWithOutputFunc: func(spec platform.LaunchSpec) (*platform.ManagedProcess, error) {
+			launched <- spec.Command
+			return mockProcess(1234), nil
+		},
+		IsRunningFunc: func(pid int) bool { return true },
+	}
+	ds := &MockDiscoveryService{
+		GetServerByIDFunc: func(serverID string) (*models.MCPServer, bool) {
+			server := cached
+			return &server, true
+		},
+	}
+
+	service := NewLifecycleService(pm, ds, &MockMonitoringService{}, nil)
+	defer service.StopAll()
+	service.restartCrashedServer(crashed)
+
+	select {
+	case command := <-launched:
+		if command != cached.InstallationPath {
+			t.Errorf("Expected the cached server to be restarted, got %s", command)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Crashed server was not restarted")
+	}
+}
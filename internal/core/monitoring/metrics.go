@@ -18,6 +18,7 @@ type MetricsCollector struct {
 	mu             sync.RWMutex
 	rateLimitCache map[string]time.Time // serverID -> last update time
 	rateLimitMu    sync.RWMutex
+	cgroupStats    func(pid int) (*platform.CgroupStats, bool) // Accounting of the cgroup MCP Manager placed pid in
 }
 
 // cachedMetrics stores metrics with their server context
@@ -34,6 +35,7 @@ func NewMetricsCollector(processInfo platform.ProcessInfo, eventBus *events.Even
 		eventBus:       eventBus,
 		metricsCache:   make(map[string]*cachedMetrics),
 		rateLimitCache: make(map[string]time.Time),
+		cgroupStats:    platform.ReadCgroupStats,
 	}
 }
 
@@ -62,13 +64,22 @@ func (mc *MetricsCollector) GetMetrics(serverID string, status *models.ServerSta
 		metrics.Uptime = time.Since(status.LastStateChange)
 	}
 
-	// Get memory usage if PID is available
+	// Prefer cgroup accounting, which includes every child process
 	if pid > 0 {
-		memBytes, err := mc.processInfo.GetMemoryUsage(pid)
-		if err == nil && memBytes > 0 {
+		if stats, ok := mc.cgroupStats(pid); ok {
+			memBytes, cpuTime, processCount := stats.MemoryBytes, stats.CPUTime, stats.ProcessCount
 			metrics.MemoryBytes = &memBytes
+			metrics.CPUTime = &cpuTime
+			metrics.ProcessCount = &processCount
+			metrics.Accounting = "cgroup"
+		} else {
+			memBytes, err := mc.processInfo.GetMemoryUsage(pid)
+			if err == nil && memBytes > 0 {
+				metrics.MemoryBytes = &memBytes
+				metrics.Accounting = "process"
+			}
+			// Silently ignore errors - memory might not be available
 		}
-		// Silently ignore errors - memory might not be available
 	}
 
 	// Request count is not implemented yet (would require MCP protocol support)
@@ -82,10 +93,16 @@ func (mc *MetricsCollector) GetMetrics(serverID string, status *models.ServerSta
 
 	// Publish metrics updated event
 	if mc.eventBus != nil {
-		event := events.ServerMetricsUpdatedEvent(serverID, map[string]interface{}{
-			"uptime":      metrics.Uptime.Seconds(),
-			"memoryBytes": metrics.MemoryBytes,
-		})
+		data := map[string]interface{}{
+			"uptime":       metrics.Uptime.Seconds(),
+			"memoryBytes":  metrics.MemoryBytes,
+			"processCount": metrics.ProcessCount,
+			"accounting":   metrics.Accounting,
+		}
+		if metrics.CPUTime != nil {
+			data["cpuTime"] = metrics.CPUTime.Seconds()
+		}
+		event := events.ServerMetricsUpdatedEvent(serverID, data)
 		mc.eventBus.Publish(event)
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Positronikal/MCPManager/internal/core/events"
 	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/Positronikal/MCPManager/internal/platform"
 )
 
 // MockProcessInfo implements ProcessInfo for testing
@@ -457,3 +458,46 @@ func TestGetMetrics_CachedUptimeUpdate(t *testing.T) {
 		t.Errorf("Expected uptime difference around 500ms, got %v", uptimeDiff)
 	}
 }
+
+func TestGetMetrics_CgroupAccounting(t *testing.T) {
+	mockPI := &MockProcessInfo{memoryUsage: map[int]uint64{1234: 1000}}
+	mc := NewMetricsCollector(mockPI, nil)
+	mc.cgroupStats = func(pid int) (*platform.CgroupStats, bool) {
+		if pid != 1234 {
+			return nil, false
+		}
+		return &platform.CgroupStats{MemoryBytes: 5000, CPUTime: 3 * time.Second, ProcessCount: 4}, true
+	}
+
+	status := models.NewServerStatus()
+	status.State = models.StatusRunning
+
+	metrics, err := mc.GetMetrics("server-1", status, 1234)
+	if err != nil {
+		t.Fatalf("GetMetrics failed: %v", err)
+	}
+
+	// The cgroup covers the whole process tree, so it wins over the main process
+	if metrics.MemoryBytes == nil || *metrics.MemoryBytes != 5000 {
+		t.Errorf("Expected cgroup memory 5000, got %v", metrics.MemoryBytes)
+	}
+	if metrics.CPUTime == nil || *metrics.CPUTime != 3*time.Second {
+		t.Errorf("Expected cgroup CPU time 3s, got %v", metrics.CPUTime)
+	}
+	if metrics.ProcessCount == nil || *metrics.ProcessCount != 4 {
+		t.Errorf("Expected 4 processes, got %v", metrics.ProcessCount)
+	}
+	if metrics.Accounting != "cgroup" {
+		t.Errorf("Expected cgroup accounting, got %q", metrics.Accounting)
+	}
+
+	// Without a managed cgroup only the main process is measured
+	mockPI.memoryUsage[5678] = 2000
+	metrics, _ = mc.GetMetrics("server-2", status, 5678)
+	if metrics.MemoryBytes == nil || *metrics.MemoryBytes != 2000 || metrics.Accounting != "process" {
+		t.Errorf("Expected process memory 2000, got %v (%s)", metrics.MemoryBytes, metrics.Accounting)
+	}
+	if metrics.CPUTime != nil || metrics.ProcessCount != nil {
+		t.Error("CPU time and process count are only available from cgroup accounting")
+	}
+}
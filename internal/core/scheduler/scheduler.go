@@ -0,0 +1,430 @@
+package scheduler
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/core/lifecycle"
+	"github.com/Positronikal/MCPManager/internal/models"
+)
+
+const (
+	// tickInterval is how often the scheduler looks for rules that are due
+	tickInterval = 15 * time.Second
+
+	// missedAfter is how late a run may start before it counts as missed
+	missedAfter = 2 * time.Minute
+
+	// sleepThreshold is how far the wall clock must run ahead of the monotonic clock
+	// between two ticks before the gap is reported as sleep
+	sleepThreshold = time.Minute
+)
+
+// Lifecycle performs scheduled actions (avoid circular dependency)
+type Lifecycle interface {
+	RunBatch(req lifecycle.BatchRequest) (*lifecycle.BatchResult, error)
+	StartGroup(group models.ServerGroup, requires models.ServerRequirements) (*lifecycle.BatchResult, error)
+	StopGroup(group models.ServerGroup, requires models.ServerRequirements, force bool) (*lifecycle.BatchResult, error)
+}
+
+// StateLoader supplies the schedule rules, groups and requirements (avoid circular dependency)
+type StateLoader interface {
+	LoadState() (*models.ApplicationState, error)
+}
+
+// RuleStatus is the schedule of one rule and the outcome of its last run
+type RuleStatus struct {
+	ID        string                `json:"id"`
+	Rule      string                `json:"rule"` // Description, e.g. "restart of group tools"
+	Cron      string                `json:"cron"`
+	Action    models.ScheduleAction `json:"action"`
+	Disabled  bool                  `json:"disabled,omitempty"`
+	NextRun   *time.Time            `json:"nextRun,omitempty"`
+	LastRun   *time.Time            `json:"lastRun,omitempty"`
+	LastError string                `json:"lastError,omitempty"`
+	Running   bool                  `json:"running,omitempty"`
+	Error     string                `json:"error,omitempty"` // Why the rule cannot be scheduled
+}
+
+// entry tracks scheduling for a single rule
+type entry struct {
+	cron      string
+	schedule  *models.CronSchedule
+	next      time.Time
+	lastRun   time.Time
+	lastError string
+	running   bool
+}
+
+// Scheduler runs the schedule rules stored in the application state
+// Rules are re-read on every tick, so edits take effect without a restart. Only runs
+// that fall due while the app is running are considered: a run missed while the
+// computer was asleep is reported once it wakes, and runs late if the rule asks to
+// catch up. Every action is logged to the affected servers like any other lifecycle event
+type Scheduler struct {
+	lifecycle Lifecycle
+	state     StateLoader
+	eventBus  *events.EventBus
+	now       func() time.Time
+	mu        sync.Mutex
+	entries   map[string]*entry // rule ID -> entry
+	lastTick  time.Time
+	stopChan  chan struct{}
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(lc Lifecycle, state StateLoader, eventBus *events.EventBus) *Scheduler {
+	return &Scheduler{
+		lifecycle: lc,
+		state:     state,
+		eventBus:  eventBus,
+		now:       time.Now,
+		entries:   make(map[string]*entry),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start begins the background scheduling loop
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop ends the scheduling loop and waits for scheduled actions in progress
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+	s.wg.Wait()
+}
+
+// run ticks periodically until stopped
+func (s *Scheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	s.tickNow()
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.tickNow()
+		}
+	}
+}
+
+// tickNow runs a tick at the current time, working out how long the computer slept since the last one
+func (s *Scheduler) tickNow() {
+	now := s.now()
+
+	s.mu.Lock()
+	last := s.lastTick
+	s.lastTick = now
+	s.mu.Unlock()
+
+	var slept time.Duration
+	if !last.IsZero() {
+		// The monotonic clock stops while the computer is suspended; the wall clock does not
+		slept = now.Round(0).Sub(last.Round(0)) - now.Sub(last)
+	}
+	s.tick(now, slept)
+}
+
+// tick runs the rules that are due at now, reporting runs that were missed
+// slept is how long the computer was asleep since the previous tick
+func (s *Scheduler) tick(now time.Time, slept time.Duration) {
+	state, err := s.state.LoadState()
+	if err != nil {
+		slog.Warn("[SCHEDULE] Failed to load schedules", "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool, len(state.Schedules))
+	for _, rule := range state.Schedules {
+		seen[rule.ID] = true
+		e, err := s.entryFor(rule, now)
+		if err != nil {
+			slog.Warn("[SCHEDULE] Ignoring invalid schedule", "ruleId", rule.ID, "error", err)
+			continue
+		}
+		if rule.Disabled {
+			e.next = e.schedule.Next(now)
+			continue
+		}
+		if e.next.IsZero() || now.Before(e.next) {
+			continue
+		}
+
+		// Count the occurrences since the last check; normally just one
+		count, latest, previous := 0, time.Time{}, time.Time{}
+		for t := e.next; !t.IsZero() && !t.After(now); t = e.schedule.Next(t) {
+			count++
+			previous, latest = latest, t
+		}
+		e.next = e.schedule.Next(now)
+
+		onTime := now.Sub(latest) <= missedAfter
+		missed, lastMissed := count, latest
+		if onTime {
+			missed, lastMissed = count-1, previous
+		}
+		if missed > 0 {
+			s.reportMissed(rule, state, missed, lastMissed, missedReason(now, slept), !onTime && rule.CatchUp)
+		}
+		if onTime || rule.CatchUp {
+			s.launch(rule, e, state, now)
+		}
+	}
+
+	for id := range s.entries {
+		if !seen[id] {
+			delete(s.entries, id)
+		}
+	}
+}
+
+// entryFor returns the entry for a rule, creating it or rescheduling it if the cron expression changed
+// Must be called with s.mu held
+func (s *Scheduler) entryFor(rule models.ScheduleRule, now time.Time) (*entry, error) {
+	e, exists := s.entries[rule.ID]
+	if exists && e.cron == rule.Cron {
+		return e, nil
+	}
+
+	schedule, err := models.ParseCron(rule.Cron)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		e = &entry{}
+		s.entries[rule.ID] = e
+	}
+	e.cron = rule.Cron
+	e.schedule = schedule
+	e.next = schedule.Next(now)
+	return e, nil
+}
+
+// missedReason explains why a run did not happen on time
+func missedReason(now time.Time, slept time.Duration) string {
+	if slept >= sleepThreshold {
+		return fmt.Sprintf("the computer was asleep or its clock jumped ahead by about %s (until %s)",
+			slept.Round(time.Minute), now.Format("15:04"))
+	}
+	return "MCP Manager was not able to run the schedule on time"
+}
+
+// reportMissed logs a missed run to the rule's servers and publishes a schedule.missed event
+func (s *Scheduler) reportMissed(rule models.ScheduleRule, state *models.ApplicationState, missed int, lastMissed time.Time, reason string, caughtUp bool) {
+	runs := "run"
+	if missed > 1 {
+		runs = fmt.Sprintf("%d runs", missed)
+	}
+	message := fmt.Sprintf("Missed scheduled %s (%s) at %s: %s", runs, rule.Describe(), lastMissed.Format("2006-01-02 15:04"), reason)
+	if caughtUp {
+		message += "; running it now"
+	} else {
+		message += "; waiting for the next run"
+	}
+
+	slog.Warn("[SCHEDULE] Missed scheduled run", "ruleId", rule.ID, "rule", rule.Describe(), "missed", missed, "lastMissed", lastMissed, "reason", reason, "catchUp", caughtUp)
+	for _, serverID := range ruleServers(rule, state) {
+		s.publishLog(serverID, models.LogWarning, message)
+	}
+	if s.eventBus != nil {
+		s.eventBus.Publish(events.ScheduleMissedEvent(rule.ID, rule.Describe(), missed, lastMissed, reason, caughtUp))
+	}
+}
+
+// launch runs a rule's action in the background, unless its previous run is still in progress
+// Must be called with s.mu held
+func (s *Scheduler) launch(rule models.ScheduleRule, e *entry, state *models.ApplicationState, now time.Time) {
+	if e.running {
+		slog.Warn("[SCHEDULE] Skipping run, previous run still in progress", "ruleId", rule.ID, "rule", rule.Describe())
+		for _, serverID := range ruleServers(rule, state) {
+			s.publishLog(serverID, models.LogWarning, fmt.Sprintf("Skipped scheduled %s (%s): the previous run is still in progress", rule.Action, rule.Describe()))
+		}
+		return
+	}
+	e.running = true
+	e.lastRun = now
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		err := s.execute(rule, state)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		e.running = false
+		e.lastError = ""
+		if err != nil {
+			e.lastError = err.Error()
+		}
+	}()
+}
+
+// execute performs a rule's action and logs the outcome for each server
+func (s *Scheduler) execute(rule models.ScheduleRule, state *models.ApplicationState) error {
+	slog.Info("[SCHEDULE] Running scheduled action", "ruleId", rule.ID, "rule", rule.Describe(), "action", rule.Action, "serverId", rule.ServerID, "group", rule.Group)
+	for _, serverID := range ruleServers(rule, state) {
+		s.publishLog(serverID, models.LogInfo, fmt.Sprintf("Scheduled %s (%s)", rule.Action, rule.Describe()))
+	}
+
+	results, err := s.perform(rule, state)
+	if err != nil {
+		slog.Warn("[SCHEDULE] Scheduled action failed", "ruleId", rule.ID, "rule", rule.Describe(), "error", err)
+		for _, serverID := range ruleServers(rule, state) {
+			s.publishLog(serverID, models.LogError, fmt.Sprintf("Scheduled %s (%s) failed: %v", rule.Action, rule.Describe(), err))
+		}
+		return err
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Success {
+			s.publishLog(result.ServerID, models.LogSuccess, fmt.Sprintf("Scheduled %s (%s) completed", rule.Action, rule.Describe()))
+			continue
+		}
+		s.publishLog(result.ServerID, models.LogError, fmt.Sprintf("Scheduled %s (%s) failed: %s", rule.Action, rule.Describe(), result.Error))
+		name := result.Name
+		if name == "" {
+			name = result.ServerID
+		}
+		failed = append(failed, fmt.Sprintf("%s: %s", name, result.Error))
+	}
+
+	slog.Info("[SCHEDULE] Scheduled action complete", "ruleId", rule.ID, "rule", rule.Describe(), "failed", len(failed))
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// perform applies the rule's action to its server or group, returning the per-server results
+func (s *Scheduler) perform(rule models.ScheduleRule, state *models.ApplicationState) ([]lifecycle.BatchServerResult, error) {
+	if rule.ServerID != "" {
+		result, err := s.lifecycle.RunBatch(lifecycle.BatchRequest{
+			Action:    lifecycle.BatchAction(rule.Action),
+			ServerIDs: []string{rule.ServerID},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Results, nil
+	}
+
+	group, exists := state.FindGroup(rule.Group)
+	if !exists {
+		return nil, fmt.Errorf("group not found: %s", rule.Group)
+	}
+
+	var stopped *lifecycle.BatchResult
+	if rule.Action == models.ScheduleStop || rule.Action == models.ScheduleRestart {
+		result, err := s.lifecycle.StopGroup(*group, state.Requires, false)
+		if err != nil {
+			return nil, err
+		}
+		if rule.Action == models.ScheduleStop {
+			return result.Results, nil
+		}
+		stopped = result
+	}
+
+	result, err := s.lifecycle.StartGroup(*group, state.Requires)
+	if err != nil {
+		return nil, err
+	}
+	if stopped == nil {
+		return result.Results, nil
+	}
+
+	// A restart fails for a server if either half failed
+	results := result.Results
+	for _, stop := range stopped.Results {
+		if stop.Success {
+			continue
+		}
+		for i := range results {
+			if results[i].ServerID == stop.ServerID && results[i].Success {
+				results[i] = stop
+			}
+		}
+	}
+	return results, nil
+}
+
+// ruleServers returns the IDs of the servers a rule acts on
+func ruleServers(rule models.ScheduleRule, state *models.ApplicationState) []string {
+	if rule.ServerID != "" {
+		return []string{rule.ServerID}
+	}
+	if group, exists := state.FindGroup(rule.Group); exists {
+		return group.ServerIDs
+	}
+	return nil
+}
+
+// Status returns the schedule of every rule, in the order they are stored
+func (s *Scheduler) Status() ([]RuleStatus, error) {
+	state, err := s.state.LoadState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schedules: %w", err)
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]RuleStatus, 0, len(state.Schedules))
+	for _, rule := range state.Schedules {
+		status := RuleStatus{
+			ID:       rule.ID,
+			Rule:     rule.Describe(),
+			Cron:     rule.Cron,
+			Action:   rule.Action,
+			Disabled: rule.Disabled,
+		}
+
+		var next time.Time
+		if e, exists := s.entries[rule.ID]; exists && e.cron == rule.Cron {
+			next = e.next
+			if !e.lastRun.IsZero() {
+				lastRun := e.lastRun
+				status.LastRun = &lastRun
+			}
+			status.LastError = e.lastError
+			status.Running = e.running
+		} else if schedule, err := models.ParseCron(rule.Cron); err == nil {
+			next = schedule.Next(now)
+		} else {
+			status.Error = err.Error()
+		}
+		if !next.IsZero() && !rule.Disabled {
+			status.NextRun = &next
+		}
+
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// publishLog publishes a lifecycle log entry for a server
+func (s *Scheduler) publishLog(serverID string, severity models.LogSeverity, message string) {
+	if s.eventBus == nil {
+		return
+	}
+	s.eventBus.Publish(events.ServerLogEntryEvent(serverID, models.NewLogEntry(severity, serverID, message)))
+}
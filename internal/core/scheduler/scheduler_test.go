@@ -0,0 +1,271 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/core/lifecycle"
+	"github.com/Positronikal/MCPManager/internal/models"
+	"github.com/google/uuid"
+)
+
+// mockLifecycle records the operations the scheduler performs
+type mockLifecycle struct {
+	mu    sync.Mutex
+	calls []string
+	fail  string // Error for every server result, if set
+}
+
+func (m *mockLifecycle) record(call string, ids []string) *lifecycle.BatchResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, call)
+
+	result := &lifecycle.BatchResult{}
+	for _, id := range ids {
+		result.Results = append(result.Results, lifecycle.BatchServerResult{ServerID: id, Success: m.fail == "", Error: m.fail})
+	}
+	return result
+}
+
+func (m *mockLifecycle) RunBatch(req lifecycle.BatchRequest) (*lifecycle.BatchResult, error) {
+	return m.record(string(req.Action), req.ServerIDs), nil
+}
+
+func (m *mockLifecycle) StartGroup(group models.ServerGroup, requires models.ServerRequirements) (*lifecycle.BatchResult, error) {
+	return m.record("start group "+group.Name, group.ServerIDs), nil
+}
+
+func (m *mockLifecycle) StopGroup(group models.ServerGroup, requires models.ServerRequirements, force bool) (*lifecycle.BatchResult, error) {
+	return m.record("stop group "+group.Name, group.ServerIDs), nil
+}
+
+func (m *mockLifecycle) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// stateLoader returns a fixed application state
+type stateLoader struct {
+	state *models.ApplicationState
+}
+
+func (l *stateLoader) LoadState() (*models.ApplicationState, error) {
+	if l.state == nil {
+		return nil, fmt.Errorf("no state")
+	}
+	return l.state, nil
+}
+
+// newTestScheduler returns a scheduler for the given rules and groups
+func newTestScheduler(rules []models.ScheduleRule, groups []models.ServerGroup) (*Scheduler, *mockLifecycle, *events.EventBus) {
+	state := models.NewApplicationState()
+	state.Schedules = rules
+	state.Groups = groups
+
+	lc := &mockLifecycle{}
+	eventBus := events.NewEventBus()
+	return NewScheduler(lc, &stateLoader{state: state}, eventBus), lc, eventBus
+}
+
+// at returns a time on the test day
+func at(hour, minute, second int) time.Time {
+	return time.Date(2026, time.March, 11, hour, minute, second, 0, time.Local)
+}
+
+// drainMessages returns the messages of the log entries published so far
+func drainMessages(ch <-chan *events.Event) []string {
+	var messages []string
+	for {
+		select {
+		case event := <-ch:
+			messages = append(messages, event.Data["message"].(string))
+		default:
+			return messages
+		}
+	}
+}
+
+func TestScheduler_RunsDueRule(t *testing.T) {
+	serverID := uuid.New().String()
+	s, lc, eventBus := newTestScheduler([]models.ScheduleRule{
+		{ID: "hourly", Cron: "0 * * * *", Action: models.ScheduleRestart, ServerID: serverID},
+	}, nil)
+	logCh := eventBus.Subscribe(events.EventServerLogEntry)
+
+	s.tick(at(10, 30, 0), 0)
+	s.tick(at(10, 59, 50), 0)
+	s.wg.Wait()
+	if calls := lc.Calls(); len(calls) != 0 {
+		t.Fatalf("Expected no runs before 11:00, got %v", calls)
+	}
+
+	s.tick(at(11, 0, 5), 0)
+	s.wg.Wait()
+	if calls := lc.Calls(); len(calls) != 1 || calls[0] != "restart" {
+		t.Fatalf("Expected one restart, got %v", calls)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	messages := drainMessages(logCh)
+	if len(messages) != 2 || !strings.HasPrefix(messages[0], "Scheduled restart") || !strings.HasSuffix(messages[1], "completed") {
+		t.Errorf("Expected start and completion log entries, got %q", messages)
+	}
+
+	statuses, err := s.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if statuses[0].LastRun == nil || !statuses[0].LastRun.Equal(at(11, 0, 5)) {
+		t.Errorf("Expected last run at 11:00:05, got %v", statuses[0].LastRun)
+	}
+	if statuses[0].NextRun == nil || !statuses[0].NextRun.Equal(at(12, 0, 0)) {
+		t.Errorf("Expected next run at 12:00, got %v", statuses[0].NextRun)
+	}
+}
+
+func TestScheduler_MissedWhileAsleep(t *testing.T) {
+	serverID := uuid.New().String()
+	s, lc, eventBus := newTestScheduler([]models.ScheduleRule{
+		{ID: "hourly", Cron: "0 * * * *", Action: models.ScheduleStart, ServerID: serverID},
+	}, nil)
+	missedCh := eventBus.Subscribe(events.EventScheduleMissed)
+	logCh := eventBus.Subscribe(events.EventServerLogEntry)
+
+	s.tick(at(10, 30, 0), 0)
+	s.tick(at(13, 5, 0), 2*time.Hour+35*time.Minute)
+	s.wg.Wait()
+
+	if calls := lc.Calls(); len(calls) != 0 {
+		t.Errorf("Expected missed runs to be skipped, got %v", calls)
+	}
+
+	select {
+	case event := <-missedCh:
+		if event.Data["missed"] != 3 {
+			t.Errorf("Expected 3 missed runs, got %v", event.Data["missed"])
+		}
+		if !strings.Contains(event.Data["reason"].(string), "asleep") {
+			t.Errorf("Expected the reason to mention sleep, got %q", event.Data["reason"])
+		}
+		if event.Data["caughtUp"] != false {
+			t.Error("Expected caughtUp to be false")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a schedule.missed event")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	messages := drainMessages(logCh)
+	if len(messages) != 1 || !strings.Contains(messages[0], "Missed scheduled 3 runs") {
+		t.Errorf("Expected a missed run log entry, got %q", messages)
+	}
+
+	// The schedule carries on from now
+	s.tick(at(14, 0, 10), 0)
+	s.wg.Wait()
+	if calls := lc.Calls(); len(calls) != 1 || calls[0] != "start" {
+		t.Errorf("Expected the 14:00 run, got %v", calls)
+	}
+}
+
+func TestScheduler_CatchUp(t *testing.T) {
+	serverID := uuid.New().String()
+	s, lc, eventBus := newTestScheduler([]models.ScheduleRule{
+		{ID: "morning", Cron: "0 9 * * *", Action: models.ScheduleStart, ServerID: serverID, CatchUp: true},
+	}, nil)
+	missedCh := eventBus.Subscribe(events.EventScheduleMissed)
+
+	s.tick(at(8, 0, 0), 0)
+	s.tick(at(9, 30, 0), 0)
+	s.wg.Wait()
+
+	if calls := lc.Calls(); len(calls) != 1 || calls[0] != "start" {
+		t.Errorf("Expected the missed run to catch up once, got %v", calls)
+	}
+
+	select {
+	case event := <-missedCh:
+		if event.Data["caughtUp"] != true {
+			t.Error("Expected caughtUp to be true")
+		}
+		if strings.Contains(event.Data["reason"].(string), "asleep") {
+			t.Errorf("Expected no mention of sleep, got %q", event.Data["reason"])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a schedule.missed event")
+	}
+}
+
+func TestScheduler_GroupRestart(t *testing.T) {
+	a, b := uuid.New().String(), uuid.New().String()
+	s, lc, _ := newTestScheduler([]models.ScheduleRule{
+		{ID: "nightly", Cron: "0 3 * * *", Action: models.ScheduleRestart, Group: "tools"},
+	}, []models.ServerGroup{{Name: "tools", ServerIDs: []string{a, b}}})
+
+	s.tick(at(2, 59, 0), 0)
+	s.tick(at(3, 0, 0), 0)
+	s.wg.Wait()
+
+	calls := lc.Calls()
+	if len(calls) != 2 || calls[0] != "stop group tools" || calls[1] != "start group tools" {
+		t.Errorf("Expected the group to stop then start, got %v", calls)
+	}
+}
+
+func TestScheduler_FailureRecorded(t *testing.T) {
+	serverID := uuid.New().String()
+	s, lc, _ := newTestScheduler([]models.ScheduleRule{
+		{ID: "evening", Name: "stop for the night", Cron: "0 18 * * *", Action: models.ScheduleStop, ServerID: serverID},
+	}, nil)
+	lc.fail = "server is busy"
+
+	s.tick(at(17, 0, 0), 0)
+	s.tick(at(18, 0, 30), 0)
+	s.wg.Wait()
+
+	statuses, err := s.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if statuses[0].Rule != "stop for the night" {
+		t.Errorf("Expected the rule name, got %q", statuses[0].Rule)
+	}
+	if !strings.Contains(statuses[0].LastError, "server is busy") {
+		t.Errorf("Expected the failure to be recorded, got %q", statuses[0].LastError)
+	}
+}
+
+func TestScheduler_DisabledRule(t *testing.T) {
+	serverID := uuid.New().String()
+	s, lc, eventBus := newTestScheduler([]models.ScheduleRule{
+		{ID: "hourly", Cron: "0 * * * *", Action: models.ScheduleStart, ServerID: serverID, Disabled: true},
+	}, nil)
+	missedCh := eventBus.Subscribe(events.EventScheduleMissed)
+
+	s.tick(at(10, 30, 0), 0)
+	s.tick(at(13, 0, 0), 0)
+	s.wg.Wait()
+
+	if calls := lc.Calls(); len(calls) != 0 {
+		t.Errorf("Expected a disabled rule not to run, got %v", calls)
+	}
+	select {
+	case event := <-missedCh:
+		t.Errorf("Expected no missed runs for a disabled rule, got %v", event.Data)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	statuses, err := s.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if statuses[0].NextRun != nil {
+		t.Errorf("Expected no next run for a disabled rule, got %v", statuses[0].NextRun)
+	}
+}
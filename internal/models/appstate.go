@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,11 +11,13 @@ import (
 
 // UserPreferences contains user-configurable preferences
 type UserPreferences struct {
-	Theme                 string `json:"theme"` // "dark" or "light"
-	LogRetentionPerServer int    `json:"logRetentionPerServer"`
-	AutoStartServers      bool   `json:"autoStartServers"`
-	MinimizeToTray        bool   `json:"minimizeToTray"`
-	ShowNotifications     bool   `json:"showNotifications"`
+	Theme                 string         `json:"theme"` // "dark" or "light"
+	LogRetentionPerServer int            `json:"logRetentionPerServer"`
+	AutoStartServers      bool           `json:"autoStartServers"`
+	RestoreSession        bool           `json:"restoreSession"`           // Start the servers that were running at the last shutdown
+	ShutdownPolicy        ShutdownPolicy `json:"shutdownPolicy,omitempty"` // What happens to managed servers on exit; empty = ShutdownStop
+	MinimizeToTray        bool           `json:"minimizeToTray"`
+	ShowNotifications     bool           `json:"showNotifications"`
 }
 
 // WindowLayout stores the window position and size
@@ -34,16 +37,27 @@ type Filters struct {
 	SearchQuery      string      `json:"searchQuery,omitempty"`
 }
 
+// DiscoverySourceOverride changes the defaults of one discovery source; nil fields keep them
+type DiscoverySourceOverride struct {
+	Enabled  *bool `json:"enabled,omitempty"`
+	Priority *int  `json:"priority,omitempty"` // Higher wins when sources find servers with the same name
+}
+
 // ApplicationState represents the complete application state
 type ApplicationState struct {
-	Version              string          `json:"version"`
-	LastSaved            time.Time       `json:"lastSaved"`
-	Preferences          UserPreferences `json:"preferences"`
-	WindowLayout         WindowLayout    `json:"windowLayout"`
-	Filters              Filters         `json:"filters"`
-	DiscoveredServers    []string        `json:"discoveredServers"` // List of server IDs
-	MonitoredConfigPaths []string        `json:"monitoredConfigPaths"`
-	LastDiscoveryScan    time.Time       `json:"lastDiscoveryScan"`
+	Version              string                             `json:"version"`
+	LastSaved            time.Time                          `json:"lastSaved"`
+	Preferences          UserPreferences                    `json:"preferences"`
+	WindowLayout         WindowLayout                       `json:"windowLayout"`
+	Filters              Filters                            `json:"filters"`
+	DiscoveredServers    []string                           `json:"discoveredServers"` // List of server IDs
+	MonitoredConfigPaths []string                           `json:"monitoredConfigPaths"`
+	LastDiscoveryScan    time.Time                          `json:"lastDiscoveryScan"`
+	LastRunningServers   []string                           `json:"lastRunningServers,omitempty"` // Server IDs running at the last shutdown
+	Groups               []ServerGroup                      `json:"groups,omitempty"`
+	Requires             ServerRequirements                 `json:"requires,omitempty"` // Server ID -> IDs of the servers it requires
+	Schedules            []ScheduleRule                     `json:"schedules,omitempty"`
+	DiscoverySources     map[string]DiscoverySourceOverride `json:"discoverySources,omitempty"` // Source name -> override
 }
 
 // NewApplicationState creates a new ApplicationState with default values
@@ -55,6 +69,8 @@ func NewApplicationState() *ApplicationState {
 			Theme:                 "dark",
 			LogRetentionPerServer: 1000,
 			AutoStartServers:      false,
+			RestoreSession:        false,
+			ShutdownPolicy:        ShutdownStop,
 			MinimizeToTray:        true,
 			ShowNotifications:     true,
 		},
@@ -107,6 +123,11 @@ func (s *ApplicationState) Validate() error {
 			s.Preferences.LogRetentionPerServer)
 	}
 
+	// Validate shutdown policy if set
+	if s.Preferences.ShutdownPolicy != "" && !s.Preferences.ShutdownPolicy.IsValid() {
+		return fmt.Errorf("invalid shutdown policy: %s", s.Preferences.ShutdownPolicy)
+	}
+
 	// Validate discovered servers are UUIDs
 	for i, serverID := range s.DiscoveredServers {
 		if _, err := uuid.Parse(serverID); err != nil {
@@ -114,6 +135,53 @@ func (s *ApplicationState) Validate() error {
 		}
 	}
 
+	// Validate last running servers are UUIDs
+	for i, serverID := range s.LastRunningServers {
+		if _, err := uuid.Parse(serverID); err != nil {
+			return fmt.Errorf("lastRunningServers[%d] is not a valid UUID: %s", i, serverID)
+		}
+	}
+
+	// Validate groups have unique names
+	names := make(map[string]bool, len(s.Groups))
+	for i := range s.Groups {
+		if err := s.Groups[i].Validate(); err != nil {
+			return err
+		}
+		if names[s.Groups[i].Name] {
+			return fmt.Errorf("duplicate group name: %s", s.Groups[i].Name)
+		}
+		names[s.Groups[i].Name] = true
+	}
+
+	// Validate requirements, rejecting dependency cycles
+	if err := s.Requires.Validate(); err != nil {
+		return err
+	}
+
+	// Validate schedules have unique IDs and target existing groups
+	ids := make(map[string]bool, len(s.Schedules))
+	for i := range s.Schedules {
+		rule := &s.Schedules[i]
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if ids[rule.ID] {
+			return fmt.Errorf("duplicate schedule id: %s", rule.ID)
+		}
+		ids[rule.ID] = true
+		if rule.Group != "" && !names[rule.Group] {
+			return fmt.Errorf("schedule %s: group not found: %s", rule.ID, rule.Group)
+		}
+	}
+
+	// Validate discovery source overrides name a source
+	for name := range s.DiscoverySources {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("discovery source name cannot be empty")
+		}
+	}
+
 	// Validate monitored config paths are absolute
 	for i, path := range s.MonitoredConfigPaths {
 		if !filepath.IsAbs(path) {
@@ -164,6 +232,16 @@ func (s *ApplicationState) RemoveDiscoveredServer(serverID string) {
 	}
 }
 
+// FindGroup returns the group with the given name
+func (s *ApplicationState) FindGroup(name string) (*ServerGroup, bool) {
+	for i := range s.Groups {
+		if s.Groups[i].Name == name {
+			return &s.Groups[i], true
+		}
+	}
+	return nil, false
+}
+
 // AddMonitoredPath adds a config path to the monitored paths list
 func (s *ApplicationState) AddMonitoredPath(path string) error {
 	// Validate absolute path
@@ -127,6 +127,69 @@ func TestApplicationStateValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "not a valid UUID",
 		},
+		{
+			name: "last running server not a UUID",
+			setup: func() *ApplicationState {
+				state := NewApplicationState()
+				state.LastRunningServers = []string{"not-a-uuid"}
+				return state
+			},
+			wantErr: true,
+			errMsg:  "lastRunningServers[0] is not a valid UUID",
+		},
+		{
+			name: "duplicate group name",
+			setup: func() *ApplicationState {
+				state := NewApplicationState()
+				state.Groups = []ServerGroup{{Name: "reporting"}, {Name: "reporting"}}
+				return state
+			},
+			wantErr: true,
+			errMsg:  "duplicate group name: reporting",
+		},
+		{
+			name: "requires cycle",
+			setup: func() *ApplicationState {
+				state := NewApplicationState()
+				a, b := uuid.New().String(), uuid.New().String()
+				state.Requires = ServerRequirements{a: {b}, b: {a}}
+				return state
+			},
+			wantErr: true,
+			errMsg:  "dependency cycle",
+		},
+		{
+			name: "duplicate schedule id",
+			setup: func() *ApplicationState {
+				state := NewApplicationState()
+				rule := ScheduleRule{ID: "nightly", Cron: "@daily", Action: ScheduleRestart, ServerID: uuid.New().String()}
+				state.Schedules = []ScheduleRule{rule, rule}
+				return state
+			},
+			wantErr: true,
+			errMsg:  "duplicate schedule id: nightly",
+		},
+		{
+			name: "schedule for unknown group",
+			setup: func() *ApplicationState {
+				state := NewApplicationState()
+				state.Schedules = []ScheduleRule{{ID: "evening", Cron: "0 18 * * *", Action: ScheduleStop, Group: "paid"}}
+				return state
+			},
+			wantErr: true,
+			errMsg:  "group not found: paid",
+		},
+		{
+			name: "discovery source override without a name",
+			setup: func() *ApplicationState {
+				state := NewApplicationState()
+				enabled := false
+				state.DiscoverySources = map[string]DiscoverySourceOverride{"": {Enabled: &enabled}}
+				return state
+			},
+			wantErr: true,
+			errMsg:  "discovery source name cannot be empty",
+		},
 		{
 			name: "monitored path not absolute",
 			setup: func() *ApplicationState {
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ClientFormat is the file format of an MCP client's configuration file
+type ClientFormat string
+
+const (
+	ClientFormatJSON  ClientFormat = "json"
+	ClientFormatJSONC ClientFormat = "jsonc" // JSON with comments and trailing commas
+	ClientFormatTOML  ClientFormat = "toml"
+)
+
+// ValidClientFormats contains all valid client config formats
+var ValidClientFormats = []ClientFormat{ClientFormatJSON, ClientFormatJSONC, ClientFormatTOML}
+
+// IsValid checks if the client format is valid
+func (f ClientFormat) IsValid() bool {
+	for _, valid := range ValidClientFormats {
+		if f == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientPathPlaceholders are the placeholders a relative client config path must start with
+// ${CONFIG} is the platform config directory (%APPDATA%, ~/Library/Application Support,
+// ~/.config), ${APPDATA} the application data directory and ${HOME} the home directory
+var ClientPathPlaceholders = []string{"${CONFIG}", "${APPDATA}", "${HOME}"}
+
+// ClientPathsDefault is the Paths key used on operating systems without their own entry
+const ClientPathsDefault = "default"
+
+// ClientDefinition describes where an MCP client keeps its server configuration and how
+// that file is laid out
+type ClientDefinition struct {
+	ID          string              `json:"id"`    // Stable identifier, e.g. "cursor"
+	Name        string              `json:"name"`  // Display name, e.g. "Cursor"
+	Paths       map[string][]string `json:"paths"` // GOOS or "default" -> config files, first preferred
+	Format      ClientFormat        `json:"format"`
+	RootKey     string              `json:"rootKey"`               // Key holding the servers, e.g. "mcpServers"; dots separate nested keys, e.g. "mcp.servers"
+	SupportsURL bool                `json:"supportsUrl,omitempty"` // Accepts url-based (remote) server entries
+	Disabled    bool                `json:"disabled,omitempty"`    // Hides a built-in client
+}
+
+// PathsFor returns the config file paths for an operating system, unexpanded
+func (d *ClientDefinition) PathsFor(goos string) []string {
+	if paths, ok := d.Paths[goos]; ok {
+		return paths
+	}
+	return d.Paths[ClientPathsDefault]
+}
+
+// RootPath returns the keys leading to the servers
+func (d *ClientDefinition) RootPath() []string {
+	return strings.Split(d.RootKey, ".")
+}
+
+// Validate checks if the ClientDefinition is valid
+func (d *ClientDefinition) Validate() error {
+	if strings.TrimSpace(d.ID) == "" {
+		return fmt.Errorf("client id cannot be empty")
+	}
+	if d.Disabled {
+		// A disabled entry only needs to name the client it hides
+		return nil
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		return fmt.Errorf("client %s: name cannot be empty", d.ID)
+	}
+	if !d.Format.IsValid() {
+		return fmt.Errorf("client %s: invalid format: %s", d.ID, d.Format)
+	}
+	if strings.TrimSpace(d.RootKey) == "" {
+		return fmt.Errorf("client %s: rootKey cannot be empty", d.ID)
+	}
+	for _, key := range d.RootPath() {
+		if key == "" {
+			return fmt.Errorf("client %s: rootKey %q has an empty key", d.ID, d.RootKey)
+		}
+	}
+	if len(d.Paths) == 0 {
+		return fmt.Errorf("client %s: at least one config path is required", d.ID)
+	}
+	for goos, paths := range d.Paths {
+		for _, path := range paths {
+			if !filepath.IsAbs(path) && !hasClientPathPlaceholder(path) {
+				return fmt.Errorf("client %s: %s path %q must be absolute or start with one of %s", d.ID, goos, path, strings.Join(ClientPathPlaceholders, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// hasClientPathPlaceholder returns true if path starts with a known placeholder
+func hasClientPathPlaceholder(path string) bool {
+	for _, placeholder := range ClientPathPlaceholders {
+		if strings.HasPrefix(path, placeholder) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClientDefinition_Validate(t *testing.T) {
+	valid := func() ClientDefinition {
+		return ClientDefinition{
+			ID:      "editor",
+			Name:    "Editor",
+			Paths:   map[string][]string{ClientPathsDefault: {"${HOME}/.editor/mcp.json"}},
+			Format:  ClientFormatJSON,
+			RootKey: "servers",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*ClientDefinition)
+		wantErr bool
+		errMsg  string
+	}{
+		{"valid", func(d *ClientDefinition) {}, false, ""},
+		{"absolute path", func(d *ClientDefinition) { d.Paths["linux"] = []string{"/etc/editor/mcp.json"} }, false, ""},
+		{"missing id", func(d *ClientDefinition) { d.ID = " " }, true, "id cannot be empty"},
+		{"missing name", func(d *ClientDefinition) { d.Name = "" }, true, "name cannot be empty"},
+		{"unknown format", func(d *ClientDefinition) { d.Format = "yaml" }, true, "invalid format"},
+		{"missing root key", func(d *ClientDefinition) { d.RootKey = "" }, true, "rootKey cannot be empty"},
+		{"nested root key", func(d *ClientDefinition) { d.RootKey = "mcp.servers"; d.Format = ClientFormatJSONC }, false, ""},
+		{"empty nested key", func(d *ClientDefinition) { d.RootKey = "mcp..servers" }, true, "has an empty key"},
+		{"no paths", func(d *ClientDefinition) { d.Paths = nil }, true, "at least one config path"},
+		{"relative path", func(d *ClientDefinition) { d.Paths["darwin"] = []string{".editor/mcp.json"} }, true, "must be absolute"},
+		{"disabled needs only an id", func(d *ClientDefinition) { *d = ClientDefinition{ID: "cursor", Disabled: true} }, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := valid()
+			tt.modify(&def)
+			err := def.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %q", tt.errMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestClientDefinition_PathsFor(t *testing.T) {
+	def := ClientDefinition{Paths: map[string][]string{
+		ClientPathsDefault: {"${CONFIG}/Editor/mcp.json"},
+		"windows":          {"${APPDATA}/Editor/mcp.json"},
+	}}
+
+	if got := def.PathsFor("windows"); len(got) != 1 || got[0] != "${APPDATA}/Editor/mcp.json" {
+		t.Errorf("Expected the windows path, got %v", got)
+	}
+	if got := def.PathsFor("linux"); len(got) != 1 || got[0] != "${CONFIG}/Editor/mcp.json" {
+		t.Errorf("Expected the default path, got %v", got)
+	}
+}
@@ -2,8 +2,11 @@ package models
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 // ServerConfiguration contains the configuration for launching and managing an MCP server
@@ -11,13 +14,29 @@ type ServerConfiguration struct {
 	EnvironmentVariables map[string]string `json:"environmentVariables,omitempty"`
 	CommandLineArguments []string          `json:"commandLineArguments,omitempty"`
 	WorkingDirectory     string            `json:"workingDirectory,omitempty"`
+	EnvInheritance       EnvInheritance    `json:"envInheritance,omitempty"` // default inherit
+	EnvAllowlist         []string          `json:"envAllowlist,omitempty"`   // inherited variable names in allowlist mode
+	ExtraPath            []string          `json:"extraPath,omitempty"`      // directories prepended to PATH
+	Umask                string            `json:"umask,omitempty"`          // octal, e.g. "022" (Unix only)
+	Stdin                StdinMode         `json:"stdin,omitempty"`          // default pipe for stdio servers, null otherwise
 	AutoStart            bool              `json:"autoStart"`
 	RestartOnCrash       bool              `json:"restartOnCrash"`
 	MaxRestartAttempts   int               `json:"maxRestartAttempts"`
 	StartupTimeout       int               `json:"startupTimeout"`                // seconds
-	ShutdownTimeout      int               `json:"shutdownTimeout"`               // seconds
+	ShutdownTimeout      int               `json:"shutdownTimeout"`               // seconds to wait after SIGTERM before force killing
+	StdinCloseTimeout    int               `json:"stdinCloseTimeout,omitempty"`   // seconds to wait after closing a piped stdin before SIGTERM, 0 = default
 	HealthCheckInterval  int               `json:"healthCheckInterval,omitempty"` // seconds
-	HealthCheckEndpoint  string            `json:"healthCheckEndpoint,omitempty"`
+	HealthCheckEndpoint  string            `json:"healthCheckEndpoint,omitempty"` // absolute URL, or a path probed on the server's port
+	HealthCheckTimeout   int               `json:"healthCheckTimeout,omitempty"`  // seconds, 0 = default
+	HealthCheckFailures  int               `json:"healthCheckFailures,omitempty"` // consecutive failures before unhealthy, 0 = default
+	RestartOnUnhealthy   bool              `json:"restartOnUnhealthy,omitempty"`  // restart through the lifecycle service once unhealthy
+	ReadinessProbe       *ReadinessProbe   `json:"readinessProbe,omitempty"`      // nil = ready once the process stays up briefly
+	Hooks                *ServerHooks      `json:"hooks,omitempty"`               // Commands run around start and stop
+	MemoryMaxMB          int               `json:"memoryMaxMB,omitempty"`         // cgroup memory.max for the process tree (Linux), 0 = unlimited
+	CPUQuotaPercent      int               `json:"cpuQuotaPercent,omitempty"`     // cgroup cpu.max as percent of one CPU (Linux), 0 = unlimited
+	PidsMax              int               `json:"pidsMax,omitempty"`             // cgroup pids.max (Linux), 0 = unlimited
+	Sandbox              *SandboxProfile   `json:"sandbox,omitempty"`             // Namespace and Landlock confinement (Linux), nil = unconfined
+	Emulation            *LaunchEmulation  `json:"emulation,omitempty"`           // Launch as an MCP client would, nil = as configured
 }
 
 // envVarRegex matches valid environment variable names (uppercase letters, digits, underscores)
@@ -59,6 +78,17 @@ func (c *ServerConfiguration) Validate() error {
 		}
 	}
 
+	// Validate launch options
+	if c.EnvInheritance != "" && !c.EnvInheritance.IsValid() {
+		return fmt.Errorf("invalid envInheritance: %s", c.EnvInheritance)
+	}
+	if c.Stdin != "" && !c.Stdin.IsValid() {
+		return fmt.Errorf("invalid stdin mode: %s", c.Stdin)
+	}
+	if _, err := c.UmaskValue(); err != nil {
+		return err
+	}
+
 	// Validate MaxRestartAttempts is in range 0-10
 	if c.MaxRestartAttempts < 0 || c.MaxRestartAttempts > 10 {
 		return fmt.Errorf("maxRestartAttempts must be between 0 and 10, got: %d", c.MaxRestartAttempts)
@@ -77,5 +107,72 @@ func (c *ServerConfiguration) Validate() error {
 		return fmt.Errorf("healthCheckInterval must be positive when healthCheckEndpoint is set")
 	}
 
+	// Validate health check endpoint is an HTTP URL, or a path on the server's port
+	if c.HealthCheckEndpoint != "" && !strings.HasPrefix(c.HealthCheckEndpoint, "/") {
+		endpoint, err := url.Parse(c.HealthCheckEndpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return fmt.Errorf("healthCheckEndpoint must be an absolute http(s) URL or a path starting with /, got: %s", c.HealthCheckEndpoint)
+		}
+	}
+
+	// Validate health check tuning (0 selects the default)
+	if c.StdinCloseTimeout < 0 {
+		return fmt.Errorf("stdinCloseTimeout cannot be negative, got: %d", c.StdinCloseTimeout)
+	}
+	if c.HealthCheckTimeout < 0 {
+		return fmt.Errorf("healthCheckTimeout cannot be negative, got: %d", c.HealthCheckTimeout)
+	}
+	if c.HealthCheckFailures < 0 {
+		return fmt.Errorf("healthCheckFailures cannot be negative, got: %d", c.HealthCheckFailures)
+	}
+
+	// Validate resource limits (0 means unlimited)
+	if c.MemoryMaxMB < 0 {
+		return fmt.Errorf("memoryMaxMB cannot be negative, got: %d", c.MemoryMaxMB)
+	}
+	if c.CPUQuotaPercent < 0 {
+		return fmt.Errorf("cpuQuotaPercent cannot be negative, got: %d", c.CPUQuotaPercent)
+	}
+	if c.PidsMax < 0 {
+		return fmt.Errorf("pidsMax cannot be negative, got: %d", c.PidsMax)
+	}
+
+	// Validate readiness probe if configured
+	if c.ReadinessProbe != nil {
+		if err := c.ReadinessProbe.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate hooks if configured
+	if c.Hooks != nil {
+		if err := c.Hooks.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate sandbox profile if configured
+	if c.Sandbox != nil {
+		if err := c.Sandbox.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// UmaskValue parses the configured octal umask
+// Returns nil if no umask is configured
+func (c *ServerConfiguration) UmaskValue() (*uint32, error) {
+	if c.Umask == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseUint(c.Umask, 8, 32)
+	if err != nil || value > 0o777 {
+		return nil, fmt.Errorf("umask must be an octal value between 000 and 777, got: %s", c.Umask)
+	}
+
+	umask := uint32(value)
+	return &umask, nil
+}
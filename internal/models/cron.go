@@ -0,0 +1,180 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed five-field cron expression: minute, hour, day of month, month, day of week
+// Fields accept *, numbers, ranges (1-5), lists (1,15) and steps (*/15, 8-18/2); months and
+// weekdays also accept three-letter names (jan, mon). The macros @hourly, @daily (@midnight),
+// @weekly, @monthly and @yearly (@annually) are accepted too. As in cron, when both day fields
+// are restricted a day matching either one matches
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64 // Bit sets of allowed values
+	domStar, dowStar              bool   // Field started with *, so it does not restrict the day
+}
+
+// cronMacros maps the supported macros to their five-field expressions
+var cronMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// cronField describes the values one field of a cron expression accepts
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // Names for min, min+1, ...
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12,
+		names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	// 7 is Sunday as well as 0
+	cronDow = cronField{name: "day of week", min: 0, max: 7,
+		names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// ParseCron parses a cron expression
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		macro, exists := cronMacros[strings.ToLower(fields[0])]
+		if !exists {
+			return nil, fmt.Errorf("unknown cron macro: %s", fields[0])
+		}
+		fields = strings.Fields(macro)
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields (minute hour day month weekday), got %d: %q", len(fields), expr)
+	}
+
+	var schedule CronSchedule
+	var err error
+	if schedule.minute, _, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, err
+	}
+	if schedule.hour, _, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, err
+	}
+	if schedule.dom, schedule.domStar, err = parseCronField(fields[2], cronDom); err != nil {
+		return nil, err
+	}
+	if schedule.month, _, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, err
+	}
+	if schedule.dow, schedule.dowStar, err = parseCronField(fields[4], cronDow); err != nil {
+		return nil, err
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	return &schedule, nil
+}
+
+// parseCronField parses one field into a bit set, reporting whether it starts with *
+func parseCronField(expr string, field cronField) (uint64, bool, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step < 1 {
+				return 0, false, fmt.Errorf("invalid %s step: %q", field.name, part)
+			}
+		}
+
+		var lo, hi int
+		if rangeExpr == "*" {
+			lo, hi = field.min, field.max
+		} else {
+			loExpr, hiExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if lo, err = field.value(loExpr); err != nil {
+				return 0, false, err
+			}
+			hi = lo
+			switch {
+			case isRange:
+				if hi, err = field.value(hiExpr); err != nil {
+					return 0, false, err
+				}
+				if hi < lo {
+					return 0, false, fmt.Errorf("invalid %s range: %q", field.name, rangeExpr)
+				}
+			case hasStep:
+				// "5/15" means from 5 to the end in steps of 15
+				hi = field.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, strings.HasPrefix(expr, "*"), nil
+}
+
+// value parses a number or name within the field's range
+func (f cronField) value(expr string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(expr, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s: %q (must be %d-%d)", f.name, expr, f.min, f.max)
+	}
+	return v, nil
+}
+
+// cronSearchYears bounds the search for the next match of expressions like "0 0 30 2 *"
+const cronSearchYears = 5
+
+// Next returns the first time after t that matches the schedule, in t's location
+// Returns the zero time if nothing matches within the next five years
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's rule for the two day fields
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		errMsg string
+	}{
+		{"every minute", "* * * * *", ""},
+		{"lists ranges and steps", "0,30 9-17/2 1-15 */3 mon-fri", ""},
+		{"names", "0 9 * JAN-jun sun", ""},
+		{"sunday as 7", "0 0 * * 7", ""},
+		{"macro", "@weekly", ""},
+		{"too few fields", "0 9 * *", "must have 5 fields"},
+		{"unknown macro", "@fortnightly", "unknown cron macro"},
+		{"minute out of range", "60 * * * *", "invalid minute"},
+		{"zero day", "0 0 0 * *", "invalid day of month"},
+		{"bad name", "0 0 * * someday", "invalid day of week"},
+		{"backwards range", "0 17-9 * * *", "invalid hour range"},
+		{"zero step", "*/0 * * * *", "invalid minute step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Wednesday
+	base := time.Date(2026, time.March, 11, 10, 30, 20, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2026, time.March, 11, 10, 31, 0, 0, time.UTC)},
+		{"later today", "0 18 * * *", time.Date(2026, time.March, 11, 18, 0, 0, 0, time.UTC)},
+		{"tomorrow", "0 9 * * *", time.Date(2026, time.March, 12, 9, 0, 0, 0, time.UTC)},
+		{"step", "*/20 * * * *", time.Date(2026, time.March, 11, 10, 40, 0, 0, time.UTC)},
+		{"start step", "5/20 * * * *", time.Date(2026, time.March, 11, 10, 45, 0, 0, time.UTC)},
+		{"weekdays skip weekend", "0 9 * * mon-fri", time.Date(2026, time.March, 12, 9, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"next month", "0 0 1 * *", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"next year", "@yearly", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 13th or any Monday, whichever comes first
+		{"day of month or week", "0 0 13 * mon", time.Date(2026, time.March, 13, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron failed: %v", err)
+			}
+			if got := schedule.Next(base); !got.Equal(tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// DetachedServer is a managed server process left running when the application exited
+// with the detach shutdown policy; the next launch re-adopts it from the PID registry
+type DetachedServer struct {
+	ServerID     string          `json:"serverId"`
+	Name         string          `json:"name"`
+	PID          int             `json:"pid"`
+	ProcessName  string          `json:"processName,omitempty"` // Guards against the PID being reused by another program
+	RunningSince time.Time       `json:"runningSince"`          // Last state change before detaching, used for uptime
+	DetachedAt   time.Time       `json:"detachedAt"`
+	Sandbox      *SandboxProfile `json:"sandbox,omitempty"`    // Sandbox the process was launched in
+	Port         *int            `json:"port,omitempty"`       // Port the process listens on
+	OutputFile   string          `json:"outputFile,omitempty"` // File the process writes its output to
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LaunchEmulation launches a server the way an MCP client would, to reproduce failures that
+// only happen there, such as a command that is on the terminal's PATH but not the client's
+// It overrides the environment, PATH and working directory settings of the configuration
+type LaunchEmulation struct {
+	Client         string `json:"client,omitempty"`         // Client being emulated, for logs
+	StripEnv       bool   `json:"stripEnv,omitempty"`       // Inherit only the variables clients pass on by default (HOME, PATH, USER, ...)
+	LoginShellPath bool   `json:"loginShellPath,omitempty"` // Use the PATH the user's login shell sets up instead of the manager's
+	HomeCwd        bool   `json:"homeCwd,omitempty"`        // Run in the home directory instead of the configured working directory
+}
+
+// LaunchEmulationPresets are the launch profiles of common MCP clients
+// Claude Desktop passes its servers only a handful of variables, and as a GUI app its PATH is
+// the system default rather than the shell's; Claude Code runs in the user's terminal
+var LaunchEmulationPresets = map[string]LaunchEmulation{
+	"claude-desktop": {Client: "Claude Desktop", StripEnv: true},
+	"claude-code":    {Client: "Claude Code", LoginShellPath: true},
+}
+
+// Describe returns a summary such as "Claude Desktop (stripped environment, home directory as cwd)"
+func (e *LaunchEmulation) Describe() string {
+	var traits []string
+	if e.StripEnv {
+		traits = append(traits, "stripped environment")
+	}
+	if e.LoginShellPath {
+		traits = append(traits, "login-shell PATH")
+	}
+	if e.HomeCwd {
+		traits = append(traits, "home directory as cwd")
+	}
+	if len(traits) == 0 {
+		traits = append(traits, "manager's environment")
+	}
+
+	client := e.Client
+	if client == "" {
+		client = "client"
+	}
+	return fmt.Sprintf("%s (%s)", client, strings.Join(traits, ", "))
+}
@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestLaunchEmulation_Describe(t *testing.T) {
+	tests := []struct {
+		name      string
+		emulation LaunchEmulation
+		want      string
+	}{
+		{"preset", LaunchEmulationPresets["claude-desktop"], "Claude Desktop (stripped environment)"},
+		{"everything", LaunchEmulation{Client: "Cursor", StripEnv: true, LoginShellPath: true, HomeCwd: true},
+			"Cursor (stripped environment, login-shell PATH, home directory as cwd)"},
+		{"nothing", LaunchEmulation{}, "client (manager's environment)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.emulation.Describe(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -91,6 +91,9 @@ type DiscoverySource string
 
 const (
 	DiscoveryClientConfig DiscoverySource = "client_config"
+	DiscoveryClaudeCode   DiscoverySource = "claude_code" // ~/.claude.json and project .mcp.json files
+	DiscoveryCodex        DiscoverySource = "codex"       // ~/.codex/config.toml
+	DiscoveryVSCode       DiscoverySource = "vscode"      // User settings.json and workspace .vscode/mcp.json files
 	DiscoveryExtension    DiscoverySource = "extension"
 	DiscoveryFilesystem   DiscoverySource = "filesystem"
 )
@@ -98,6 +101,9 @@ const (
 // ValidDiscoverySources contains all valid discovery sources
 var ValidDiscoverySources = []DiscoverySource{
 	DiscoveryClientConfig,
+	DiscoveryClaudeCode,
+	DiscoveryCodex,
+	DiscoveryVSCode,
 	DiscoveryExtension,
 	DiscoveryFilesystem,
 }
@@ -112,6 +118,80 @@ func (d DiscoverySource) IsValid() bool {
 	return false
 }
 
+// EnvInheritance controls which of the manager's environment variables a server inherits
+type EnvInheritance string
+
+const (
+	EnvInheritAll       EnvInheritance = "inherit"   // Full environment plus configured variables
+	EnvInheritNone      EnvInheritance = "clean"     // Only configured variables
+	EnvInheritAllowlist EnvInheritance = "allowlist" // Variables named in envAllowlist plus configured variables
+)
+
+// ValidEnvInheritances contains all valid environment inheritance modes
+var ValidEnvInheritances = []EnvInheritance{
+	EnvInheritAll,
+	EnvInheritNone,
+	EnvInheritAllowlist,
+}
+
+// IsValid validates if the environment inheritance mode is valid
+func (e EnvInheritance) IsValid() bool {
+	for _, valid := range ValidEnvInheritances {
+		if e == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// StdinMode controls what a launched server reads on standard input
+type StdinMode string
+
+const (
+	StdinNull StdinMode = "null" // Null device (EOF immediately)
+	StdinPipe StdinMode = "pipe" // Pipe held open by the manager
+)
+
+// ValidStdinModes contains all valid stdin modes
+var ValidStdinModes = []StdinMode{
+	StdinNull,
+	StdinPipe,
+}
+
+// IsValid validates if the stdin mode is valid
+func (m StdinMode) IsValid() bool {
+	for _, valid := range ValidStdinModes {
+		if m == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ShutdownPolicy controls what happens to managed servers when the application exits
+type ShutdownPolicy string
+
+const (
+	ShutdownStop   ShutdownPolicy = "stop"   // Stop managed servers gracefully using each server's ShutdownTimeout
+	ShutdownDetach ShutdownPolicy = "detach" // Leave managed servers running and re-adopt them on the next launch; stdio servers are stopped
+)
+
+// ValidShutdownPolicies contains all valid shutdown policies
+var ValidShutdownPolicies = []ShutdownPolicy{
+	ShutdownStop,
+	ShutdownDetach,
+}
+
+// IsValid validates if the shutdown policy is valid
+func (p ShutdownPolicy) IsValid() bool {
+	for _, valid := range ValidShutdownPolicies {
+		if p == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateEnum validates any enum type
 func ValidateEnum(value interface{}, validValues []interface{}, fieldName string) error {
 	for _, valid := range validValues {
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ServerGroup is a named set of servers that are started and stopped together
+type ServerGroup struct {
+	Name      string   `json:"name"`
+	ServerIDs []string `json:"serverIds"`
+}
+
+// Validate checks if the ServerGroup is valid
+func (g *ServerGroup) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return fmt.Errorf("group name cannot be empty")
+	}
+	for i, serverID := range g.ServerIDs {
+		if _, err := uuid.Parse(serverID); err != nil {
+			return fmt.Errorf("group %q serverIds[%d] is not a valid UUID: %s", g.Name, i, serverID)
+		}
+	}
+	return nil
+}
+
+// ServerRequirements maps a server ID to the IDs of the servers it requires
+// A required server must be running and ready before the server that requires it starts
+type ServerRequirements map[string][]string
+
+// Validate checks that every ID is a UUID and that the requirements contain no cycle
+func (r ServerRequirements) Validate() error {
+	for serverID, required := range r {
+		if _, err := uuid.Parse(serverID); err != nil {
+			return fmt.Errorf("requires key is not a valid UUID: %s", serverID)
+		}
+		for i, requiredID := range required {
+			if _, err := uuid.Parse(requiredID); err != nil {
+				return fmt.Errorf("requires[%s][%d] is not a valid UUID: %s", serverID, i, requiredID)
+			}
+		}
+	}
+
+	if cycle := r.findCycle(); cycle != nil {
+		return fmt.Errorf("requires contains a dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// findCycle returns the server IDs along one dependency cycle, or nil if there is none
+// The first ID is repeated at the end so the cycle reads as a path
+func (r ServerRequirements) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		path = append(path, id)
+		for _, requiredID := range r[id] {
+			switch state[requiredID] {
+			case visiting:
+				for i, pathID := range path {
+					if pathID == requiredID {
+						return append(append([]string{}, path[i:]...), requiredID)
+					}
+				}
+			case unvisited:
+				if cycle := visit(requiredID); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return nil
+	}
+
+	// Visit in a stable order so the reported cycle does not change between runs
+	ids := make([]string, 0, len(r))
+	for id := range r {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if state[id] == unvisited {
+			if cycle := visit(id); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// StartLevels orders serverIDs, plus every server they transitively require, for starting
+// Each level only requires servers in earlier levels, so a level's servers can start
+// together once the previous level is ready. IDs keep their input order within a level
+func (r ServerRequirements) StartLevels(serverIDs []string) ([][]string, error) {
+	// Pull in transitive requirements
+	var ids []string
+	included := make(map[string]bool)
+	var include func(id string)
+	include = func(id string) {
+		if included[id] {
+			return
+		}
+		included[id] = true
+		ids = append(ids, id)
+		for _, requiredID := range r[id] {
+			include(requiredID)
+		}
+	}
+	for _, id := range serverIDs {
+		include(id)
+	}
+
+	return r.levels(ids, included)
+}
+
+// StopLevels orders serverIDs for stopping: servers that require others stop first
+// Only requirements between the given servers are considered
+func (r ServerRequirements) StopLevels(serverIDs []string) ([][]string, error) {
+	var ids []string
+	included := make(map[string]bool)
+	for _, id := range serverIDs {
+		if !included[id] {
+			included[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	levels, err := r.levels(ids, included)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(levels)-1; i < j; i, j = i+1, j-1 {
+		levels[i], levels[j] = levels[j], levels[i]
+	}
+	return levels, nil
+}
+
+// levels layers ids by their requirements within the included set
+func (r ServerRequirements) levels(ids []string, included map[string]bool) ([][]string, error) {
+	level := make(map[string]int, len(ids))
+	remaining := ids
+	var levels [][]string
+	for len(remaining) > 0 {
+		var current, next []string
+		for _, id := range remaining {
+			ready := true
+			for _, requiredID := range r[id] {
+				if _, placed := level[requiredID]; included[requiredID] && !placed {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				current = append(current, id)
+			} else {
+				next = append(next, id)
+			}
+		}
+		if len(current) == 0 {
+			return nil, fmt.Errorf("requires contains a dependency cycle between %s", strings.Join(next, ", "))
+		}
+		for _, id := range current {
+			level[id] = len(levels)
+		}
+		levels = append(levels, current)
+		remaining = next
+	}
+	return levels, nil
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestServerRequirements_Validate(t *testing.T) {
+	db, api, report := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+	tests := []struct {
+		name     string
+		requires ServerRequirements
+		errMsg   string
+	}{
+		{"empty", nil, ""},
+		{"chain", ServerRequirements{report: {api}, api: {db}}, ""},
+		{"diamond", ServerRequirements{report: {api, db}, api: {db}}, ""},
+		{"self", ServerRequirements{db: {db}}, "dependency cycle"},
+		{"cycle", ServerRequirements{report: {api}, api: {db}, db: {report}}, "dependency cycle"},
+		{"invalid key", ServerRequirements{"not-a-uuid": {db}}, "not a valid UUID"},
+		{"invalid requirement", ServerRequirements{db: {"not-a-uuid"}}, "not a valid UUID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.requires.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestServerRequirements_StartLevels(t *testing.T) {
+	db, cache, api, report := uuid.New().String(), uuid.New().String(), uuid.New().String(), uuid.New().String()
+	requires := ServerRequirements{
+		report: {api},
+		api:    {db, cache},
+	}
+
+	// db and cache are pulled in even though only report and api are in the group
+	levels, err := requires.StartLevels([]string{report, api})
+	if err != nil {
+		t.Fatalf("StartLevels failed: %v", err)
+	}
+	want := [][]string{{db, cache}, {api}, {report}}
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("Expected levels %v, got %v", want, levels)
+	}
+
+	requires[db] = []string{report}
+	if _, err := requires.StartLevels([]string{report}); err == nil {
+		t.Error("Expected a cycle to be rejected")
+	}
+}
+
+func TestServerRequirements_StopLevels(t *testing.T) {
+	db, api, report, outside := uuid.New().String(), uuid.New().String(), uuid.New().String(), uuid.New().String()
+	requires := ServerRequirements{
+		report: {api},
+		api:    {db, outside},
+	}
+
+	// Requirements outside the group do not add servers to stop
+	levels, err := requires.StopLevels([]string{db, api, report})
+	if err != nil {
+		t.Fatalf("StopLevels failed: %v", err)
+	}
+	want := [][]string{{report}, {api}, {db}}
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("Expected levels %v, got %v", want, levels)
+	}
+}
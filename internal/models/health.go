@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+)
+
+// HealthState represents the result of active health checking for a server
+type HealthState string
+
+const (
+	HealthUnknown   HealthState = "unknown"   // No check has completed yet
+	HealthHealthy   HealthState = "healthy"   // Last check succeeded
+	HealthUnhealthy HealthState = "unhealthy" // Consecutive failures reached the threshold
+	HealthDegraded  HealthState = "degraded"  // Failing, but below the threshold
+)
+
+// HealthStatus tracks active health check results for an MCP server
+type HealthStatus struct {
+	ServerID            string        `json:"serverId"`
+	State               HealthState   `json:"state"`
+	Endpoint            string        `json:"endpoint"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"`
+	TotalChecks         int64         `json:"totalChecks"`
+	TotalFailures       int64         `json:"totalFailures"`
+	LastLatency         time.Duration `json:"lastLatency"` // Round-trip time of the last check
+	LastCheck           time.Time     `json:"lastCheck"`
+	LastSuccess         time.Time     `json:"lastSuccess,omitempty"`
+	LastError           string        `json:"lastError,omitempty"`
+}
+
+// NewHealthStatus creates a new HealthStatus in the unknown state
+func NewHealthStatus(serverID, endpoint string) *HealthStatus {
+	return &HealthStatus{
+		ServerID: serverID,
+		State:    HealthUnknown,
+		Endpoint: endpoint,
+	}
+}
+
+// RecordSuccess records a successful health check
+func (h *HealthStatus) RecordSuccess(latency time.Duration) {
+	now := time.Now()
+	h.TotalChecks++
+	h.ConsecutiveFailures = 0
+	h.LastLatency = latency
+	h.LastCheck = now
+	h.LastSuccess = now
+	h.LastError = ""
+	h.State = HealthHealthy
+}
+
+// RecordFailure records a failed health check
+// threshold is the number of consecutive failures after which the server is unhealthy
+func (h *HealthStatus) RecordFailure(latency time.Duration, reason string, threshold int) {
+	h.TotalChecks++
+	h.TotalFailures++
+	h.ConsecutiveFailures++
+	h.LastLatency = latency
+	h.LastCheck = time.Now()
+	h.LastError = reason
+
+	if h.ConsecutiveFailures >= threshold {
+		h.State = HealthUnhealthy
+	} else {
+		h.State = HealthDegraded
+	}
+}
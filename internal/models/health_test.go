@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewHealthStatus(t *testing.T) {
+	status := NewHealthStatus("test-server", "http://localhost:8080/health")
+
+	if status.State != HealthUnknown {
+		t.Errorf("Expected unknown state, got %s", status.State)
+	}
+
+	if status.Endpoint != "http://localhost:8080/health" {
+		t.Errorf("Expected endpoint to be set, got %s", status.Endpoint)
+	}
+
+	if status.TotalChecks != 0 {
+		t.Error("Expected no checks initially")
+	}
+}
+
+func TestHealthStatus_RecordFailureAndRecovery(t *testing.T) {
+	status := NewHealthStatus("test-server", "http://localhost:8080/health")
+
+	status.RecordFailure(10*time.Millisecond, "connection refused", 2)
+	if status.State != HealthDegraded {
+		t.Errorf("Expected degraded below threshold, got %s", status.State)
+	}
+
+	status.RecordFailure(10*time.Millisecond, "connection refused", 2)
+	if status.State != HealthUnhealthy {
+		t.Errorf("Expected unhealthy at threshold, got %s", status.State)
+	}
+	if status.ConsecutiveFailures != 2 {
+		t.Errorf("Expected 2 consecutive failures, got %d", status.ConsecutiveFailures)
+	}
+	if status.LastError != "connection refused" {
+		t.Errorf("Expected last error to be recorded, got %q", status.LastError)
+	}
+
+	status.RecordSuccess(5 * time.Millisecond)
+	if status.State != HealthHealthy {
+		t.Errorf("Expected healthy after success, got %s", status.State)
+	}
+	if status.ConsecutiveFailures != 0 {
+		t.Error("Expected consecutive failures to reset")
+	}
+	if status.LastError != "" {
+		t.Error("Expected last error to be cleared")
+	}
+	if status.TotalChecks != 3 || status.TotalFailures != 2 {
+		t.Errorf("Expected 3 checks and 2 failures, got %d and %d", status.TotalChecks, status.TotalFailures)
+	}
+	if status.LastLatency != 5*time.Millisecond {
+		t.Errorf("Expected latency 5ms, got %v", status.LastLatency)
+	}
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// StateTransition is one journaled change of a server's lifecycle state
+type StateTransition struct {
+	ServerID  string       `json:"serverId"`
+	OldState  StatusState  `json:"oldState"`
+	NewState  StatusState  `json:"newState"`
+	Reason    string       `json:"reason,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+	PID       *int         `json:"pid,omitempty"`  // Process involved in the transition, if any
+	Exit      *ProcessExit `json:"exit,omitempty"` // Set when the transition was caused by the process ending
+}
+
+// IsCrash returns true if the transition records a failure of the server
+func (t *StateTransition) IsCrash() bool {
+	return t.NewState == StatusError
+}
+
+// DailyCrashes is the number of crashes on one calendar day (local time)
+type DailyCrashes struct {
+	Date    string `json:"date"` // YYYY-MM-DD
+	Crashes int    `json:"crashes"`
+}
+
+// AvailabilityReport summarizes a server's availability over a time window
+type AvailabilityReport struct {
+	ServerID      string         `json:"serverId"`
+	From          time.Time      `json:"from"`
+	To            time.Time      `json:"to"`
+	Uptime        time.Duration  `json:"uptime"`        // Time spent in the running state
+	UptimePercent float64        `json:"uptimePercent"` // Uptime as a percentage of the window
+	Crashes       int            `json:"crashes"`
+	MTBF          time.Duration  `json:"mtbf"` // Mean running time between crashes; 0 if there were none
+	CrashesPerDay []DailyCrashes `json:"crashesPerDay"`
+	Transitions   int            `json:"transitions"` // Journaled transitions inside the window
+}
+
+// TransitionsBetween returns the transitions with from <= timestamp < to, oldest first
+// A zero from or to leaves that side of the range open
+func TransitionsBetween(transitions []StateTransition, from, to time.Time) []StateTransition {
+	result := make([]StateTransition, 0, len(transitions))
+	for _, t := range transitions {
+		if !from.IsZero() && t.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !t.Timestamp.Before(to) {
+			continue
+		}
+		result = append(result, t)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	return result
+}
+
+// ComputeAvailability derives availability for the window [from, to) from a server's journal
+// The state at the start of the window is taken from the last transition before it;
+// a server without earlier history is assumed to have been stopped
+func ComputeAvailability(serverID string, transitions []StateTransition, from, to time.Time) *AvailabilityReport {
+	report := &AvailabilityReport{
+		ServerID:      serverID,
+		From:          from,
+		To:            to,
+		CrashesPerDay: []DailyCrashes{},
+	}
+	if !to.After(from) {
+		return report
+	}
+
+	state := StatusStopped
+	for _, t := range TransitionsBetween(transitions, time.Time{}, from) {
+		state = t.NewState
+	}
+
+	// Pre-fill every day of the window so quiet days show up as zero
+	dayIndex := make(map[string]int)
+	for day := truncateToDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		dayIndex[date] = len(report.CrashesPerDay)
+		report.CrashesPerDay = append(report.CrashesPerDay, DailyCrashes{Date: date})
+	}
+
+	cursor := from
+	for _, t := range TransitionsBetween(transitions, from, to) {
+		if state == StatusRunning {
+			report.Uptime += t.Timestamp.Sub(cursor)
+		}
+		cursor = t.Timestamp
+		state = t.NewState
+		report.Transitions++
+
+		if t.IsCrash() {
+			report.Crashes++
+			report.CrashesPerDay[dayIndex[t.Timestamp.Local().Format("2006-01-02")]].Crashes++
+		}
+	}
+	if state == StatusRunning {
+		report.Uptime += to.Sub(cursor)
+	}
+
+	report.UptimePercent = float64(report.Uptime) / float64(to.Sub(from)) * 100
+	if report.Crashes > 0 {
+		report.MTBF = report.Uptime / time.Duration(report.Crashes)
+	}
+
+	return report
+}
+
+// truncateToDay returns midnight (local time) of the day containing t
+func truncateToDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTransitionsBetween(t *testing.T) {
+	base := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+	transitions := []StateTransition{
+		{NewState: StatusRunning, Timestamp: base.Add(2 * time.Hour)},
+		{NewState: StatusStarting, Timestamp: base},
+		{NewState: StatusStopped, Timestamp: base.Add(4 * time.Hour)},
+	}
+
+	got := TransitionsBetween(transitions, base, base.Add(4*time.Hour))
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 transitions in range, got %d", len(got))
+	}
+	if got[0].NewState != StatusStarting || got[1].NewState != StatusRunning {
+		t.Errorf("Expected transitions sorted oldest first, got %s, %s", got[0].NewState, got[1].NewState)
+	}
+
+	if all := TransitionsBetween(transitions, time.Time{}, time.Time{}); len(all) != 3 {
+		t.Errorf("Zero bounds should leave the range open, got %d", len(all))
+	}
+}
+
+func TestComputeAvailability(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	from := day
+	to := day.AddDate(0, 0, 2)
+
+	transitions := []StateTransition{
+		// Running since before the window
+		{OldState: StatusStarting, NewState: StatusRunning, Timestamp: day.Add(-time.Hour)},
+		// Crash after 6h, restarted and running again 1h later
+		{OldState: StatusRunning, NewState: StatusError, Timestamp: day.Add(6 * time.Hour)},
+		{OldState: StatusError, NewState: StatusStarting, Timestamp: day.Add(7 * time.Hour)},
+		{OldState: StatusStarting, NewState: StatusRunning, Timestamp: day.Add(7 * time.Hour)},
+		// Crash on the second day after another 30h of running
+		{OldState: StatusRunning, NewState: StatusError, Timestamp: day.Add(37 * time.Hour)},
+		// Outside the window
+		{OldState: StatusError, NewState: StatusStopped, Timestamp: to.Add(time.Hour)},
+	}
+
+	report := ComputeAvailability("server-1", transitions, from, to)
+
+	if report.Uptime != 36*time.Hour {
+		t.Errorf("Expected 36h uptime, got %s", report.Uptime)
+	}
+	if report.UptimePercent != 75 {
+		t.Errorf("Expected 75%% uptime, got %f", report.UptimePercent)
+	}
+	if report.Crashes != 2 {
+		t.Errorf("Expected 2 crashes, got %d", report.Crashes)
+	}
+	if report.MTBF != 18*time.Hour {
+		t.Errorf("Expected MTBF of 18h, got %s", report.MTBF)
+	}
+	if report.Transitions != 4 {
+		t.Errorf("Expected 4 transitions in window, got %d", report.Transitions)
+	}
+
+	want := []DailyCrashes{{Date: "2025-03-10", Crashes: 1}, {Date: "2025-03-11", Crashes: 1}}
+	if len(report.CrashesPerDay) != len(want) {
+		t.Fatalf("Expected %d days, got %v", len(want), report.CrashesPerDay)
+	}
+	for i := range want {
+		if report.CrashesPerDay[i] != want[i] {
+			t.Errorf("Day %d: expected %+v, got %+v", i, want[i], report.CrashesPerDay[i])
+		}
+	}
+}
+
+func TestComputeAvailability_NoHistory(t *testing.T) {
+	to := time.Now()
+	report := ComputeAvailability("server-1", nil, to.AddDate(0, 0, -7), to)
+
+	if report.Uptime != 0 || report.Crashes != 0 || report.MTBF != 0 {
+		t.Errorf("Expected empty availability, got %+v", report)
+	}
+	if len(report.CrashesPerDay) < 7 {
+		t.Errorf("Expected a zero entry for every day, got %d", len(report.CrashesPerDay))
+	}
+}
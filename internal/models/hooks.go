@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HookStage identifies the point in a server's lifecycle at which a hook runs
+type HookStage string
+
+const (
+	HookPreStart  HookStage = "pre-start"  // Before the process launches; a failure aborts the start
+	HookPostStart HookStage = "post-start" // Once the server is ready
+	HookPreStop   HookStage = "pre-stop"   // Before a graceful stop signals the process
+	HookPostStop  HookStage = "post-stop"  // After the process has stopped or exited
+)
+
+// ValidHookStages contains all valid hook stages, in lifecycle order
+var ValidHookStages = []HookStage{HookPreStart, HookPostStart, HookPreStop, HookPostStop}
+
+// IsValid checks if the hook stage is valid
+func (s HookStage) IsValid() bool {
+	for _, valid := range ValidHookStages {
+		if s == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Hook is a command run at one point in a server's lifecycle
+// It runs with the server's environment and working directory; a non-zero exit is a failure
+type Hook struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	Timeout int      `json:"timeout,omitempty"` // seconds, 0 = default
+}
+
+// Validate checks if the Hook is valid
+func (h *Hook) Validate() error {
+	if strings.TrimSpace(h.Command) == "" {
+		return fmt.Errorf("hook command cannot be empty")
+	}
+	if h.Timeout < 0 {
+		return fmt.Errorf("hook timeout cannot be negative, got: %d", h.Timeout)
+	}
+	return nil
+}
+
+// ServerHooks holds the hooks configured for a server; nil entries are not run
+type ServerHooks struct {
+	PreStart  *Hook `json:"preStart,omitempty"`
+	PostStart *Hook `json:"postStart,omitempty"`
+	PreStop   *Hook `json:"preStop,omitempty"`
+	PostStop  *Hook `json:"postStop,omitempty"`
+}
+
+// Get returns the hook configured for a stage, or nil
+func (h *ServerHooks) Get(stage HookStage) *Hook {
+	if h == nil {
+		return nil
+	}
+	switch stage {
+	case HookPreStart:
+		return h.PreStart
+	case HookPostStart:
+		return h.PostStart
+	case HookPreStop:
+		return h.PreStop
+	case HookPostStop:
+		return h.PostStop
+	}
+	return nil
+}
+
+// Validate checks every configured hook
+func (h *ServerHooks) Validate() error {
+	for _, stage := range ValidHookStages {
+		if hook := h.Get(stage); hook != nil {
+			if err := hook.Validate(); err != nil {
+				return fmt.Errorf("%s %w", stage, err)
+			}
+		}
+	}
+	return nil
+}
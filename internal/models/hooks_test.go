@@ -0,0 +1,49 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServerHooks_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		hooks  *ServerHooks
+		errMsg string
+	}{
+		{"none", &ServerHooks{}, ""},
+		{"valid", &ServerHooks{PreStart: &Hook{Command: "refresh-token", Timeout: 30}, PostStop: &Hook{Command: "close-tunnel"}}, ""},
+		{"empty command", &ServerHooks{PreStop: &Hook{Command: " "}}, "pre-stop hook command cannot be empty"},
+		{"negative timeout", &ServerHooks{PostStart: &Hook{Command: "notify", Timeout: -1}}, "post-start hook timeout cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hooks.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestServerHooks_Get(t *testing.T) {
+	var unset *ServerHooks
+	if unset.Get(HookPreStart) != nil {
+		t.Error("Expected no hook from nil hooks")
+	}
+
+	hooks := &ServerHooks{PostStop: &Hook{Command: "cleanup"}}
+	if hook := hooks.Get(HookPostStop); hook == nil || hook.Command != "cleanup" {
+		t.Errorf("Expected the post-stop hook, got %+v", hook)
+	}
+	if hooks.Get(HookPreStart) != nil {
+		t.Error("Expected no pre-start hook")
+	}
+}
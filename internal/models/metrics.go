@@ -6,11 +6,14 @@ import (
 
 // ServerMetrics represents runtime metrics for an MCP server
 type ServerMetrics struct {
-	ServerID     string        `json:"serverId"`
-	Uptime       time.Duration `json:"uptime"`       // Time since server started
-	MemoryBytes  *uint64       `json:"memoryBytes"`  // Current memory usage in bytes (nil if unavailable)
-	RequestCount *int64        `json:"requestCount"` // Total requests handled (nil if unavailable)
-	Timestamp    time.Time     `json:"timestamp"`    // When these metrics were collected
+	ServerID     string         `json:"serverId"`
+	Uptime       time.Duration  `json:"uptime"`                 // Time since server started
+	MemoryBytes  *uint64        `json:"memoryBytes"`            // Current memory usage in bytes (nil if unavailable)
+	RequestCount *int64         `json:"requestCount"`           // Total requests handled (nil if unavailable)
+	CPUTime      *time.Duration `json:"cpuTime,omitempty"`      // CPU time used by the whole process tree (cgroup accounting only)
+	ProcessCount *int           `json:"processCount,omitempty"` // Processes in the tree (cgroup accounting only)
+	Accounting   string         `json:"accounting,omitempty"`   // "cgroup" (whole process tree) or "process" (main process only)
+	Timestamp    time.Time      `json:"timestamp"`              // When these metrics were collected
 }
 
 // NewServerMetrics creates a new ServerMetrics instance
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// PortPlaceholder in a server's arguments, environment or readiness probe is replaced with
+// the port the server is given at launch
+const PortPlaceholder = "${PORT}"
+
+// ReadinessProbeType identifies how a starting server is checked for readiness
+type ReadinessProbeType string
+
+const (
+	ReadinessLog  ReadinessProbeType = "log"  // A stdout/stderr line matches a regular expression
+	ReadinessTCP  ReadinessProbeType = "tcp"  // A TCP port accepts connections
+	ReadinessHTTP ReadinessProbeType = "http" // An HTTP GET returns 200
+	ReadinessMCP  ReadinessProbeType = "mcp"  // An MCP initialize handshake succeeds
+)
+
+// ReadinessProbe describes how to decide that a starting server is ready
+// The server stays in the starting state until the probe succeeds or StartupTimeout expires
+type ReadinessProbe struct {
+	Type     ReadinessProbeType `json:"type"`
+	Pattern  string             `json:"pattern,omitempty"`  // log: regular expression matched per output line
+	Address  string             `json:"address,omitempty"`  // tcp: host:port to connect to, may use ${PORT}
+	URL      string             `json:"url,omitempty"`      // http, mcp: endpoint to probe, may use ${PORT}
+	Interval int                `json:"interval,omitempty"` // milliseconds between attempts, 0 = default
+}
+
+// Validate checks if the ReadinessProbe is valid
+func (p *ReadinessProbe) Validate() error {
+	switch p.Type {
+	case ReadinessLog:
+		if p.Pattern == "" {
+			return fmt.Errorf("log readiness probe requires a pattern")
+		}
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return fmt.Errorf("invalid readiness pattern: %w", err)
+		}
+	case ReadinessTCP:
+		if _, _, err := net.SplitHostPort(withSamplePort(p.Address)); err != nil {
+			return fmt.Errorf("tcp readiness probe requires a host:port address, got: %s", p.Address)
+		}
+	case ReadinessHTTP, ReadinessMCP:
+		endpoint, err := url.Parse(withSamplePort(p.URL))
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return fmt.Errorf("%s readiness probe requires an absolute http(s) URL, got: %s", p.Type, p.URL)
+		}
+	default:
+		return fmt.Errorf("invalid readiness probe type: %s", p.Type)
+	}
+
+	if p.Interval < 0 {
+		return fmt.Errorf("readiness probe interval cannot be negative, got: %d", p.Interval)
+	}
+
+	return nil
+}
+
+// withSamplePort stands in a number for ${PORT} so a placeholder target can be checked
+// before the real port is known
+func withSamplePort(target string) string {
+	return strings.ReplaceAll(target, PortPlaceholder, "0")
+}
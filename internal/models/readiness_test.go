@@ -0,0 +1,87 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestReadinessProbeValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		probe   ReadinessProbe
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:  "valid log probe",
+			probe: ReadinessProbe{Type: ReadinessLog, Pattern: `listening on \d+`},
+		},
+		{
+			name:    "log probe without pattern",
+			probe:   ReadinessProbe{Type: ReadinessLog},
+			wantErr: true,
+			errMsg:  "requires a pattern",
+		},
+		{
+			name:    "log probe with invalid regex",
+			probe:   ReadinessProbe{Type: ReadinessLog, Pattern: "("},
+			wantErr: true,
+			errMsg:  "invalid readiness pattern",
+		},
+		{
+			name:  "valid tcp probe",
+			probe: ReadinessProbe{Type: ReadinessTCP, Address: "127.0.0.1:8080"},
+		},
+		{
+			name:    "tcp probe without port",
+			probe:   ReadinessProbe{Type: ReadinessTCP, Address: "localhost"},
+			wantErr: true,
+			errMsg:  "host:port",
+		},
+		{
+			name:  "valid http probe",
+			probe: ReadinessProbe{Type: ReadinessHTTP, URL: "http://localhost:8080/ready", Interval: 250},
+		},
+		{
+			name:  "tcp probe with port placeholder",
+			probe: ReadinessProbe{Type: ReadinessTCP, Address: "127.0.0.1:${PORT}"},
+		},
+		{
+			name:  "mcp probe with port placeholder",
+			probe: ReadinessProbe{Type: ReadinessMCP, URL: "http://localhost:${PORT}/mcp"},
+		},
+		{
+			name:    "mcp probe with relative URL",
+			probe:   ReadinessProbe{Type: ReadinessMCP, URL: "/mcp"},
+			wantErr: true,
+			errMsg:  "absolute http(s) URL",
+		},
+		{
+			name:    "negative interval",
+			probe:   ReadinessProbe{Type: ReadinessHTTP, URL: "http://localhost:8080", Interval: -1},
+			wantErr: true,
+			errMsg:  "interval",
+		},
+		{
+			name:    "unknown type",
+			probe:   ReadinessProbe{Type: "ping"},
+			wantErr: true,
+			errMsg:  "invalid readiness probe type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.probe.Validate()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got nil")
+				} else if tt.errMsg != "" && !contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing '%s', got '%s'", tt.errMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
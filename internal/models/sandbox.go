@@ -0,0 +1,32 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SandboxProfile confines a server launched by the manager (Linux only)
+// The server runs in its own user and mount namespaces with a private /tmp. Landlock limits
+// its filesystem access to the system directories, its command and working directories and
+// the paths listed here. Without Network it gets an empty network namespace
+type SandboxProfile struct {
+	ReadPaths  []string `json:"readPaths,omitempty"`  // Readable and executable, e.g. an interpreter outside /usr
+	WritePaths []string `json:"writePaths,omitempty"` // Readable, writable and executable
+	Network    bool     `json:"network"`              // Allow network access
+}
+
+// Validate checks that every path is absolute or relative to the home directory (~/)
+func (p *SandboxProfile) Validate() error {
+	for _, paths := range []struct {
+		field string
+		list  []string
+	}{{"readPaths", p.ReadPaths}, {"writePaths", p.WritePaths}} {
+		for _, path := range paths.list {
+			if path != "~" && !strings.HasPrefix(path, "~/") && !filepath.IsAbs(path) {
+				return fmt.Errorf("sandbox %s must be absolute or start with ~/, got: %s", paths.field, path)
+			}
+		}
+	}
+	return nil
+}
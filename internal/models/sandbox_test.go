@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSandboxProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *SandboxProfile
+		errMsg  string
+	}{
+		{"empty", &SandboxProfile{}, ""},
+		{"absolute and home paths", &SandboxProfile{ReadPaths: []string{"/opt/node", "~/.config/server"}, WritePaths: []string{"~"}, Network: true}, ""},
+		{"relative read path", &SandboxProfile{ReadPaths: []string{"data"}}, "sandbox readPaths must be absolute or start with ~/"},
+		{"other user's home", &SandboxProfile{WritePaths: []string{"~bob/data"}}, "sandbox writePaths must be absolute or start with ~/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
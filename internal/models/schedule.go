@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleAction is the lifecycle operation a schedule rule performs
+type ScheduleAction string
+
+const (
+	ScheduleStart   ScheduleAction = "start"
+	ScheduleStop    ScheduleAction = "stop"
+	ScheduleRestart ScheduleAction = "restart"
+)
+
+// ValidScheduleActions contains all valid schedule actions
+var ValidScheduleActions = []ScheduleAction{ScheduleStart, ScheduleStop, ScheduleRestart}
+
+// IsValid checks if the schedule action is valid
+func (a ScheduleAction) IsValid() bool {
+	for _, valid := range ValidScheduleActions {
+		if a == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduleRule runs a lifecycle action on a server or a group whenever its cron expression matches
+// Times are in the local time zone. Runs missed while the computer was asleep are reported;
+// with CatchUp the latest of them runs once the scheduler notices
+type ScheduleRule struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name,omitempty"`
+	Cron     string         `json:"cron"` // e.g. "0 9 * * mon-fri"; see CronSchedule
+	Action   ScheduleAction `json:"action"`
+	ServerID string         `json:"serverId,omitempty"`
+	Group    string         `json:"group,omitempty"`   // Group name; groups start and stop in dependency order
+	CatchUp  bool           `json:"catchUp,omitempty"` // Run the latest missed occurrence late rather than skip it
+	Disabled bool           `json:"disabled,omitempty"`
+}
+
+// Validate checks that the rule has an ID, a valid cron expression and action, and exactly one target
+func (r *ScheduleRule) Validate() error {
+	if strings.TrimSpace(r.ID) == "" {
+		return fmt.Errorf("schedule id cannot be empty")
+	}
+	if _, err := ParseCron(r.Cron); err != nil {
+		return fmt.Errorf("schedule %s: %w", r.ID, err)
+	}
+	if !r.Action.IsValid() {
+		return fmt.Errorf("schedule %s: invalid action: %q", r.ID, r.Action)
+	}
+	if (r.ServerID == "") == (r.Group == "") {
+		return fmt.Errorf("schedule %s: exactly one of serverId and group is required", r.ID)
+	}
+	if r.ServerID != "" {
+		if _, err := uuid.Parse(r.ServerID); err != nil {
+			return fmt.Errorf("schedule %s: serverId is not a valid UUID: %s", r.ID, r.ServerID)
+		}
+	}
+	return nil
+}
+
+// Describe returns a short description such as "nightly restart" or "restart of group tools"
+func (r *ScheduleRule) Describe() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	if r.Group != "" {
+		return fmt.Sprintf("%s of group %s", r.Action, r.Group)
+	}
+	return fmt.Sprintf("%s of server %s", r.Action, r.ServerID)
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScheduleRule_Validate(t *testing.T) {
+	serverID := "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+	tests := []struct {
+		name   string
+		rule   ScheduleRule
+		errMsg string
+	}{
+		{"server", ScheduleRule{ID: "nightly", Cron: "0 3 * * *", Action: ScheduleRestart, ServerID: serverID}, ""},
+		{"group", ScheduleRule{ID: "morning", Cron: "0 9 * * mon-fri", Action: ScheduleStart, Group: "paid"}, ""},
+		{"no id", ScheduleRule{Cron: "@daily", Action: ScheduleStart, Group: "paid"}, "schedule id cannot be empty"},
+		{"bad cron", ScheduleRule{ID: "x", Cron: "daily", Action: ScheduleStart, Group: "paid"}, "must have 5 fields"},
+		{"bad action", ScheduleRule{ID: "x", Cron: "@daily", Action: "pause", Group: "paid"}, "invalid action"},
+		{"no target", ScheduleRule{ID: "x", Cron: "@daily", Action: ScheduleStart}, "exactly one of serverId and group"},
+		{"both targets", ScheduleRule{ID: "x", Cron: "@daily", Action: ScheduleStart, ServerID: serverID, Group: "paid"}, "exactly one of serverId and group"},
+		{"server not a UUID", ScheduleRule{ID: "x", Cron: "@daily", Action: ScheduleStart, ServerID: "abc"}, "not a valid UUID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,6 +37,43 @@ type MCPServer struct {
 	DiscoveredAt     time.Time           `json:"discoveredAt"`
 	LastSeenAt       time.Time           `json:"lastSeenAt"`
 	Source           DiscoverySource     `json:"source"`
+	Client           string              `json:"client,omitempty"`         // Display name of the MCP client whose config defines the server
+	ConfigFile       string              `json:"configFile,omitempty"`     // Client config file that defines the server
+	ConfigSection    []string            `json:"configSection,omitempty"`  // Keys of the object in ConfigFile that holds the server, if not the client's root key
+	RequiredInputs   []ServerInput       `json:"requiredInputs,omitempty"` // Values the client prompts for, which MCP Manager cannot
+	Launcher         *ServerLauncher     `json:"launcher,omitempty"`       // Process that launched the running server, if known
+	Sandbox          *SandboxProfile     `json:"sandbox,omitempty"`        // Sandbox the running server was launched in, with ~ expanded
+	Port             *int                `json:"port,omitempty"`           // Port the running server listens on, if known
+}
+
+// ServerLauncher identifies the process that launched a server's process
+type ServerLauncher struct {
+	PID     int    `json:"pid"`
+	Name    string `json:"name,omitempty"`
+	Manager bool   `json:"manager"` // MCP Manager itself, which owns the server's pipes
+}
+
+// Describe returns a short description such as "Claude (PID 4242)"
+func (l *ServerLauncher) Describe() string {
+	if l.Name == "" {
+		return fmt.Sprintf("PID %d", l.PID)
+	}
+	return fmt.Sprintf("%s (PID %d)", l.Name, l.PID)
+}
+
+// ServerInput is a value an MCP client prompts the user for when it starts a server, such as
+// an API key referenced as ${input:api-key} in VS Code. MCP Manager cannot prompt for it, so
+// the server requires user input until the value is set in its configuration
+type ServerInput struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Password    bool     `json:"password,omitempty"`
+	Env         []string `json:"env,omitempty"` // Environment variables whose value references the input
+}
+
+// Placeholder returns the text that references the input, e.g. "${input:api-key}"
+func (i ServerInput) Placeholder() string {
+	return "${input:" + i.ID + "}"
 }
 
 // GenerateDeterministicUUID creates a stable UUID based on server identity
@@ -167,7 +205,49 @@ func (s *MCPServer) SetPID(pid int) {
 	s.PID = &pid
 }
 
-// ClearPID clears the process ID
+// ClearPID clears the process ID and the launcher, sandbox and port that came with the process
 func (s *MCPServer) ClearPID() {
 	s.PID = nil
+	s.Launcher = nil
+	s.Sandbox = nil
+	s.Port = nil
+}
+
+// MissingInputs returns the required inputs that have no value yet: those still referenced
+// by an environment variable or an argument, or taken by an environment variable that is
+// not set
+func (s *MCPServer) MissingInputs() []ServerInput {
+	var missing []ServerInput
+	for _, input := range s.RequiredInputs {
+		unset := false
+		for _, name := range input.Env {
+			value := s.Configuration.EnvironmentVariables[name]
+			if value == "" || strings.Contains(value, input.Placeholder()) {
+				unset = true
+			}
+		}
+		for _, arg := range s.Configuration.CommandLineArguments {
+			if strings.Contains(arg, input.Placeholder()) {
+				unset = true
+			}
+		}
+		if unset {
+			missing = append(missing, input)
+		}
+	}
+	return missing
+}
+
+// HealthCheckURL returns the URL the health checker probes, or "" if there is none
+// An endpoint that is only a path is resolved against the server's port on localhost,
+// so it has no URL until the port is known
+func (s *MCPServer) HealthCheckURL() string {
+	endpoint := s.Configuration.HealthCheckEndpoint
+	if !strings.HasPrefix(endpoint, "/") {
+		return endpoint
+	}
+	if s.Port == nil {
+		return ""
+	}
+	return fmt.Sprintf("http://localhost:%d%s", *s.Port, endpoint)
 }
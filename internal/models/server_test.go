@@ -234,6 +234,53 @@ func TestMCPServerHelperMethods(t *testing.T) {
 	}
 }
 
+func TestMCPServer_HealthCheckURL(t *testing.T) {
+	port := 8931
+	tests := []struct {
+		name     string
+		endpoint string
+		port     *int
+		want     string
+	}{
+		{"none", "", &port, ""},
+		{"absolute URL", "http://127.0.0.1:9000/health", &port, "http://127.0.0.1:9000/health"},
+		{"path on port", "/healthz", &port, "http://localhost:8931/healthz"},
+		{"path without port", "/healthz", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewMCPServer("http-server", "/path/to/server", DiscoveryClientConfig)
+			server.Configuration.HealthCheckEndpoint = tt.endpoint
+			server.Port = tt.port
+			if got := server.HealthCheckURL(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestServerConfiguration_HealthCheckEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		valid    bool
+	}{
+		{"http://localhost:8080/health", true},
+		{"/health", true},
+		{"health", false},
+		{"localhost:8080/health", false},
+	}
+
+	for _, tt := range tests {
+		cfg := NewServerConfiguration()
+		cfg.HealthCheckEndpoint = tt.endpoint
+		cfg.HealthCheckInterval = 30
+		if err := cfg.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Endpoint %q: expected valid=%v, got %v", tt.endpoint, tt.valid, err)
+		}
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || containsSubstring(s, substr)))
@@ -247,3 +294,28 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestMCPServer_MissingInputs(t *testing.T) {
+	server := NewMCPServer("vscode-server", "npx", DiscoveryVSCode)
+	server.Configuration.CommandLineArguments = []string{"--token", "${input:token}"}
+	server.Configuration.EnvironmentVariables["API_KEY"] = "Bearer ${input:api-key}"
+	server.RequiredInputs = []ServerInput{
+		{ID: "api-key", Password: true, Env: []string{"API_KEY"}},
+		{ID: "token"},
+	}
+
+	if missing := server.MissingInputs(); len(missing) != 2 {
+		t.Fatalf("Expected both inputs to be missing, got %+v", missing)
+	}
+
+	server.Configuration.EnvironmentVariables["API_KEY"] = "Bearer secret"
+	server.Configuration.CommandLineArguments[1] = "abc123"
+	if missing := server.MissingInputs(); len(missing) != 0 {
+		t.Errorf("Expected no missing inputs once the values are set, got %+v", missing)
+	}
+
+	delete(server.Configuration.EnvironmentVariables, "API_KEY")
+	if missing := server.MissingInputs(); len(missing) != 1 || missing[0].ID != "api-key" {
+		t.Errorf("Expected the unset variable's input to be missing, got %+v", missing)
+	}
+}
@@ -7,11 +7,36 @@ import (
 
 // ServerStatus represents the runtime status of an MCP server
 type ServerStatus struct {
-	State            StatusState `json:"state"`
-	StartupAttempts  int         `json:"startupAttempts"`
-	LastStateChange  time.Time   `json:"lastStateChange"`
-	ErrorMessage     string      `json:"errorMessage,omitempty"`
-	CrashRecoverable bool        `json:"crashRecoverable"`
+	State            StatusState  `json:"state"`
+	StartupAttempts  int          `json:"startupAttempts"`
+	LastStateChange  time.Time    `json:"lastStateChange"`
+	ErrorMessage     string       `json:"errorMessage,omitempty"`
+	CrashRecoverable bool         `json:"crashRecoverable"`
+	Quarantined      bool         `json:"quarantined,omitempty"` // Set by the crash supervisor after a crash loop
+	LastExit         *ProcessExit `json:"lastExit,omitempty"`    // How the most recent managed process ended
+}
+
+// ProcessExit records how a managed server process ended
+type ProcessExit struct {
+	ExitCode   int           `json:"exitCode"`         // -1 if terminated by a signal
+	Signal     string        `json:"signal,omitempty"` // e.g. "SIGKILL" (Unix only)
+	UserTime   time.Duration `json:"userTime"`         // User CPU time
+	SystemTime time.Duration `json:"systemTime"`       // System CPU time
+	MaxRSS     uint64        `json:"maxRss"`           // Peak resident set size in bytes
+	ExitedAt   time.Time     `json:"exitedAt"`
+}
+
+// Clean reports whether the process exited on its own with status 0
+func (e *ProcessExit) Clean() bool {
+	return e.ExitCode == 0 && e.Signal == ""
+}
+
+// Describe returns a short description such as "exit code 1" or "signal SIGKILL"
+func (e *ProcessExit) Describe() string {
+	if e.Signal != "" {
+		return fmt.Sprintf("signal %s", e.Signal)
+	}
+	return fmt.Sprintf("exit code %d", e.ExitCode)
 }
 
 // NewServerStatus creates a new ServerStatus in the stopped state
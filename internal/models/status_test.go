@@ -257,3 +257,24 @@ func TestErrorStateBehavior(t *testing.T) {
 		t.Error("Error message should be cleared when transitioning to stopped")
 	}
 }
+
+func TestProcessExit_Describe(t *testing.T) {
+	tests := []struct {
+		exit      ProcessExit
+		wantClean bool
+		want      string
+	}{
+		{ProcessExit{ExitCode: 0}, true, "exit code 0"},
+		{ProcessExit{ExitCode: 2}, false, "exit code 2"},
+		{ProcessExit{ExitCode: -1, Signal: "SIGKILL"}, false, "signal SIGKILL"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.exit.Describe(); got != tt.want {
+			t.Errorf("Describe() = %q, want %q", got, tt.want)
+		}
+		if got := tt.exit.Clean(); got != tt.wantClean {
+			t.Errorf("Clean() for %s = %v, want %v", tt.want, got, tt.wantClean)
+		}
+	}
+}
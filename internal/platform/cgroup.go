@@ -0,0 +1,42 @@
+package platform
+
+import "time"
+
+// cgroupSliceName is the directory under the delegated cgroup subtree that holds
+// one sub-group per managed server
+const cgroupSliceName = "mcpmanager.slice"
+
+// ResourceLimits caps the resources of a launched process and all of its descendants
+// A zero field leaves that resource unlimited
+type ResourceLimits struct {
+	MemoryMax       uint64 // bytes
+	CPUQuotaPercent int    // percent of one CPU, e.g. 150 = one and a half CPUs
+	PidsMax         int    // maximum number of processes and threads
+}
+
+// IsZero returns true if no limit is set
+func (l ResourceLimits) IsZero() bool {
+	return l.MemoryMax == 0 && l.CPUQuotaPercent == 0 && l.PidsMax == 0
+}
+
+// CgroupStats is the resource accounting of a cgroup, covering every process in it
+type CgroupStats struct {
+	MemoryBytes  uint64        // memory.current
+	CPUTime      time.Duration // usage_usec from cpu.stat
+	ProcessCount int           // pids.current
+}
+
+// CgroupManager places managed servers in their own cgroup v2 sub-groups, so that
+// limits and accounting cover every process a server starts. Sub-groups live in
+// mcpmanager.slice under the user's delegated subtree (user@UID.service)
+// When cgroups cannot be used, Available reports why and Create always fails
+type CgroupManager struct {
+	root        string          // mcpmanager.slice directory; empty when unavailable
+	controllers map[string]bool // Controllers enabled for the server sub-groups
+	reason      string          // Why cgroups are unavailable
+}
+
+// Available reports whether servers can be placed in cgroups, and if not, why
+func (m *CgroupManager) Available() (bool, string) {
+	return m.root != "", m.reason
+}
@@ -0,0 +1,267 @@
+package platform
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// cgroupMount is where the unified cgroup v2 hierarchy is mounted
+	cgroupMount = "/sys/fs/cgroup"
+
+	// cpuPeriod is the cpu.max period in microseconds
+	cpuPeriod = 100000
+
+	// cgroupRemoveWait bounds how long Remove waits for killed processes to leave
+	cgroupRemoveWait = time.Second
+)
+
+// cgroupControllers are the controllers enabled for server sub-groups, one per kind of limit
+var cgroupControllers = []string{"memory", "cpu", "pids"}
+
+// NewCgroupManager finds the delegated cgroup subtree of the current user and
+// prepares mcpmanager.slice in it
+func NewCgroupManager() *CgroupManager {
+	return newCgroupManager(cgroupMount, "/proc/self/cgroup", os.Getuid())
+}
+
+// newCgroupManager prepares a CgroupManager for the given hierarchy and membership file
+func newCgroupManager(mount, selfCgroup string, uid int) *CgroupManager {
+	m := &CgroupManager{controllers: make(map[string]bool)}
+
+	if _, err := os.Stat(filepath.Join(mount, "cgroup.controllers")); err != nil {
+		m.reason = fmt.Sprintf("cgroup v2 is not mounted at %s", mount)
+		return m
+	}
+
+	self, err := readCgroupPath(selfCgroup)
+	if err != nil {
+		m.reason = err.Error()
+		return m
+	}
+
+	// The user's service manager owns a subtree delegated to the user
+	delegated := ""
+	marker := fmt.Sprintf("user@%d.service", uid)
+	parts := strings.Split(strings.Trim(self, "/"), "/")
+	for i, part := range parts {
+		if part == marker {
+			delegated = filepath.Join(append([]string{mount}, parts[:i+1]...)...)
+			break
+		}
+	}
+	if delegated == "" {
+		m.reason = fmt.Sprintf("MCP Manager is not running in a delegated cgroup (%s)", marker)
+		return m
+	}
+
+	root := filepath.Join(delegated, cgroupSliceName)
+	if err := os.Mkdir(root, 0o755); err != nil && !errors.Is(err, os.ErrExist) {
+		m.reason = fmt.Sprintf("cannot create %s: %v", root, err)
+		return m
+	}
+
+	// Enable each controller for the server sub-groups as far as the parent delegates it
+	available, _ := os.ReadFile(filepath.Join(root, "cgroup.controllers"))
+	for _, controller := range strings.Fields(string(available)) {
+		for _, wanted := range cgroupControllers {
+			if controller != wanted {
+				continue
+			}
+			if err := os.WriteFile(filepath.Join(root, "cgroup.subtree_control"), []byte("+"+controller), 0o644); err == nil {
+				m.controllers[controller] = true
+			}
+		}
+	}
+
+	m.root = root
+	return m
+}
+
+// readCgroupPath returns the cgroup v2 path from a /proc/[pid]/cgroup file
+func readCgroupPath(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read cgroup membership: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// The unified hierarchy is listed as "0::/path"
+		if path, ok := strings.CutPrefix(scanner.Text(), "0::"); ok {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no cgroup v2 membership in %s", file)
+}
+
+// Create prepares the sub-group name with the given limits and returns its directory
+// An existing sub-group is reused and its limits rewritten. Fails if a limit needs a
+// controller that is not delegated, so the caller can launch without a cgroup instead
+func (m *CgroupManager) Create(name string, limits ResourceLimits) (string, error) {
+	if m.root == "" {
+		return "", fmt.Errorf("cgroups unavailable: %s", m.reason)
+	}
+
+	required := map[string]bool{
+		"memory": limits.MemoryMax > 0,
+		"cpu":    limits.CPUQuotaPercent > 0,
+		"pids":   limits.PidsMax > 0,
+	}
+	for _, controller := range cgroupControllers {
+		if required[controller] && !m.controllers[controller] {
+			return "", fmt.Errorf("the %s controller is not delegated to %s", controller, m.root)
+		}
+	}
+
+	dir := filepath.Join(m.root, name)
+	if err := os.Mkdir(dir, 0o755); err != nil && !errors.Is(err, os.ErrExist) {
+		return "", fmt.Errorf("failed to create cgroup: %w", err)
+	}
+
+	// "max" clears a limit left from an earlier run
+	settings := map[string]string{
+		"memory": "memory.max",
+		"cpu":    "cpu.max",
+		"pids":   "pids.max",
+	}
+	values := map[string]string{
+		"memory": "max",
+		"cpu":    fmt.Sprintf("max %d", cpuPeriod),
+		"pids":   "max",
+	}
+	if limits.MemoryMax > 0 {
+		values["memory"] = strconv.FormatUint(limits.MemoryMax, 10)
+	}
+	if limits.CPUQuotaPercent > 0 {
+		values["cpu"] = fmt.Sprintf("%d %d", limits.CPUQuotaPercent*cpuPeriod/100, cpuPeriod)
+	}
+	if limits.PidsMax > 0 {
+		values["pids"] = strconv.Itoa(limits.PidsMax)
+	}
+
+	for _, controller := range cgroupControllers {
+		if !m.controllers[controller] {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, settings[controller]), []byte(values[controller]), 0o644); err != nil {
+			return "", fmt.Errorf("failed to set %s: %w", settings[controller], err)
+		}
+	}
+
+	return dir, nil
+}
+
+// Remove kills any process left in the sub-group name and deletes it
+func (m *CgroupManager) Remove(name string) error {
+	if m.root == "" {
+		return nil
+	}
+
+	dir := filepath.Join(m.root, name)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	// cgroup.kill (Linux 5.14+) SIGKILLs every process in the group
+	os.WriteFile(filepath.Join(dir, "cgroup.kill"), []byte("1"), 0o644)
+
+	deadline := time.Now().Add(cgroupRemoveWait)
+	for {
+		err := os.Remove(dir)
+		if err == nil || errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("failed to remove cgroup %s: %w", dir, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// ReadCgroupStats returns the accounting of the cgroup MCP Manager placed pid in
+// Returns false if pid is not in a sub-group of mcpmanager.slice
+func ReadCgroupStats(pid int) (*CgroupStats, bool) {
+	path, err := readCgroupPath(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil || !strings.Contains(path, "/"+cgroupSliceName+"/") {
+		return nil, false
+	}
+
+	stats, err := readCgroupStats(filepath.Join(cgroupMount, path))
+	if err != nil {
+		return nil, false
+	}
+	return stats, true
+}
+
+// readCgroupStats reads memory, CPU and process accounting from a cgroup directory
+// Files of controllers that are not enabled are skipped
+func readCgroupStats(dir string) (*CgroupStats, error) {
+	stats := &CgroupStats{}
+	found := false
+
+	if value, err := readCgroupValue(filepath.Join(dir, "memory.current")); err == nil {
+		stats.MemoryBytes = value
+		found = true
+	}
+	if value, err := readCgroupValue(filepath.Join(dir, "pids.current")); err == nil {
+		stats.ProcessCount = int(value)
+		found = true
+	}
+
+	// cpu.stat is present even without the cpu controller
+	if data, err := os.ReadFile(filepath.Join(dir, "cpu.stat")); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if usec, ok := strings.CutPrefix(line, "usage_usec "); ok {
+				if value, err := strconv.ParseUint(strings.TrimSpace(usec), 10, 64); err == nil {
+					stats.CPUTime = time.Duration(value) * time.Microsecond
+					found = true
+				}
+			}
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no accounting files in %s", dir)
+	}
+	return stats, nil
+}
+
+// readCgroupValue reads a single-number cgroup file
+func readCgroupValue(file string) (uint64, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// placeInCgroup makes command start directly inside the cgroup directory dir, so
+// no child it forks early can escape the limits. Call release once the command started
+func placeInCgroup(command *exec.Cmd, dir string) (release func(), err error) {
+	if dir == "" {
+		return func() {}, nil
+	}
+
+	fd, err := syscall.Open(dir, syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cgroup %s: %w", dir, err)
+	}
+
+	if command.SysProcAttr == nil {
+		command.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	command.SysProcAttr.UseCgroupFD = true
+	command.SysProcAttr.CgroupFD = fd
+
+	return func() { syscall.Close(fd) }, nil
+}
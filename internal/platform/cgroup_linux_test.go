@@ -0,0 +1,135 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeCgroupTree lays out a cgroup v2 hierarchy in a temporary directory
+// The manager runs in app.scope under the delegated subtree of uid 1000
+func fakeCgroupTree(t *testing.T, sliceControllers string) (mount, selfCgroup string) {
+	t.Helper()
+	mount = t.TempDir()
+	self := "/user.slice/user-1000.slice/user@1000.service/app.slice/app.scope"
+
+	if err := os.WriteFile(filepath.Join(mount, "cgroup.controllers"), []byte("cpu memory pids"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(mount, self), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// The kernel fills cgroup.controllers of a new group; pre-create the slice to stand in
+	slice := filepath.Join(mount, "user.slice/user-1000.slice/user@1000.service", cgroupSliceName)
+	if err := os.MkdirAll(slice, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(slice, "cgroup.controllers"), []byte(sliceControllers), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	selfCgroup = filepath.Join(t.TempDir(), "cgroup")
+	if err := os.WriteFile(selfCgroup, []byte("0::"+self+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return mount, selfCgroup
+}
+
+func TestCgroupManager_Create(t *testing.T) {
+	mount, selfCgroup := fakeCgroupTree(t, "memory pids")
+	m := newCgroupManager(mount, selfCgroup, 1000)
+
+	if available, reason := m.Available(); !available {
+		t.Fatalf("Expected cgroups to be available, got: %s", reason)
+	}
+
+	dir, err := m.Create("server-a", ResourceLimits{MemoryMax: 512 * 1024 * 1024, PidsMax: 64})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if !strings.HasSuffix(dir, filepath.Join("user@1000.service", cgroupSliceName, "server-a")) {
+		t.Errorf("Expected the sub-group under the delegated slice, got %s", dir)
+	}
+
+	want := map[string]string{"memory.max": "536870912", "pids.max": "64"}
+	for file, value := range want {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil || string(data) != value {
+			t.Errorf("Expected %s = %s, got %q (%v)", file, value, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cpu.max")); err == nil {
+		t.Error("cpu.max should not be written without the cpu controller")
+	}
+
+	// A limit whose controller is not delegated cannot be enforced
+	if _, err := m.Create("server-b", ResourceLimits{CPUQuotaPercent: 50}); err == nil || !strings.Contains(err.Error(), "cpu controller") {
+		t.Errorf("Expected the missing cpu controller to be reported, got %v", err)
+	}
+}
+
+func TestCgroupManager_Create_CPUQuota(t *testing.T) {
+	mount, selfCgroup := fakeCgroupTree(t, "cpu memory pids")
+	m := newCgroupManager(mount, selfCgroup, 1000)
+
+	dir, err := m.Create("server-a", ResourceLimits{CPUQuotaPercent: 150})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "cpu.max"))
+	if string(data) != "150000 100000" {
+		t.Errorf("Expected cpu.max for 1.5 CPUs, got %q", data)
+	}
+
+	// Unset limits are cleared when a sub-group is reused
+	data, _ = os.ReadFile(filepath.Join(dir, "memory.max"))
+	if string(data) != "max" {
+		t.Errorf("Expected memory.max to be unlimited, got %q", data)
+	}
+}
+
+func TestCgroupManager_Unavailable(t *testing.T) {
+	// No cgroup v2 hierarchy
+	m := newCgroupManager(t.TempDir(), "/nonexistent", 1000)
+	if available, reason := m.Available(); available || !strings.Contains(reason, "not mounted") {
+		t.Errorf("Expected cgroup v2 to be reported missing, got %v %q", available, reason)
+	}
+	if _, err := m.Create("server-a", ResourceLimits{}); err == nil {
+		t.Error("Create should fail when cgroups are unavailable")
+	}
+
+	// Not under a delegated subtree
+	mount, selfCgroup := fakeCgroupTree(t, "memory")
+	os.WriteFile(selfCgroup, []byte("0::/system.slice/other.service\n"), 0o644)
+	m = newCgroupManager(mount, selfCgroup, 1000)
+	if available, reason := m.Available(); available || !strings.Contains(reason, "user@1000.service") {
+		t.Errorf("Expected missing delegation to be reported, got %v %q", available, reason)
+	}
+}
+
+func TestReadCgroupStats(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "memory.current"), []byte("1048576\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "pids.current"), []byte("3\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "cpu.stat"), []byte("usage_usec 2500000\nuser_usec 2000000\nsystem_usec 500000\n"), 0o644)
+
+	stats, err := readCgroupStats(dir)
+	if err != nil {
+		t.Fatalf("readCgroupStats failed: %v", err)
+	}
+	if stats.MemoryBytes != 1048576 || stats.ProcessCount != 3 || stats.CPUTime != 2500*time.Millisecond {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	if _, err := readCgroupStats(t.TempDir()); err == nil {
+		t.Error("Expected an error for a directory without accounting files")
+	}
+
+	// Processes outside mcpmanager.slice are not reported
+	if _, ok := ReadCgroupStats(os.Getpid()); ok {
+		t.Error("The test process is not in a managed cgroup")
+	}
+}
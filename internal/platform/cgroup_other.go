@@ -0,0 +1,36 @@
+//go:build !linux
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// NewCgroupManager returns a CgroupManager that reports cgroups as unavailable
+func NewCgroupManager() *CgroupManager {
+	return &CgroupManager{reason: "cgroups are only available on Linux"}
+}
+
+// Create always fails outside Linux
+func (m *CgroupManager) Create(name string, limits ResourceLimits) (string, error) {
+	return "", fmt.Errorf("cgroups unavailable: %s", m.reason)
+}
+
+// Remove does nothing outside Linux
+func (m *CgroupManager) Remove(name string) error {
+	return nil
+}
+
+// ReadCgroupStats always returns false outside Linux
+func ReadCgroupStats(pid int) (*CgroupStats, bool) {
+	return nil, false
+}
+
+// placeInCgroup rejects a cgroup outside Linux
+func placeInCgroup(command *exec.Cmd, dir string) (release func(), err error) {
+	if dir != "" {
+		return nil, fmt.Errorf("cgroups are only available on Linux")
+	}
+	return func() {}, nil
+}
@@ -0,0 +1,16 @@
+package platform
+
+import "runtime"
+
+// ClientInheritedEnv returns the variables MCP clients pass on to the servers they launch
+// This is the default inherited environment of the MCP SDKs, used by clients such as Claude
+// Desktop; everything else a server needs must be set in its configuration
+func ClientInheritedEnv() []string {
+	if runtime.GOOS == "windows" {
+		return []string{
+			"APPDATA", "HOMEDRIVE", "HOMEPATH", "LOCALAPPDATA", "PATH", "PROCESSOR_ARCHITECTURE",
+			"SYSTEMDRIVE", "SYSTEMROOT", "TEMP", "USERNAME", "USERPROFILE", "PROGRAMFILES",
+		}
+	}
+	return []string{"HOME", "LOGNAME", "PATH", "SHELL", "TERM", "USER"}
+}
@@ -0,0 +1,59 @@
+//go:build !windows
+
+package platform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// loginShellTimeout bounds how long the user's shell startup files may take
+	loginShellTimeout = 10 * time.Second
+
+	// loginShellMarker delimits PATH in the shell's output, which startup files may add to
+	loginShellMarker = "__MCPMANAGER_PATH__"
+)
+
+// LoginShellPath returns the PATH the user's login shell sets up from a fresh environment
+// The shell runs interactively as well, as terminals do, so PATH changes made in files such
+// as ~/.zshrc are included
+func LoginShellPath() (string, error) {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), loginShellTimeout)
+	defer cancel()
+
+	script := fmt.Sprintf(`printf '%s%%s%s' "$PATH"`, loginShellMarker, loginShellMarker)
+	command := exec.CommandContext(ctx, shell, "-l", "-i", "-c", script)
+	command.Env = []string{
+		"HOME=" + os.Getenv("HOME"),
+		"USER=" + os.Getenv("USER"),
+		"LOGNAME=" + os.Getenv("LOGNAME"),
+		"SHELL=" + shell,
+		"TERM=dumb",
+		"PATH=/usr/bin:/bin:/usr/sbin:/sbin",
+	}
+	var stdout bytes.Buffer
+	command.Stdout = &stdout
+	setProcAttributes(command, ProcessGroupSession)
+
+	if err := command.Run(); err != nil && ctx.Err() != nil {
+		return "", fmt.Errorf("login shell %s did not finish within %s", shell, loginShellTimeout)
+	}
+
+	_, rest, found := strings.Cut(stdout.String(), loginShellMarker)
+	path, _, closed := strings.Cut(rest, loginShellMarker)
+	if !found || !closed || path == "" {
+		return "", fmt.Errorf("login shell %s did not report a PATH", shell)
+	}
+	return path, nil
+}
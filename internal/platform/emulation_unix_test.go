@@ -0,0 +1,25 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestLoginShellPath(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	t.Setenv("SHELL", "/bin/sh")
+	t.Setenv("PATH", "/manager/only/bin")
+
+	path, err := LoginShellPath()
+	if err != nil {
+		t.Fatalf("LoginShellPath failed: %v", err)
+	}
+	if path == "" || strings.Contains(path, "/manager/only/bin") {
+		t.Errorf("Expected a PATH built from a fresh login, got %q", path)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package platform
+
+import "fmt"
+
+// LoginShellPath is not supported on Windows, where clients and terminals share the user's PATH
+func LoginShellPath() (string, error) {
+	return "", fmt.Errorf("login-shell PATH is not supported on Windows")
+}
@@ -0,0 +1,55 @@
+package platform
+
+import (
+	"os"
+	"os/exec"
+	"time"
+)
+
+// ExitInfo describes how a waited-for process ended
+type ExitInfo struct {
+	ExitCode   int           `json:"exitCode"`         // -1 if the process was terminated by a signal
+	Signal     string        `json:"signal,omitempty"` // Terminating signal, e.g. "SIGKILL" (Unix only)
+	UserTime   time.Duration `json:"userTime"`         // User CPU time of the process
+	SystemTime time.Duration `json:"systemTime"`       // System CPU time of the process
+	MaxRSS     uint64        `json:"maxRss"`           // Peak resident set size in bytes (0 where unavailable)
+	ExitedAt   time.Time     `json:"exitedAt"`
+	Error      string        `json:"error,omitempty"` // Wait failure, if the status could not be collected
+}
+
+// newExitInfo extracts exit details from a finished process
+func newExitInfo(state *os.ProcessState, waitErr error) *ExitInfo {
+	info := &ExitInfo{
+		ExitCode: -1,
+		ExitedAt: time.Now(),
+	}
+
+	if state == nil {
+		if waitErr != nil {
+			info.Error = waitErr.Error()
+		}
+		return info
+	}
+
+	info.ExitCode = state.ExitCode()
+	info.Signal = exitSignal(state)
+	info.UserTime = state.UserTime()
+	info.SystemTime = state.SystemTime()
+	info.MaxRSS = maxRSS(state)
+
+	return info
+}
+
+// waitInBackground reaps the process so it never lingers as a zombie
+// The returned channel is closed once the process has exited and *exit is set
+func waitInBackground(command *exec.Cmd, exit **ExitInfo) <-chan struct{} {
+	exited := make(chan struct{})
+
+	go func() {
+		err := command.Wait()
+		*exit = newExitInfo(command.ProcessState, err)
+		close(exited)
+	}()
+
+	return exited
+}
@@ -0,0 +1,77 @@
+package platform
+
+import (
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitExit waits for a managed process to be reaped and returns its exit information
+func waitExit(t *testing.T, proc *ManagedProcess) *ExitInfo {
+	t.Helper()
+
+	select {
+	case <-proc.Exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Process was not reaped")
+	}
+
+	exit := proc.ExitInfo()
+	if exit == nil {
+		t.Fatal("Expected exit information after Exited is closed")
+	}
+	return exit
+}
+
+func TestStartWithOutput_ExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Uses a POSIX shell")
+	}
+
+	pm := NewProcessManager()
+	proc, err := pm.StartWithOutput(LaunchSpec{Command: "sh", Args: []string{"-c", "echo last words; exit 3"}})
+	if err != nil {
+		t.Fatalf("StartWithOutput failed: %v", err)
+	}
+
+	exit := waitExit(t, proc)
+	if exit.ExitCode != 3 || exit.Signal != "" {
+		t.Errorf("Expected exit code 3 without signal, got %d %q", exit.ExitCode, exit.Signal)
+	}
+
+	// Reaping the process must not discard output that was not read yet
+	output, _ := io.ReadAll(proc.Stdout)
+	if strings.TrimSpace(string(output)) != "last words" {
+		t.Errorf("Expected output to survive the exit, got %q", output)
+	}
+}
+
+func TestStartWithOutput_ExitSignalAndUsage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Uses a POSIX shell and signals")
+	}
+
+	// Burn some CPU so the resource usage is measurable, then die by SIGKILL
+	script := `i=0; while [ $i -lt 200000 ]; do i=$((i+1)); done; kill -9 $$`
+	pm := NewProcessManager()
+	proc, err := pm.StartWithOutput(LaunchSpec{Command: "sh", Args: []string{"-c", script}})
+	if err != nil {
+		t.Fatalf("StartWithOutput failed: %v", err)
+	}
+
+	exit := waitExit(t, proc)
+	if exit.Signal != "SIGKILL" || exit.ExitCode != -1 {
+		t.Errorf("Expected SIGKILL with exit code -1, got %d %q", exit.ExitCode, exit.Signal)
+	}
+	if exit.UserTime+exit.SystemTime <= 0 {
+		t.Error("Expected non-zero CPU time")
+	}
+	if exit.MaxRSS == 0 {
+		t.Error("Expected non-zero max RSS")
+	}
+	if pm.IsRunning(proc.PID) {
+		t.Error("Reaped process should not be reported as running")
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// exitSignal returns the name of the signal that terminated the process, if any
+func exitSignal(state *os.ProcessState) string {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return ""
+	}
+	return unix.SignalName(status.Signal())
+}
+
+// maxRSS returns the peak resident set size in bytes
+// Linux reports ru_maxrss in kilobytes, macOS in bytes
+func maxRSS(state *os.ProcessState) uint64 {
+	usage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || usage.Maxrss <= 0 {
+		return 0
+	}
+	if runtime.GOOS == "darwin" {
+		return uint64(usage.Maxrss)
+	}
+	return uint64(usage.Maxrss) * 1024
+}
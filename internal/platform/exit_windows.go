@@ -0,0 +1,15 @@
+//go:build windows
+
+package platform
+
+import "os"
+
+// exitSignal returns "" on Windows, where processes are not ended by signals
+func exitSignal(state *os.ProcessState) string {
+	return ""
+}
+
+// maxRSS returns 0 on Windows; the wait status carries no memory accounting
+func maxRSS(state *os.ProcessState) uint64 {
+	return 0
+}
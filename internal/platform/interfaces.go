@@ -1,7 +1,5 @@
 package platform
 
-import "io"
-
 // PathResolver provides platform-specific path resolution
 type PathResolver interface {
 	// GetConfigDir returns the platform-specific configuration directory
@@ -18,19 +16,19 @@ type PathResolver interface {
 
 // ProcessManager handles process lifecycle operations
 type ProcessManager interface {
-	// Start launches a new process with the given command, arguments, and environment
+	// Start launches a new process described by spec
 	// Returns the process ID on success
-	Start(cmd string, args []string, env map[string]string) (pid int, err error)
+	Start(spec LaunchSpec) (pid int, err error)
 
-	// StartWithOutput launches a new process and returns stdout/stderr readers
-	// Returns the process ID and readers for stdout and stderr
-	// The readers will be closed when the process exits
-	StartWithOutput(cmd string, args []string, env map[string]string) (pid int, stdout, stderr io.ReadCloser, err error)
+	// StartWithOutput launches a new process described by spec and returns its stdio pipes
+	// The stdout/stderr readers will be closed when the process exits
+	StartWithOutput(spec LaunchSpec) (*ManagedProcess, error)
 
-	// Stop terminates a process by its ID
+	// Stop terminates a process and all of its descendants
 	// If graceful is true, attempts graceful shutdown before forcing termination
 	// timeout specifies how long to wait for graceful shutdown (in seconds)
-	Stop(pid int, graceful bool, timeout int) error
+	// The report lists every process found in the tree and how it ended
+	Stop(pid int, graceful bool, timeout int) (*StopReport, error)
 
 	// IsRunning checks if a process with the given ID is currently running
 	IsRunning(pid int) bool
@@ -0,0 +1,234 @@
+package platform
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// EnvMode controls which variables of the manager's environment a process inherits
+type EnvMode string
+
+const (
+	EnvInherit   EnvMode = "inherit"   // Full parent environment, overridden by Env (default)
+	EnvClean     EnvMode = "clean"     // Only Env
+	EnvAllowlist EnvMode = "allowlist" // Parent variables named in EnvAllowlist, overridden by Env
+)
+
+// StdinMode controls what a process reads on standard input
+type StdinMode string
+
+const (
+	StdinNull StdinMode = "null" // Null device, reads return EOF immediately (default)
+	StdinPipe StdinMode = "pipe" // A pipe held by the caller via ManagedProcess.Stdin
+)
+
+// ProcessGroupMode controls how a process is grouped relative to the manager
+type ProcessGroupMode string
+
+const (
+	ProcessGroupNew     ProcessGroupMode = "new"     // Own process group, so signals reach its children (default)
+	ProcessGroupInherit ProcessGroupMode = "inherit" // Stay in the manager's process group
+	ProcessGroupSession ProcessGroupMode = "session" // New session, detached from the manager's terminal (Unix)
+)
+
+// LaunchSpec describes how to launch a process
+// The zero value of every option selects the previous default behavior
+type LaunchSpec struct {
+	Command      string            // Executable path or name looked up in PATH
+	Args         []string          // Arguments, excluding the command itself
+	Dir          string            // Working directory; relative commands resolve against it. Empty = manager's cwd
+	Env          map[string]string // Variables added on top of the inherited environment
+	EnvMode      EnvMode           // Inheritance mode, empty = EnvInherit
+	EnvAllowlist []string          // Parent variable names kept in EnvAllowlist mode
+	ExtraPath    []string          // Directories prepended to PATH (also used to find Command)
+	Umask        *uint32           // File mode creation mask for the process (Unix only), nil = inherit
+	Stdin        StdinMode         // Standard input handling, empty = StdinNull
+	ProcessGroup ProcessGroupMode  // Process group placement, empty = ProcessGroupNew
+	Cgroup       string            // cgroup v2 directory to start the process in (Linux only), empty = the manager's
+	Sandbox      *SandboxSpec      // Namespace and Landlock confinement (Linux only), nil = unconfined
+	OutputFile   string            // File receiving stdout and stderr instead of pipes (StartWithOutput only), empty = pipes
+}
+
+// ManagedProcess is a process launched from a LaunchSpec
+// The process manager waits for it, so its exit is observed without polling
+type ManagedProcess struct {
+	PID    int
+	Stdin  io.WriteCloser // Non-nil only for StdinPipe; closing it signals EOF to the process
+	Stdout io.ReadCloser
+	Stderr io.ReadCloser
+	Exited <-chan struct{} // Closed once the process has exited and been reaped; nil if unknown
+	Exit   *ExitInfo       // Set before Exited is closed
+
+	// OutputFile is the file the process writes its output to, if not pipes; Stdout follows it
+	// A process without pipes keeps running when the manager lets go of it
+	OutputFile string
+}
+
+// ExitInfo returns how the process ended, or nil if it has not exited yet
+func (p *ManagedProcess) ExitInfo() *ExitInfo {
+	if p.Exited == nil {
+		return nil
+	}
+	select {
+	case <-p.Exited:
+		return p.Exit
+	default:
+		return nil
+	}
+}
+
+// Validate checks if the LaunchSpec is valid
+func (s *LaunchSpec) Validate() error {
+	if s.Command == "" {
+		return fmt.Errorf("launch command cannot be empty")
+	}
+
+	switch s.EnvMode {
+	case "", EnvInherit, EnvClean, EnvAllowlist:
+	default:
+		return fmt.Errorf("invalid environment mode: %s", s.EnvMode)
+	}
+
+	switch s.Stdin {
+	case "", StdinNull, StdinPipe:
+	default:
+		return fmt.Errorf("invalid stdin mode: %s", s.Stdin)
+	}
+
+	switch s.ProcessGroup {
+	case "", ProcessGroupNew, ProcessGroupInherit, ProcessGroupSession:
+	default:
+		return fmt.Errorf("invalid process group mode: %s", s.ProcessGroup)
+	}
+
+	if s.Umask != nil && *s.Umask > 0o777 {
+		return fmt.Errorf("invalid umask: %o", *s.Umask)
+	}
+
+	if s.Sandbox != nil {
+		if available, reason := SandboxSupport(); !available {
+			return fmt.Errorf("sandbox unavailable: %s", reason)
+		}
+	}
+
+	if s.Dir != "" {
+		info, err := os.Stat(s.Dir)
+		if err != nil {
+			return fmt.Errorf("cannot access working directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("working directory is not a directory: %s", s.Dir)
+		}
+	}
+
+	return nil
+}
+
+// buildCommand creates an exec.Cmd for the spec without starting it
+func buildCommand(spec LaunchSpec) (*exec.Cmd, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	target := resolveCommand(spec.Command, spec.ExtraPath)
+	path, args := wrapUmask(target, spec.Args, spec.Umask)
+
+	command := exec.Command(path, args...)
+	command.Dir = spec.Dir
+	command.Env = buildEnv(spec, os.Environ())
+
+	// Platform-specific process group settings
+	setProcAttributes(command, spec.ProcessGroup)
+
+	if spec.Sandbox != nil {
+		if err := applySandbox(command, spec.Sandbox, target); err != nil {
+			return nil, err
+		}
+	}
+
+	return command, nil
+}
+
+// resolveCommand looks up a bare command name in the extra PATH entries first
+// Names that are not found there are left for exec to resolve against the manager's PATH
+func resolveCommand(name string, extraPath []string) string {
+	if strings.ContainsAny(name, `/\`) {
+		return name
+	}
+
+	for _, dir := range extraPath {
+		if path, err := exec.LookPath(filepath.Join(dir, name)); err == nil {
+			return path
+		}
+	}
+
+	return name
+}
+
+// buildEnv computes the environment for a spec from the parent environment
+func buildEnv(spec LaunchSpec, parent []string) []string {
+	var env []string
+
+	switch spec.EnvMode {
+	case EnvClean:
+		// Nothing inherited
+	case EnvAllowlist:
+		for _, entry := range parent {
+			key, _, _ := strings.Cut(entry, "=")
+			for _, allowed := range spec.EnvAllowlist {
+				if envKeyEqual(key, allowed) {
+					env = append(env, entry)
+					break
+				}
+			}
+		}
+	default:
+		env = append(env, parent...)
+	}
+
+	for key, value := range spec.Env {
+		env = setEnv(env, key, value)
+	}
+
+	if len(spec.ExtraPath) > 0 {
+		pathKey := "PATH"
+		pathValue := ""
+		for _, entry := range env {
+			if key, value, _ := strings.Cut(entry, "="); envKeyEqual(key, "PATH") {
+				pathKey, pathValue = key, value
+			}
+		}
+
+		entries := append([]string{}, spec.ExtraPath...)
+		if pathValue != "" {
+			entries = append(entries, pathValue)
+		}
+		env = setEnv(env, pathKey, strings.Join(entries, string(os.PathListSeparator)))
+	}
+
+	return env
+}
+
+// setEnv replaces an existing variable or appends a new one
+func setEnv(env []string, key, value string) []string {
+	for i, entry := range env {
+		if existing, _, _ := strings.Cut(entry, "="); envKeyEqual(existing, key) {
+			env[i] = key + "=" + value
+			return env
+		}
+	}
+	return append(env, key+"="+value)
+}
+
+// envKeyEqual compares variable names (case-insensitive on Windows)
+func envKeyEqual(a, b string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
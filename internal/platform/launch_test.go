@@ -0,0 +1,159 @@
+package platform
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// envValue returns the value of key in env, or "" if it is not set
+func envValue(env []string, key string) (string, bool) {
+	for _, entry := range env {
+		if k, v, _ := strings.Cut(entry, "="); envKeyEqual(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func TestBuildEnv(t *testing.T) {
+	parent := []string{"PATH=/usr/bin", "HOME=/home/user", "SECRET=token"}
+
+	t.Run("inherit", func(t *testing.T) {
+		env := buildEnv(LaunchSpec{Env: map[string]string{"SECRET": "override", "EXTRA": "1"}}, parent)
+
+		if v, _ := envValue(env, "HOME"); v != "/home/user" {
+			t.Errorf("Expected HOME to be inherited, got %q", v)
+		}
+		if v, _ := envValue(env, "SECRET"); v != "override" {
+			t.Errorf("Expected SECRET to be overridden, got %q", v)
+		}
+		if v, _ := envValue(env, "EXTRA"); v != "1" {
+			t.Errorf("Expected EXTRA to be added, got %q", v)
+		}
+		if len(env) != 4 {
+			t.Errorf("Overrides should replace entries rather than duplicate them, got %v", env)
+		}
+	})
+
+	t.Run("clean", func(t *testing.T) {
+		env := buildEnv(LaunchSpec{EnvMode: EnvClean, Env: map[string]string{"EXTRA": "1"}}, parent)
+
+		if len(env) != 1 || env[0] != "EXTRA=1" {
+			t.Errorf("Expected only configured variables, got %v", env)
+		}
+	})
+
+	t.Run("allowlist", func(t *testing.T) {
+		env := buildEnv(LaunchSpec{EnvMode: EnvAllowlist, EnvAllowlist: []string{"HOME", "PATH"}}, parent)
+
+		if _, ok := envValue(env, "SECRET"); ok {
+			t.Error("SECRET should not be inherited in allowlist mode")
+		}
+		if _, ok := envValue(env, "HOME"); !ok {
+			t.Error("HOME should be inherited in allowlist mode")
+		}
+	})
+
+	t.Run("extra path", func(t *testing.T) {
+		env := buildEnv(LaunchSpec{ExtraPath: []string{"/opt/a", "/opt/b"}}, parent)
+
+		sep := string(os.PathListSeparator)
+		want := "/opt/a" + sep + "/opt/b" + sep + "/usr/bin"
+		if v, _ := envValue(env, "PATH"); v != want {
+			t.Errorf("Expected PATH %q, got %q", want, v)
+		}
+	})
+
+	t.Run("extra path in clean mode", func(t *testing.T) {
+		env := buildEnv(LaunchSpec{EnvMode: EnvClean, ExtraPath: []string{"/opt/a"}}, parent)
+
+		if v, _ := envValue(env, "PATH"); v != "/opt/a" {
+			t.Errorf("Expected PATH to contain only extra entries, got %q", v)
+		}
+	})
+}
+
+func TestLaunchSpec_Validate(t *testing.T) {
+	umask := uint32(0o1000)
+	tests := []struct {
+		name    string
+		spec    LaunchSpec
+		wantErr bool
+	}{
+		{"minimal", LaunchSpec{Command: "server"}, false},
+		{"missing command", LaunchSpec{}, true},
+		{"invalid env mode", LaunchSpec{Command: "server", EnvMode: "partial"}, true},
+		{"invalid stdin", LaunchSpec{Command: "server", Stdin: "tty"}, true},
+		{"invalid process group", LaunchSpec{Command: "server", ProcessGroup: "job"}, true},
+		{"invalid umask", LaunchSpec{Command: "server", Umask: &umask}, true},
+		{"missing working directory", LaunchSpec{Command: "server", Dir: "/nonexistent/dir"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStartWithOutput_LaunchSpec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Uses a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	umask := uint32(0o077)
+	spec := LaunchSpec{
+		Command: "sh",
+		Args:    []string{"-c", `read line; echo "$line $(pwd) $MCP_TEST $(umask)"`},
+		Dir:     dir,
+		Env:     map[string]string{"MCP_TEST": "value"},
+		EnvMode: EnvClean,
+		// A clean environment has no PATH, so sh must be found through ExtraPath
+		ExtraPath: []string{"/bin", "/usr/bin"},
+		Umask:     &umask,
+		Stdin:     StdinPipe,
+	}
+
+	pm := NewProcessManager()
+	proc, err := pm.StartWithOutput(spec)
+	if err != nil {
+		t.Fatalf("StartWithOutput failed: %v", err)
+	}
+	if proc.Stdin == nil {
+		t.Fatal("Expected a stdin pipe")
+	}
+
+	io.WriteString(proc.Stdin, "hello\n")
+	proc.Stdin.Close()
+
+	output, err := io.ReadAll(proc.Stdout)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	resolvedDir, _ := filepath.EvalSymlinks(dir)
+	fields := strings.Fields(string(output))
+	if len(fields) != 4 {
+		t.Fatalf("Unexpected output: %q", output)
+	}
+	if fields[0] != "hello" {
+		t.Errorf("Expected stdin to be delivered, got %q", fields[0])
+	}
+	if fields[1] != dir && fields[1] != resolvedDir {
+		t.Errorf("Expected working directory %s, got %s", dir, fields[1])
+	}
+	if fields[2] != "value" {
+		t.Errorf("Expected configured variable, got %q", fields[2])
+	}
+	if fields[3] != "0077" {
+		t.Errorf("Expected umask 0077, got %q", fields[3])
+	}
+}
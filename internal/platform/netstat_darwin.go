@@ -4,7 +4,6 @@
 package platform
 
 import (
-	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// followInterval is how often a followed file is checked for more output
+const followInterval = 100 * time.Millisecond
+
+// fileFollower reads a file that a process is still writing to, like tail -f
+// Reads wait for more output until the process has exited and everything it wrote was read
+type fileFollower struct {
+	file      *os.File
+	exited    <-chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// followFile returns a reader of file that reaches EOF only once exited is closed and the
+// rest of the file has been read, or once the reader is closed
+func followFile(file *os.File, exited <-chan struct{}) io.ReadCloser {
+	return &fileFollower{file: file, exited: exited, closed: make(chan struct{})}
+}
+
+// Read reads the next output, waiting for the process to write more if there is none
+func (f *fileFollower) Read(p []byte) (int, error) {
+	for {
+		n, err := f.file.Read(p)
+		if n > 0 || (err != nil && err != io.EOF) {
+			return n, err
+		}
+
+		select {
+		case <-f.closed:
+			return 0, io.EOF
+		case <-f.exited:
+			// Whatever the process wrote is in the file by now
+			if n, err = f.file.Read(p); n > 0 {
+				return n, nil
+			}
+			return 0, io.EOF
+		case <-time.After(followInterval):
+		}
+	}
+}
+
+// Close stops following the file; the process is not affected
+func (f *fileFollower) Close() error {
+	var err error
+	f.closeOnce.Do(func() {
+		close(f.closed)
+		err = f.file.Close()
+	})
+	return err
+}
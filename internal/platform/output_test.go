@@ -0,0 +1,86 @@
+package platform
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStartWithOutput_OutputFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Uses a POSIX shell")
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "server.log")
+	proc, err := NewProcessManager().StartWithOutput(LaunchSpec{
+		Command:    "sh",
+		Args:       []string{"-c", `echo started; echo warning >&2; sleep 0.3; echo more`},
+		OutputFile: outputFile,
+	})
+	if err != nil {
+		t.Fatalf("StartWithOutput failed: %v", err)
+	}
+	if proc.OutputFile != outputFile {
+		t.Errorf("Expected the output file to be recorded, got %q", proc.OutputFile)
+	}
+
+	// The follower keeps reading output written after it first reached the end of the file
+	output, err := io.ReadAll(proc.Stdout)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if string(output) != "started\nwarning\nmore\n" {
+		t.Errorf("Expected stdout and stderr in write order, got %q", output)
+	}
+	if exit := proc.ExitInfo(); exit == nil || exit.ExitCode != 0 {
+		t.Errorf("Expected the process to have exited cleanly once the output ended, got %+v", exit)
+	}
+}
+
+func TestStartWithOutput_OutputFileOutlivesManager(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Uses a POSIX shell")
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "server.log")
+	proc, err := NewProcessManager().StartWithOutput(LaunchSpec{
+		Command:    "sh",
+		Args:       []string{"-c", `echo started; sleep 0.3; echo after-release; echo error-after-release >&2`},
+		OutputFile: outputFile,
+	})
+	if err != nil {
+		t.Fatalf("StartWithOutput failed: %v", err)
+	}
+
+	line, err := bufio.NewReader(proc.Stdout).ReadString('\n')
+	if err != nil || line != "started\n" {
+		t.Fatalf("Expected the first line of output, got %q (%v)", line, err)
+	}
+
+	// The manager lets go of the process, as when it exits after detaching it
+	proc.Stdout.Close()
+	proc.Stderr.Close()
+
+	select {
+	case <-proc.Exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the process to finish")
+	}
+	// Writing to a pipe without a reader would have killed it with SIGPIPE
+	if proc.Exit.ExitCode != 0 || proc.Exit.Signal != "" {
+		t.Fatalf("Expected the process to keep writing after the manager let go, got %+v", proc.Exit)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "after-release\nerror-after-release\n") {
+		t.Errorf("Expected the output written after the release in the file, got %q", data)
+	}
+}
@@ -5,8 +5,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"runtime"
-	"syscall"
+	"strings"
 	"time"
 )
 
@@ -18,149 +17,341 @@ func NewProcessManager() ProcessManager {
 	return &DefaultProcessManager{}
 }
 
-// Start launches a new process with the given command, arguments, and environment
-func (pm *DefaultProcessManager) Start(cmd string, args []string, env map[string]string) (int, error) {
+// Start launches a new process described by spec
+// Output is discarded; use StartWithOutput to read it
+func (pm *DefaultProcessManager) Start(spec LaunchSpec) (int, error) {
 	// Create the command
-	command := exec.Command(cmd, args...)
-
-	// Set environment variables
-	if env != nil {
-		// Start with current environment
-		command.Env = os.Environ()
+	command, err := buildCommand(spec)
+	if err != nil {
+		return 0, err
+	}
 
-		// Add/override with provided env vars
-		for key, value := range env {
-			command.Env = append(command.Env, fmt.Sprintf("%s=%s", key, value))
-		}
+	// A pipe for stdin is only useful when the caller can reach it
+	if spec.Stdin == StdinPipe {
+		return 0, fmt.Errorf("stdin pipe requires StartWithOutput")
 	}
 
-	// Platform-specific process group settings
-	setProcAttributes(command)
+	release, err := placeInCgroup(command, spec.Cgroup)
+	if err != nil {
+		return 0, err
+	}
 
 	// Start the process
-	if err := command.Start(); err != nil {
+	err = command.Start()
+	release()
+	if err != nil {
 		return 0, fmt.Errorf("failed to start process: %w", err)
 	}
 
+	// Reap the process when it exits so it does not become a zombie
+	go command.Wait()
+
 	// Return the PID
 	return command.Process.Pid, nil
 }
 
-// StartWithOutput launches a new process and returns stdout/stderr readers
-func (pm *DefaultProcessManager) StartWithOutput(cmd string, args []string, env map[string]string) (int, io.ReadCloser, io.ReadCloser, error) {
+// StartWithOutput launches a new process described by spec and returns its stdio pipes
+func (pm *DefaultProcessManager) StartWithOutput(spec LaunchSpec) (*ManagedProcess, error) {
 	// Create the command
-	command := exec.Command(cmd, args...)
-
-	// Set environment variables
-	if env != nil {
-		// Start with current environment
-		command.Env = os.Environ()
+	command, err := buildCommand(spec)
+	if err != nil {
+		return nil, err
+	}
 
-		// Add/override with provided env vars
-		for key, value := range env {
-			command.Env = append(command.Env, fmt.Sprintf("%s=%s", key, value))
+	// Create a stdin pipe if requested (otherwise stdin is the null device)
+	var stdinPipe io.WriteCloser
+	if spec.Stdin == StdinPipe {
+		stdinPipe, err = command.StdinPipe()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
 		}
 	}
 
+	// An output file receives both streams, so the process keeps no pipe to the manager
+	// and can outlive it; it is followed for the output instead
+	if spec.OutputFile != "" {
+		return startWithOutputFile(command, spec, stdinPipe)
+	}
+
 	// Create pipes for stdout and stderr
-	stdoutPipe, err := command.StdoutPipe()
+	// os.Pipe rather than StdoutPipe: Wait closes StdoutPipe readers as soon as the
+	// process exits, which would drop output still buffered in the pipe
+	stdoutReader, stdoutWriter, err := os.Pipe()
 	if err != nil {
-		return 0, nil, nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
 
-	stderrPipe, err := command.StderrPipe()
+	stderrReader, stderrWriter, err := os.Pipe()
 	if err != nil {
-		return 0, nil, nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+		stdoutReader.Close()
+		stdoutWriter.Close()
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
+	command.Stdout = stdoutWriter
+	command.Stderr = stderrWriter
 
-	// Platform-specific process group settings
-	setProcAttributes(command)
+	release, err := placeInCgroup(command, spec.Cgroup)
+	if err != nil {
+		stdoutReader.Close()
+		stdoutWriter.Close()
+		stderrReader.Close()
+		stderrWriter.Close()
+		return nil, err
+	}
 
 	// Start the process
-	if err := command.Start(); err != nil {
-		return 0, nil, nil, fmt.Errorf("failed to start process: %w", err)
+	err = command.Start()
+	release()
+
+	// The child holds its own copies of the write ends; readers see EOF once it exits
+	stdoutWriter.Close()
+	stderrWriter.Close()
+
+	if err != nil {
+		stdoutReader.Close()
+		stderrReader.Close()
+		return nil, fmt.Errorf("failed to start process: %w", err)
+	}
+
+	proc := &ManagedProcess{
+		PID:    command.Process.Pid,
+		Stdin:  stdinPipe,
+		Stdout: stdoutReader,
+		Stderr: stderrReader,
 	}
 
-	// Return PID and pipes (StdoutPipe and StderrPipe already return io.ReadCloser)
-	return command.Process.Pid, stdoutPipe, stderrPipe, nil
+	// Keep a wait handle: reaps the process and records how it exited
+	proc.Exited = waitInBackground(command, &proc.Exit)
+
+	return proc, nil
 }
 
-// Stop terminates a process by its ID
-func (pm *DefaultProcessManager) Stop(pid int, graceful bool, timeout int) error {
-	fmt.Printf("[ProcessManager] Stop called: pid=%d, graceful=%v, timeout=%d\n", pid, graceful, timeout)
+// startWithOutputFile starts command with stdout and stderr going to spec.OutputFile
+// The process's Stdout follows the file until the process exits; its Stderr is empty
+func startWithOutputFile(command *exec.Cmd, spec LaunchSpec, stdinPipe io.WriteCloser) (*ManagedProcess, error) {
+	output, err := os.OpenFile(spec.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output file: %w", err)
+	}
+	// The child holds its own copy of the file
+	defer output.Close()
 
-	// Find the process
-	process, err := os.FindProcess(pid)
+	reader, err := os.Open(spec.OutputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output file: %w", err)
+	}
+	command.Stdout = output
+	command.Stderr = output
+
+	release, err := placeInCgroup(command, spec.Cgroup)
 	if err != nil {
-		fmt.Printf("[ProcessManager] os.FindProcess failed: pid=%d, error=%v\n", pid, err)
-		return fmt.Errorf("process not found: %w", err)
+		reader.Close()
+		return nil, err
+	}
+
+	// Start the process
+	err = command.Start()
+	release()
+	if err != nil {
+		reader.Close()
+		return nil, fmt.Errorf("failed to start process: %w", err)
+	}
+
+	proc := &ManagedProcess{
+		PID:        command.Process.Pid,
+		Stdin:      stdinPipe,
+		Stderr:     io.NopCloser(strings.NewReader("")),
+		OutputFile: spec.OutputFile,
+	}
+
+	// Keep a wait handle: reaps the process and records how it exited
+	proc.Exited = waitInBackground(command, &proc.Exit)
+	proc.Stdout = followFile(reader, proc.Exited)
+
+	return proc, nil
+}
+
+// killWait bounds how long Stop waits for force-killed processes to disappear
+const killWait = 2 * time.Second
+
+// Stop terminates a process and every process in its tree
+// Graceful stops signal the process group and any descendants that escaped it, wait up to
+// timeout seconds, then force kill whatever is left (including children forked meanwhile)
+// Returns a per-PID report of how each process ended
+func (pm *DefaultProcessManager) Stop(pid int, graceful bool, timeout int) (*StopReport, error) {
+	fmt.Printf("[ProcessManager] Stop called: pid=%d, graceful=%v, timeout=%d\n", pid, graceful, timeout)
+
+	report := &StopReport{PID: pid}
+	tree := pm.snapshotTree(pid)
+	if len(tree) == 0 {
+		fmt.Printf("[ProcessManager] Process not running: pid=%d\n", pid)
+		report.Processes = append(report.Processes, ProcessTermination{PID: pid, Outcome: OutcomeGone})
+		report.Stage = StageNotRunning
+		return report, nil
+	}
+
+	// Only signal the group when the root leads it; a discovered process may share
+	// its group with the client that spawned it
+	leader := false
+	for _, entry := range tree {
+		if entry.PGID == pid {
+			leader = true
+		}
 	}
-	fmt.Printf("[ProcessManager] Process found: pid=%d\n", pid)
+	fmt.Printf("[ProcessManager] Process tree found: pid=%d, processes=%d, groupLeader=%v\n", pid, len(tree), leader)
+
+	entries := make(map[int]procEntry)
+	results := make(map[int]*ProcessTermination)
+	var order []int
+	track := func(found []procEntry) {
+		for _, entry := range found {
+			if _, exists := results[entry.PID]; exists {
+				continue
+			}
+			entries[entry.PID] = entry
+			results[entry.PID] = &ProcessTermination{
+				PID:     entry.PID,
+				PPID:    entry.PPID,
+				Name:    entry.Name,
+				Escaped: leader && entry.PGID != pid,
+			}
+			order = append(order, entry.PID)
+		}
+	}
+	track(tree)
 
 	if graceful {
 		fmt.Printf("[ProcessManager] Attempting graceful shutdown: pid=%d\n", pid)
-		// Try graceful shutdown first
-		if err := pm.sendTermSignal(process); err != nil {
-			fmt.Printf("[ProcessManager] Graceful termination signal failed: pid=%d, error=%v\n", pid, err)
-			// If signal failed, process might already be dead
-			if !pm.IsRunning(pid) {
-				fmt.Printf("[ProcessManager] Process already dead after signal failure: pid=%d\n", pid)
-				return nil
+		pm.signalTree(pid, tree, leader, false, results)
+
+		remaining := pm.waitForExit(order, time.Duration(timeout)*time.Second)
+		for _, p := range order {
+			if !remaining[p] {
+				results[p].Outcome = OutcomeExited
 			}
-			// Signal failed but process still running, fall through to force kill
+		}
+		if len(remaining) == 0 {
+			fmt.Printf("[ProcessManager] Process tree exited gracefully: pid=%d\n", pid)
+			return pm.buildReport(report, order, results), nil
+		}
+
+		// Graceful shutdown timed out, fall through to force kill
+		fmt.Printf("[ProcessManager] Graceful shutdown timed out: pid=%d, remaining=%d\n", pid, len(remaining))
+
+		// Pick up children forked during shutdown
+		track(pm.snapshotTree(pid))
+	}
+
+	// Force kill everything that has not exited yet
+	var targets []procEntry
+	var targetPIDs []int
+	for _, p := range order {
+		if results[p].Outcome == "" {
+			targets = append(targets, entries[p])
+			targetPIDs = append(targetPIDs, p)
+		}
+	}
+	fmt.Printf("[ProcessManager] Attempting force kill: pid=%d, processes=%d\n", pid, len(targets))
+	pm.signalTree(pid, targets, leader, true, results)
+
+	remaining := pm.waitForExit(targetPIDs, killWait)
+	for _, p := range targetPIDs {
+		if remaining[p] {
+			results[p].Outcome = OutcomeSurvived
 		} else {
-			fmt.Printf("[ProcessManager] Graceful termination signal sent: pid=%d, waiting for exit...\n", pid)
-
-			// Wait for process to exit
-			done := make(chan bool)
-			go func() {
-				// Poll for process exit
-				for i := 0; i < timeout*10; i++ {
-					if !pm.IsRunning(pid) {
-						fmt.Printf("[ProcessManager] Process exited gracefully: pid=%d\n", pid)
-						done <- true
-						return
-					}
-					time.Sleep(100 * time.Millisecond)
-				}
-				fmt.Printf("[ProcessManager] Graceful shutdown timed out: pid=%d\n", pid)
-				done <- false
-			}()
-
-			if <-done {
-				// Process exited gracefully
-				return nil
+			results[p].Outcome = OutcomeKilled
+		}
+	}
+
+	pm.buildReport(report, order, results)
+	if survivors := report.Survivors(); len(survivors) > 0 {
+		fmt.Printf("[ProcessManager] Force kill failed: pid=%d, survivors=%v\n", pid, survivors)
+		return report, fmt.Errorf("failed to kill process tree of %d: still running: %v", pid, survivors)
+	}
+
+	fmt.Printf("[ProcessManager] Force kill succeeded: pid=%d\n", pid)
+	return report, nil
+}
+
+// buildReport fills the report in tracking order (parents before children)
+// The stage is taken from the root's outcome; a root missing from the tree had already exited
+func (pm *DefaultProcessManager) buildReport(report *StopReport, order []int, results map[int]*ProcessTermination) *StopReport {
+	report.Stage = StageNotRunning
+	for _, p := range order {
+		report.Processes = append(report.Processes, *results[p])
+		if p == report.PID {
+			report.Stage = stageFromOutcome(results[p].Outcome)
+		}
+	}
+	return report
+}
+
+// signalTree signals root's process group (if it leads one) and every listed process outside it
+func (pm *DefaultProcessManager) signalTree(root int, targets []procEntry, leader, force bool, results map[int]*ProcessTermination) {
+	if leader {
+		if err := signalGroup(root, force); err != nil {
+			fmt.Printf("[ProcessManager] Group signal failed: pgid=%d, force=%v, error=%v\n", root, force, err)
+			if result, exists := results[root]; exists {
+				result.Error = err.Error()
 			}
+		}
+	}
 
-			// Graceful shutdown timed out, fall through to force kill
-			fmt.Printf("[ProcessManager] Proceeding to force kill: pid=%d\n", pid)
+	// Children first, so a parent cannot react to a child's death by respawning it
+	for i := len(targets) - 1; i >= 0; i-- {
+		entry := targets[i]
+		if leader && entry.PGID == root {
+			// Already reached through the group
+			continue
+		}
+		if err := signalProcess(entry.PID, force); err != nil {
+			fmt.Printf("[ProcessManager] Signal failed: pid=%d, force=%v, error=%v\n", entry.PID, force, err)
+			results[entry.PID].Error = err.Error()
 		}
 	}
+}
 
-	// Force kill
-	fmt.Printf("[ProcessManager] Attempting force kill: pid=%d\n", pid)
-	if err := process.Kill(); err != nil {
-		fmt.Printf("[ProcessManager] Force kill failed: pid=%d, error=%v\n", pid, err)
-		// Check if process is actually dead
-		if !pm.IsRunning(pid) {
-			fmt.Printf("[ProcessManager] Process is dead despite kill error: pid=%d\n", pid)
-			return nil
+// snapshotTree returns the live process tree rooted at pid
+// Falls back to the root alone when the process table cannot be read
+func (pm *DefaultProcessManager) snapshotTree(pid int) []procEntry {
+	table, err := listProcessTable()
+	if err != nil {
+		fmt.Printf("[ProcessManager] Process table unavailable, stopping root only: pid=%d, error=%v\n", pid, err)
+		if pm.IsRunning(pid) {
+			return []procEntry{{PID: pid}}
 		}
-		return fmt.Errorf("failed to kill process %d: %w", pid, err)
+		return nil
 	}
+	return collectTree(pid, table)
+}
 
-	fmt.Printf("[ProcessManager] Force kill succeeded: pid=%d\n", pid)
-	return nil
+// waitForExit polls until none of the PIDs are alive or the timeout expires
+// Returns the PIDs still alive
+func (pm *DefaultProcessManager) waitForExit(pids []int, timeout time.Duration) map[int]bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		alive := pm.alive(pids)
+		if len(alive) == 0 || time.Now().After(deadline) {
+			return alive
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
 }
 
-// sendTermSignal sends a termination signal to the process
-func (pm *DefaultProcessManager) sendTermSignal(process *os.Process) error {
-	if runtime.GOOS == "windows" {
-		// Windows doesn't support SIGTERM, use Kill
-		return process.Kill()
+// alive returns which of the PIDs are still running (zombies count as exited)
+func (pm *DefaultProcessManager) alive(pids []int) map[int]bool {
+	table, err := listProcessTable()
+	if err == nil {
+		return alivePIDs(table, pids)
 	}
-	// Unix systems: send SIGTERM
-	return process.Signal(syscall.SIGTERM)
+
+	alive := make(map[int]bool)
+	for _, pid := range pids {
+		if pm.IsRunning(pid) {
+			alive[pid] = true
+		}
+	}
+	return alive
 }
 
 // IsRunning checks if a process with the given ID is currently running
@@ -170,13 +361,5 @@ func (pm *DefaultProcessManager) IsRunning(pid int) bool {
 		return false
 	}
 
-	// On Windows, we need to use platform-specific process checking
-	if runtime.GOOS == "windows" {
-		// Use Windows-specific process check
-		return isRunningWindows(pid)
-	}
-
-	// On Unix, sending signal 0 checks if process exists
-	err = process.Signal(syscall.Signal(0))
-	return err == nil
+	return processExists(process)
 }
@@ -3,13 +3,57 @@
 package platform
 
 import (
+	"fmt"
+	"os"
 	"os/exec"
 	"syscall"
 )
 
 // setProcAttributes sets Unix-specific process attributes
-func setProcAttributes(command *exec.Cmd) {
-	command.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
+func setProcAttributes(command *exec.Cmd, mode ProcessGroupMode) {
+	switch mode {
+	case ProcessGroupInherit:
+		// Stay in the manager's process group
+	case ProcessGroupSession:
+		command.SysProcAttr = &syscall.SysProcAttr{
+			Setsid: true,
+		}
+	default:
+		command.SysProcAttr = &syscall.SysProcAttr{
+			Setpgid: true,
+		}
 	}
 }
+
+// wrapUmask runs the command through /bin/sh to apply a umask
+// The umask is process-wide in Go, so it cannot be changed safely around fork;
+// the shell sets it and then execs the command, keeping the same PID
+func wrapUmask(path string, args []string, umask *uint32) (string, []string) {
+	if umask == nil {
+		return path, args
+	}
+
+	script := fmt.Sprintf(`umask %04o && exec "$0" "$@"`, *umask)
+	return "/bin/sh", append([]string{"-c", script, path}, args...)
+}
+
+// signalProcess sends SIGTERM, or SIGKILL if force is set, to a single process
+func signalProcess(pid int, force bool) error {
+	if force {
+		return syscall.Kill(pid, syscall.SIGKILL)
+	}
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
+
+// signalGroup sends SIGTERM, or SIGKILL if force is set, to every process in a group
+func signalGroup(pgid int, force bool) error {
+	if force {
+		return syscall.Kill(-pgid, syscall.SIGKILL)
+	}
+	return syscall.Kill(-pgid, syscall.SIGTERM)
+}
+
+// processExists checks if a process is running by sending it signal 0
+func processExists(process *os.Process) bool {
+	return process.Signal(syscall.Signal(0)) == nil
+}
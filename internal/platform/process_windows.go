@@ -4,6 +4,7 @@ package platform
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"syscall"
 	"unsafe"
@@ -20,12 +21,42 @@ const (
 )
 
 // setProcAttributes sets Windows-specific process attributes
-func setProcAttributes(command *exec.Cmd) {
+// Windows has no sessions for console processes, so ProcessGroupSession also gets a new group
+func setProcAttributes(command *exec.Cmd, mode ProcessGroupMode) {
+	if mode == ProcessGroupInherit {
+		return
+	}
 	command.SysProcAttr = &syscall.SysProcAttr{
 		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP,
 	}
 }
 
+// wrapUmask is a no-op on Windows, which has no umask
+func wrapUmask(path string, args []string, umask *uint32) (string, []string) {
+	return path, args
+}
+
+// signalProcess terminates a single process
+// Windows has no SIGTERM for console processes, so graceful and forced stops both kill
+func signalProcess(pid int, force bool) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Kill()
+}
+
+// signalGroup is a no-op on Windows, which has no process groups to signal;
+// the tree is stopped process by process instead
+func signalGroup(pgid int, force bool) error {
+	return nil
+}
+
+// processExists checks if a process is running, using the native Win32 API
+func processExists(process *os.Process) bool {
+	return isRunningWindows(process.Pid)
+}
+
 // isRunningWindows checks if a process is running on Windows using native Win32 API
 func isRunningWindows(pid int) bool {
 	fmt.Printf("[ProcessManager] IsRunning check for PID %d\n", pid)
@@ -0,0 +1,183 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TerminationOutcome describes how a single process in a stopped tree ended
+type TerminationOutcome string
+
+const (
+	OutcomeGone     TerminationOutcome = "gone"     // Already exited before it was signalled
+	OutcomeExited   TerminationOutcome = "exited"   // Exited after the graceful termination signal
+	OutcomeKilled   TerminationOutcome = "killed"   // Exited only after being force killed
+	OutcomeSurvived TerminationOutcome = "survived" // Still running after being force killed
+)
+
+// ProcessTermination records what happened to one process when stopping a tree
+type ProcessTermination struct {
+	PID     int                `json:"pid"`
+	PPID    int                `json:"ppid"`
+	Name    string             `json:"name,omitempty"`
+	Escaped bool               `json:"escaped,omitempty"` // Descendant that left the root's process group
+	Outcome TerminationOutcome `json:"outcome"`
+	Error   string             `json:"error,omitempty"` // Last signalling error, if any
+}
+
+// StopStage is the step of a staged shutdown that ended the root process
+type StopStage string
+
+const (
+	StageNotRunning StopStage = "not_running" // Root had already exited when the stop began
+	StageStdin      StopStage = "stdin"       // Root exited after its standard input was closed
+	StageTerminate  StopStage = "terminate"   // Root exited after the graceful termination signal
+	StageKill       StopStage = "kill"        // Root was force killed (or survived it, see Survivors)
+)
+
+// StopReport is the per-PID result of stopping a process tree
+type StopReport struct {
+	PID       int                  `json:"pid"` // Root process that was asked to stop
+	Stage     StopStage            `json:"stage,omitempty"`
+	Processes []ProcessTermination `json:"processes"`
+}
+
+// stageFromOutcome returns the stop stage matching how the root process ended
+func stageFromOutcome(outcome TerminationOutcome) StopStage {
+	switch outcome {
+	case OutcomeExited:
+		return StageTerminate
+	case OutcomeKilled, OutcomeSurvived:
+		return StageKill
+	default:
+		return StageNotRunning
+	}
+}
+
+// Survivors returns the PIDs that were still running after the force kill
+func (r *StopReport) Survivors() []int {
+	var pids []int
+	for _, p := range r.Processes {
+		if p.Outcome == OutcomeSurvived {
+			pids = append(pids, p.PID)
+		}
+	}
+	return pids
+}
+
+// Summary returns a one-line, human readable description of the report
+func (r *StopReport) Summary() string {
+	if r == nil || len(r.Processes) == 0 {
+		return "no processes found"
+	}
+
+	parts := make([]string, 0, len(r.Processes))
+	for _, p := range r.Processes {
+		name := ""
+		if p.Name != "" {
+			name = " " + p.Name
+		}
+		parts = append(parts, fmt.Sprintf("%d%s %s", p.PID, name, p.Outcome))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// procEntry is one row of the process table used to find a process tree
+type procEntry struct {
+	PID    int
+	PPID   int
+	PGID   int // 0 where process groups do not exist (Windows)
+	Name   string
+	Zombie bool // Exited but not yet reaped; treated as gone
+}
+
+// collectTree returns root and every process belonging to it: members of root's
+// process group (when root leads one) and all descendants by parent PID, including
+// those that moved to a different group or session
+// Entries are ordered parents before children
+func collectTree(root int, table []procEntry) []procEntry {
+	byPID := make(map[int]procEntry, len(table))
+	children := make(map[int][]int)
+	for _, entry := range table {
+		byPID[entry.PID] = entry
+		children[entry.PPID] = append(children[entry.PPID], entry.PID)
+	}
+
+	var tree []procEntry
+	seen := make(map[int]bool)
+	queue := []int{root}
+
+	// Group members survive their leader, so pick them up even if root is gone
+	for _, entry := range table {
+		if entry.PGID == root && entry.PID != root {
+			queue = append(queue, entry.PID)
+		}
+	}
+
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+
+		entry, exists := byPID[pid]
+		if !exists || entry.Zombie {
+			continue
+		}
+		tree = append(tree, entry)
+		queue = append(queue, children[pid]...)
+	}
+
+	return tree
+}
+
+// alivePIDs returns which of the given PIDs appear as live processes in the table
+func alivePIDs(table []procEntry, pids []int) map[int]bool {
+	wanted := make(map[int]bool, len(pids))
+	for _, pid := range pids {
+		wanted[pid] = true
+	}
+
+	alive := make(map[int]bool)
+	for _, entry := range table {
+		if wanted[entry.PID] && !entry.Zombie {
+			alive[entry.PID] = true
+		}
+	}
+	return alive
+}
+
+// ProcessName returns the name of a running process, or "" if it is not running
+// or the process table cannot be read
+func ProcessName(pid int) string {
+	table, err := listProcessTable()
+	if err != nil {
+		return ""
+	}
+	for _, entry := range table {
+		if entry.PID == pid && !entry.Zombie {
+			return entry.Name
+		}
+	}
+	return ""
+}
+
+// ParentProcess returns the parent PID and parent name of a running process
+// ok is false if the process is not running or the process table cannot be read
+func ParentProcess(pid int) (ppid int, name string, ok bool) {
+	table, err := listProcessTable()
+	if err != nil {
+		return 0, "", false
+	}
+
+	names := make(map[int]string, len(table))
+	for _, entry := range table {
+		names[entry.PID] = entry.Name
+		if entry.PID == pid && !entry.Zombie {
+			ppid, ok = entry.PPID, true
+		}
+	}
+	return ppid, names[ppid], ok
+}
@@ -0,0 +1,43 @@
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// listProcessTable reads pid, parent, process group and name of every process using ps
+// macOS has no /proc
+func listProcessTable() ([]procEntry, error) {
+	output, err := exec.Command("ps", "-axo", "pid=,ppid=,pgid=,stat=,comm=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run ps: %w", err)
+	}
+
+	var table []procEntry
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		pgid, err3 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+
+		table = append(table, procEntry{
+			PID:    pid,
+			PPID:   ppid,
+			PGID:   pgid,
+			Name:   filepath.Base(strings.Join(fields[4:], " ")),
+			Zombie: strings.HasPrefix(fields[3], "Z"),
+		})
+	}
+
+	return table, nil
+}
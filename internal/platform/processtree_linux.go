@@ -0,0 +1,70 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// listProcessTable reads pid, parent, process group and name of every process from /proc
+func listProcessTable() ([]procEntry, error) {
+	dirs, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read /proc: %w", err)
+	}
+
+	var table []procEntry
+	for _, dir := range dirs {
+		pid, err := strconv.Atoi(dir.Name())
+		if err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			// Process exited while we were scanning
+			continue
+		}
+
+		if entry, ok := parseProcStat(string(data)); ok {
+			table = append(table, entry)
+		}
+	}
+
+	return table, nil
+}
+
+// parseProcStat parses /proc/[pid]/stat: "pid (comm) state ppid pgrp ..."
+// comm may contain spaces and parentheses, so it is delimited by the last ')'
+func parseProcStat(stat string) (procEntry, bool) {
+	open := strings.IndexByte(stat, '(')
+	close := strings.LastIndexByte(stat, ')')
+	if open < 0 || close < open {
+		return procEntry{}, false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(stat[:open]))
+	if err != nil {
+		return procEntry{}, false
+	}
+
+	fields := strings.Fields(stat[close+1:])
+	if len(fields) < 3 {
+		return procEntry{}, false
+	}
+
+	ppid, err1 := strconv.Atoi(fields[1])
+	pgid, err2 := strconv.Atoi(fields[2])
+	if err1 != nil || err2 != nil {
+		return procEntry{}, false
+	}
+
+	return procEntry{
+		PID:    pid,
+		PPID:   ppid,
+		PGID:   pgid,
+		Name:   stat[open+1 : close],
+		Zombie: fields[0] == "Z" || fields[0] == "X",
+	}, true
+}
@@ -0,0 +1,100 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseProcStat(t *testing.T) {
+	entry, ok := parseProcStat("4242 (node (worker) x) S 4200 4100 4100 0 -1 4194560 0 0")
+	if !ok {
+		t.Fatal("Expected stat line to parse")
+	}
+	if entry.PID != 4242 || entry.PPID != 4200 || entry.PGID != 4100 {
+		t.Errorf("Unexpected ids: %+v", entry)
+	}
+	if entry.Name != "node (worker) x" {
+		t.Errorf("Expected name with parentheses, got %q", entry.Name)
+	}
+
+	zombie, _ := parseProcStat("10 (sh) Z 1 10 10")
+	if !zombie.Zombie {
+		t.Error("Expected Z state to be a zombie")
+	}
+}
+
+func TestParentProcess(t *testing.T) {
+	ppid, _, ok := ParentProcess(os.Getpid())
+	if !ok || ppid != os.Getppid() {
+		t.Errorf("Expected parent %d, got %d (ok=%v)", os.Getppid(), ppid, ok)
+	}
+
+	if name := ProcessName(os.Getpid()); name == "" {
+		t.Error("Expected the test process to have a name")
+	}
+}
+
+func TestStop_KillsProcessTree(t *testing.T) {
+	// The shell forks one child inside its process group and one that escapes
+	// into a new session, then prints their PIDs and ignores SIGTERM
+	script := `trap '' TERM; sleep 60 & echo $!; setsid sleep 60 & echo $!; wait`
+
+	pm := NewProcessManager()
+	proc, err := pm.StartWithOutput(LaunchSpec{Command: "/bin/sh", Args: []string{"-c", script}})
+	if err != nil {
+		t.Fatalf("StartWithOutput failed: %v", err)
+	}
+
+	var children []int
+	buf := make([]byte, 256)
+	deadline := time.Now().Add(5 * time.Second)
+	var output string
+	for len(children) < 2 && time.Now().Before(deadline) {
+		n, err := proc.Stdout.Read(buf)
+		output += string(buf[:n])
+		if err != nil {
+			break
+		}
+		children = children[:0]
+		for _, field := range strings.Fields(output) {
+			if pid, err := strconv.Atoi(field); err == nil {
+				children = append(children, pid)
+			}
+		}
+	}
+	if len(children) < 2 {
+		t.Fatalf("Expected two child PIDs, got output %q", output)
+	}
+
+	// Let setsid complete before stopping
+	time.Sleep(200 * time.Millisecond)
+
+	report, err := pm.Stop(proc.PID, true, 1)
+	if err != nil {
+		t.Fatalf("Stop failed: %v (report: %s)", err, report.Summary())
+	}
+
+	outcomes := map[int]TerminationOutcome{}
+	for _, p := range report.Processes {
+		outcomes[p.PID] = p.Outcome
+	}
+
+	// The shell ignores SIGTERM, so it needs SIGKILL
+	if outcomes[proc.PID] != OutcomeKilled || report.Stage != StageKill {
+		t.Errorf("Expected shell to be killed, report: %s (stage %s)", report.Summary(), report.Stage)
+	}
+	for _, child := range children {
+		if _, exists := outcomes[child]; !exists {
+			t.Errorf("Child %d missing from report: %s", child, report.Summary())
+		}
+		if alive := pm.(*DefaultProcessManager).alive([]int{child}); alive[child] {
+			t.Errorf("Child %d still running after stop", child)
+			exec.Command("kill", "-9", fmt.Sprint(child)).Run()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package platform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollectTree(t *testing.T) {
+	table := []procEntry{
+		{PID: 1, PPID: 0, PGID: 1, Name: "init"},
+		{PID: 100, PPID: 1, PGID: 100, Name: "npx"},
+		{PID: 101, PPID: 100, PGID: 100, Name: "node"},
+		{PID: 102, PPID: 101, PGID: 102, Name: "escaped"}, // left the group via setsid
+		{PID: 103, PPID: 1, PGID: 100, Name: "orphan"},    // group member reparented to init
+		{PID: 104, PPID: 100, PGID: 100, Name: "defunct", Zombie: true},
+		{PID: 200, PPID: 1, PGID: 200, Name: "unrelated"},
+	}
+
+	tree := collectTree(100, table)
+
+	got := map[int]bool{}
+	for _, entry := range tree {
+		got[entry.PID] = true
+	}
+	for _, pid := range []int{100, 101, 102, 103} {
+		if !got[pid] {
+			t.Errorf("Expected PID %d in tree", pid)
+		}
+	}
+	for _, pid := range []int{1, 104, 200} {
+		if got[pid] {
+			t.Errorf("PID %d should not be in tree", pid)
+		}
+	}
+	if tree[0].PID != 100 {
+		t.Errorf("Root should come first, got %d", tree[0].PID)
+	}
+}
+
+func TestCollectTree_RootNotLeader(t *testing.T) {
+	// A client-spawned server shares the client's group; only its own descendants count
+	table := []procEntry{
+		{PID: 50, PPID: 1, PGID: 50, Name: "client"},
+		{PID: 60, PPID: 50, PGID: 50, Name: "server"},
+		{PID: 61, PPID: 60, PGID: 50, Name: "server-child"},
+		{PID: 70, PPID: 50, PGID: 50, Name: "other-server"},
+	}
+
+	tree := collectTree(60, table)
+	if len(tree) != 2 || tree[0].PID != 60 || tree[1].PID != 61 {
+		t.Errorf("Expected only the server and its child, got %+v", tree)
+	}
+}
+
+func TestStopReport_Summary(t *testing.T) {
+	report := &StopReport{
+		PID: 100,
+		Processes: []ProcessTermination{
+			{PID: 100, Name: "npx", Outcome: OutcomeExited},
+			{PID: 101, Name: "node", Outcome: OutcomeKilled},
+			{PID: 102, Outcome: OutcomeSurvived},
+		},
+	}
+
+	summary := report.Summary()
+	for _, want := range []string{"100 npx exited", "101 node killed", "102 survived"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary %q should contain %q", summary, want)
+		}
+	}
+
+	if survivors := report.Survivors(); len(survivors) != 1 || survivors[0] != 102 {
+		t.Errorf("Expected survivor 102, got %v", survivors)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// listProcessTable reads pid, parent and executable name of every process
+// using a toolhelp snapshot; Windows has no process groups, so PGID is 0
+func listProcessTable() ([]procEntry, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create process snapshot: %w", err)
+	}
+	defer windows.CloseHandle(snapshot)
+
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+
+	if err := windows.Process32First(snapshot, &entry); err != nil {
+		return nil, fmt.Errorf("Process32First failed: %w", err)
+	}
+
+	var table []procEntry
+	for {
+		if entry.ProcessID != 0 {
+			table = append(table, procEntry{
+				PID:  int(entry.ProcessID),
+				PPID: int(entry.ParentProcessID),
+				Name: windows.UTF16ToString(entry.ExeFile[:]),
+			})
+		}
+
+		if err := windows.Process32Next(snapshot, &entry); err != nil {
+			break
+		}
+	}
+
+	return table, nil
+}
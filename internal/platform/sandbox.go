@@ -0,0 +1,29 @@
+package platform
+
+// sandboxEnv passes the sandbox rules from the manager to the sandbox helper
+// The helper is the manager's own executable; see runSandboxHelper
+const sandboxEnv = "MCPMANAGER_SANDBOX"
+
+// SandboxSpec confines a launched process (Linux only)
+// The process runs in new user and mount namespaces, and without Network in an empty
+// network namespace. Landlock limits the filesystem to the system directories, the
+// command's directory, the working directory and the listed paths
+type SandboxSpec struct {
+	ReadPaths  []string // Readable and executable
+	WritePaths []string // Readable, writable and executable
+	Network    bool     // Share the manager's network; false = no network at all
+}
+
+// sandboxReadPaths are readable by every sandboxed process, so that programs, their shared
+// libraries and system configuration load; entries missing on this system are skipped
+var sandboxReadPaths = []string{"/bin", "/sbin", "/usr", "/lib", "/lib32", "/lib64", "/etc", "/opt", "/nix/store", "/proc", "/sys", "/dev"}
+
+// sandboxWritePaths are writable by every sandboxed process
+var sandboxWritePaths = []string{"/dev/null", "/dev/zero", "/dev/full", "/dev/tty", "/dev/pts", "/dev/shm"}
+
+// sandboxRules is what the sandbox helper applies before it execs the command
+type sandboxRules struct {
+	ReadPaths  []string `json:"read"`
+	WritePaths []string `json:"write"`
+	PrivateTmp bool     `json:"privateTmp"` // Mount an empty tmpfs on /tmp
+}
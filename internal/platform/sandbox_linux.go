@@ -0,0 +1,303 @@
+package platform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// A sandboxed launch starts the manager's own executable in the new namespaces as a helper.
+// The helper applies the mount and Landlock rules to itself and then execs the command,
+// since Go cannot run code in the child between fork and exec
+func init() {
+	if encoded, ok := os.LookupEnv(sandboxEnv); ok {
+		runSandboxHelper(encoded)
+	}
+}
+
+// sandboxExitCode is the helper's exit code when it cannot run the command, as in shells
+const sandboxExitCode = 126
+
+// Landlock rights granted on read-only paths, and the subset that applies to a single file
+const (
+	landlockReadAccess = unix.LANDLOCK_ACCESS_FS_EXECUTE | unix.LANDLOCK_ACCESS_FS_READ_FILE | unix.LANDLOCK_ACCESS_FS_READ_DIR
+	landlockFileAccess = unix.LANDLOCK_ACCESS_FS_EXECUTE | unix.LANDLOCK_ACCESS_FS_WRITE_FILE | unix.LANDLOCK_ACCESS_FS_READ_FILE |
+		unix.LANDLOCK_ACCESS_FS_TRUNCATE | unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+)
+
+var sandboxSupport struct {
+	once      sync.Once
+	available bool
+	reason    string
+}
+
+// SandboxSupport reports whether processes can be sandboxed on this system, and if not, why
+func SandboxSupport() (bool, string) {
+	sandboxSupport.once.Do(func() {
+		sandboxSupport.available, sandboxSupport.reason = probeSandboxSupport()
+	})
+	return sandboxSupport.available, sandboxSupport.reason
+}
+
+// probeSandboxSupport checks for Landlock and for user namespaces the manager may create
+func probeSandboxSupport() (bool, string) {
+	if _, err := landlockABI(); err != nil {
+		switch err {
+		case unix.ENOSYS:
+			return false, "the kernel does not support Landlock"
+		case unix.EOPNOTSUPP:
+			return false, "Landlock is disabled (add it to the lsm= boot parameter)"
+		}
+		return false, fmt.Sprintf("Landlock is unavailable: %v", err)
+	}
+
+	if readSysctl("/proc/sys/user/max_user_namespaces") == "0" {
+		return false, "user namespaces are disabled (user.max_user_namespaces = 0)"
+	}
+	if os.Getuid() != 0 {
+		if readSysctl("/proc/sys/kernel/unprivileged_userns_clone") == "0" {
+			return false, "unprivileged user namespaces are disabled (kernel.unprivileged_userns_clone = 0)"
+		}
+		if readSysctl("/proc/sys/kernel/apparmor_restrict_unprivileged_userns") == "1" {
+			return false, "AppArmor restricts unprivileged user namespaces (kernel.apparmor_restrict_unprivileged_userns = 1)"
+		}
+	}
+	return true, ""
+}
+
+// readSysctl returns the trimmed content of a /proc/sys file, or "" if it cannot be read
+func readSysctl(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// applySandbox turns command into a launch of the sandbox helper for it
+// target is the command as configured, before any umask wrapper; its directory and the
+// working directory are readable so that the command can start
+func applySandbox(command *exec.Cmd, sandbox *SandboxSpec, target string) error {
+	if command.Err != nil {
+		return command.Err
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate sandbox helper: %w", err)
+	}
+
+	dir := command.Dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+
+	rules := sandboxRules{
+		ReadPaths:  append(append([]string{}, sandboxReadPaths...), sandbox.ReadPaths...),
+		WritePaths: append(append([]string{}, sandboxWritePaths...), sandbox.WritePaths...),
+	}
+	rules.ReadPaths = append(rules.ReadPaths, commandDirs(target, dir)...)
+	if dir != "" {
+		rules.ReadPaths = append(rules.ReadPaths, dir)
+	}
+
+	// A private /tmp would hide allowed paths below it
+	rules.PrivateTmp = true
+	for _, path := range append(append([]string{}, rules.ReadPaths...), rules.WritePaths...) {
+		if path == "/tmp" || strings.HasPrefix(path, "/tmp/") {
+			rules.PrivateTmp = false
+		}
+	}
+	if rules.PrivateTmp {
+		rules.WritePaths = append(rules.WritePaths, "/tmp")
+	}
+
+	encoded, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("failed to encode sandbox rules: %w", err)
+	}
+
+	// The helper runs as [helper, command path, command argv...]
+	command.Args = append([]string{self, command.Path}, command.Args...)
+	command.Path = self
+	command.Env = setEnv(command.Env, sandboxEnv, string(encoded))
+
+	if command.SysProcAttr == nil {
+		command.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	attr := command.SysProcAttr
+	attr.Cloneflags |= syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS
+	if !sandbox.Network {
+		// A new network namespace has only a loopback device, and it is down
+		attr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	// Map the manager's own IDs, so files keep their owners inside the sandbox
+	attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}}
+	attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}}
+	attr.GidMappingsEnableSetgroups = false
+
+	return nil
+}
+
+// commandDirs returns the directory of the command, and of the file it links to, if it can be found
+// Bare names are looked up in PATH the way exec.Command does; relative paths resolve against dir
+func commandDirs(target, dir string) []string {
+	if !strings.Contains(target, "/") {
+		path, err := exec.LookPath(target)
+		if err != nil {
+			return nil
+		}
+		target = path
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(dir, target)
+	}
+
+	dirs := []string{filepath.Dir(target)}
+	if resolved, err := filepath.EvalSymlinks(target); err == nil && filepath.Dir(resolved) != dirs[0] {
+		dirs = append(dirs, filepath.Dir(resolved))
+	}
+	return dirs
+}
+
+// runSandboxHelper confines the current process and execs the command; it never returns
+// os.Args is [helper, command path, command argv...]. no_new_privs and Landlock apply to
+// the calling thread, so the thread that confines itself must be the one that execs
+func runSandboxHelper(encoded string) {
+	runtime.LockOSThread()
+	os.Unsetenv(sandboxEnv)
+
+	var rules sandboxRules
+	if err := json.Unmarshal([]byte(encoded), &rules); err != nil {
+		sandboxExit(fmt.Errorf("invalid sandbox rules: %w", err))
+	}
+	if len(os.Args) < 3 {
+		sandboxExit(fmt.Errorf("no command to run"))
+	}
+	if err := rules.apply(); err != nil {
+		sandboxExit(err)
+	}
+
+	err := syscall.Exec(os.Args[1], os.Args[2:], os.Environ())
+	sandboxExit(fmt.Errorf("failed to run %s: %w", os.Args[1], err))
+}
+
+// sandboxExit reports why the helper could not run the command on the server's stderr
+func sandboxExit(err error) {
+	fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+	os.Exit(sandboxExitCode)
+}
+
+// apply sets up the mount namespace and restricts the filesystem
+func (r sandboxRules) apply() error {
+	// Mounts made in the sandbox must not propagate back to the manager's namespace
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %w", err)
+	}
+	if r.PrivateTmp {
+		if err := unix.Mount("tmpfs", "/tmp", "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=1777"); err != nil {
+			return fmt.Errorf("failed to mount private /tmp: %w", err)
+		}
+	}
+	return restrictFilesystem(r.ReadPaths, r.WritePaths)
+}
+
+// landlockABI returns the kernel's Landlock ABI version
+func landlockABI() (int, error) {
+	abi, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(abi), nil
+}
+
+// landlockHandledAccess returns every filesystem right a Landlock ABI version can restrict
+func landlockHandledAccess(abi int) uint64 {
+	// ABI 1 covers everything from execute up to make_sym
+	access := uint64(unix.LANDLOCK_ACCESS_FS_MAKE_SYM<<1 - 1)
+	if abi >= 2 {
+		access |= unix.LANDLOCK_ACCESS_FS_REFER
+	}
+	if abi >= 3 {
+		access |= unix.LANDLOCK_ACCESS_FS_TRUNCATE
+	}
+	if abi >= 5 {
+		access |= unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+	}
+	return access
+}
+
+// restrictFilesystem confines the calling thread to read access beneath readPaths and
+// full access beneath writePaths; everything else fails with EACCES
+func restrictFilesystem(readPaths, writePaths []string) error {
+	abi, err := landlockABI()
+	if err != nil {
+		return fmt.Errorf("landlock is unavailable: %w", err)
+	}
+	handled := landlockHandledAccess(abi)
+
+	// Only the filesystem field is set; the kernel accepts this prefix of newer versions of the struct
+	attr := unix.LandlockRulesetAttr{Access_fs: handled}
+	fd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr.Access_fs), 0)
+	if errno != 0 {
+		return fmt.Errorf("failed to create landlock ruleset: %w", errno)
+	}
+	ruleset := int(fd)
+	defer unix.Close(ruleset)
+
+	for _, path := range readPaths {
+		if err := landlockAllow(ruleset, path, handled&landlockReadAccess); err != nil {
+			return err
+		}
+	}
+	for _, path := range writePaths {
+		if err := landlockAllow(ruleset, path, handled); err != nil {
+			return err
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to set no_new_privs: %w", err)
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_RESTRICT_SELF, uintptr(ruleset), 0, 0); errno != 0 {
+		return fmt.Errorf("failed to enforce landlock ruleset: %w", errno)
+	}
+	return nil
+}
+
+// landlockAllow grants access beneath path; paths that do not exist are skipped
+func landlockAllow(ruleset int, path string, access uint64) error {
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if err != nil {
+		if errors.Is(err, unix.ENOENT) {
+			return nil
+		}
+		return fmt.Errorf("cannot open sandbox path %s: %w", path, err)
+	}
+	defer unix.Close(fd)
+
+	var stat unix.Stat_t
+	if err := unix.Fstat(fd, &stat); err != nil {
+		return fmt.Errorf("cannot stat sandbox path %s: %w", path, err)
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFDIR {
+		access &= landlockFileAccess
+	}
+
+	rule := unix.LandlockPathBeneathAttr{Allowed_access: access, Parent_fd: int32(fd)}
+	if _, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(ruleset), unix.LANDLOCK_RULE_PATH_BENEATH,
+		uintptr(unsafe.Pointer(&rule)), 0, 0, 0); errno != 0 {
+		return fmt.Errorf("failed to allow sandbox path %s: %w", path, errno)
+	}
+	return nil
+}
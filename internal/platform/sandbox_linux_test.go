@@ -0,0 +1,97 @@
+package platform
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runSandboxed runs a shell script in a sandbox and returns its combined output
+func runSandboxed(t *testing.T, dir string, sandbox *SandboxSpec, script string) string {
+	t.Helper()
+	if available, reason := SandboxSupport(); !available {
+		t.Skipf("Sandbox unavailable: %s", reason)
+	}
+
+	proc, err := NewProcessManager().StartWithOutput(LaunchSpec{
+		Command: "sh",
+		Args:    []string{"-c", script + " 2>&1"},
+		Dir:     dir,
+		Sandbox: sandbox,
+	})
+	if err != nil {
+		t.Fatalf("StartWithOutput failed: %v", err)
+	}
+	output, _ := io.ReadAll(proc.Stdout)
+	io.Copy(io.Discard, proc.Stderr)
+	<-proc.Exited
+	return string(output)
+}
+
+func TestSandbox_Filesystem(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"work", "data", "private"} {
+		os.Mkdir(filepath.Join(root, name), 0o755)
+	}
+	os.WriteFile(filepath.Join(root, "data", "input"), []byte("readable\n"), 0o644)
+	os.WriteFile(filepath.Join(root, "private", "secret"), []byte("secret\n"), 0o644)
+
+	output := runSandboxed(t, filepath.Join(root, "work"), &SandboxSpec{
+		ReadPaths:  []string{filepath.Join(root, "data")},
+		WritePaths: []string{filepath.Join(root, "work")},
+	}, `cat ../data/input
+echo out > result && echo wrote
+echo out > ../data/output || echo data-readonly
+cat ../private/secret || echo private-denied`)
+
+	for _, want := range []string{"readable", "wrote", "data-readonly", "private-denied"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "secret\n") {
+		t.Errorf("The sandbox read a path outside its allowlist:\n%s", output)
+	}
+	if _, err := os.Stat(filepath.Join(root, "work", "result")); err != nil {
+		t.Errorf("Expected the write to the allowed path to reach the host: %v", err)
+	}
+}
+
+func TestSandbox_NetworkAndTmp(t *testing.T) {
+	marker := filepath.Join("/tmp", "mcpmanager-sandbox-test")
+	os.WriteFile(marker, nil, 0o644)
+	defer os.Remove(marker)
+
+	// No allowed path below /tmp, so the sandbox gets an empty private /tmp
+	// The working directory is readable, so it must not depend on where the checkout lives
+	output := runSandboxed(t, "/", &SandboxSpec{}, `grep -o '^ *[a-z0-9]*:' /proc/net/dev; test -e `+marker+` && echo host-tmp; echo x > /tmp/scratch && echo tmp-writable`)
+
+	if devices := netDevices(output); len(devices) != 1 || devices[0] != "lo" {
+		t.Errorf("Expected only a loopback device without network, got:\n%s", output)
+	}
+	if strings.Contains(output, "host-tmp") {
+		t.Error("Expected the host's /tmp to be hidden")
+	}
+	if !strings.Contains(output, "tmp-writable") {
+		t.Errorf("Expected the private /tmp to be writable:\n%s", output)
+	}
+
+	output = runSandboxed(t, "/", &SandboxSpec{Network: true}, `cat /proc/net/dev`)
+	hostDevices, _ := os.ReadFile("/proc/net/dev")
+	if len(netDevices(output)) != len(netDevices(string(hostDevices))) {
+		t.Errorf("Expected the host's network devices with network enabled, got:\n%s", output)
+	}
+}
+
+// netDevices returns the interface names in /proc/net/dev output
+func netDevices(output string) []string {
+	var devices []string
+	for _, line := range strings.Split(output, "\n") {
+		if name, _, found := strings.Cut(line, ":"); found {
+			devices = append(devices, strings.TrimSpace(name))
+		}
+	}
+	return devices
+}
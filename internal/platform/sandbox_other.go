@@ -0,0 +1,18 @@
+//go:build !linux
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// SandboxSupport always reports sandboxing as unavailable outside Linux
+func SandboxSupport() (bool, string) {
+	return false, "sandboxing is only available on Linux"
+}
+
+// applySandbox fails outside Linux; LaunchSpec.Validate normally rejects the spec first
+func applySandbox(command *exec.Cmd, sandbox *SandboxSpec, target string) error {
+	return fmt.Errorf("sandboxing is only available on Linux")
+}
@@ -0,0 +1,123 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// ShellCommand renders a spec as a command that repeats the launch from a terminal
+// The environment is spelled out in full and the inherited one cleared, so the process sees
+// exactly the variables, arguments and working directory it would get from the manager.
+// POSIX sh syntax is used on Unix and PowerShell on Windows. Sandboxes and cgroups are
+// not reproduced
+func ShellCommand(spec LaunchSpec) (string, error) {
+	if spec.Command == "" {
+		return "", fmt.Errorf("launch command cannot be empty")
+	}
+
+	dir := spec.Dir
+	if dir == "" {
+		var err error
+		if dir, err = os.Getwd(); err != nil {
+			return "", fmt.Errorf("cannot determine working directory: %w", err)
+		}
+	}
+
+	command := shellCommand{
+		path:      resolveCommand(spec.Command, spec.ExtraPath),
+		args:      spec.Args,
+		dir:       dir,
+		env:       buildEnv(spec, os.Environ()),
+		umask:     spec.Umask,
+		sandboxed: spec.Sandbox != nil,
+	}
+	if runtime.GOOS == "windows" {
+		return command.powerShell(), nil
+	}
+	return command.posix(), nil
+}
+
+// shellCommand is a resolved launch to render
+type shellCommand struct {
+	path      string
+	args      []string
+	dir       string
+	env       []string
+	umask     *uint32
+	sandboxed bool
+}
+
+// sortedEnv returns the environment sorted by name, leaving out Windows' hidden drive variables
+func (c shellCommand) sortedEnv() []string {
+	env := make([]string, 0, len(c.env))
+	for _, entry := range c.env {
+		if !strings.HasPrefix(entry, "=") {
+			env = append(env, entry)
+		}
+	}
+	sort.Strings(env)
+	return env
+}
+
+// posix renders the command for sh, bash and zsh, one variable per line
+func (c shellCommand) posix() string {
+	var b strings.Builder
+	if c.sandboxed {
+		b.WriteString("# MCP Manager runs this server sandboxed; this command runs it unconfined\n")
+	}
+	fmt.Fprintf(&b, "cd %s && ", posixQuote(c.dir))
+	if c.umask != nil {
+		fmt.Fprintf(&b, "umask %04o && ", *c.umask)
+	}
+	b.WriteString("env -i \\\n")
+	for _, entry := range c.sortedEnv() {
+		fmt.Fprintf(&b, "  %s \\\n", posixQuote(entry))
+	}
+	b.WriteString("  ")
+	b.WriteString(posixQuote(c.path))
+	for _, arg := range c.args {
+		b.WriteString(" ")
+		b.WriteString(posixQuote(arg))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// powerShell renders the command for PowerShell, replacing the session's environment
+func (c shellCommand) powerShell() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Set-Location -LiteralPath %s\n", powerShellQuote(c.dir))
+	b.WriteString("Get-ChildItem Env: | Remove-Item\n")
+	for _, entry := range c.sortedEnv() {
+		key, value, _ := strings.Cut(entry, "=")
+		fmt.Fprintf(&b, "Set-Item -LiteralPath %s -Value %s\n", powerShellQuote("Env:"+key), powerShellQuote(value))
+	}
+	b.WriteString("& ")
+	b.WriteString(powerShellQuote(c.path))
+	for _, arg := range c.args {
+		b.WriteString(" ")
+		b.WriteString(powerShellQuote(arg))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// posixSafe matches words that need no quoting in sh
+var posixSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// posixQuote quotes a word for sh, leaving plain words as they are
+func posixQuote(s string) string {
+	if posixSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// powerShellQuote quotes a word as a PowerShell verbatim string
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
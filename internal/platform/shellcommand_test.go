@@ -0,0 +1,82 @@
+package platform
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestShellCommand_POSIX(t *testing.T) {
+	umask := uint32(0o027)
+	command := shellCommand{
+		path:      "/usr/bin/node",
+		args:      []string{"server.js", "--name", "it's mine"},
+		dir:       "/srv/my server",
+		env:       []string{"PATH=/usr/bin:/bin", "HOME=/home/user", "GREETING=hello world"},
+		umask:     &umask,
+		sandboxed: true,
+	}
+
+	want := "# MCP Manager runs this server sandboxed; this command runs it unconfined\n" +
+		"cd '/srv/my server' && umask 0027 && env -i \\\n" +
+		"  'GREETING=hello world' \\\n" +
+		"  HOME=/home/user \\\n" +
+		"  PATH=/usr/bin:/bin \\\n" +
+		"  /usr/bin/node server.js --name 'it'\\''s mine'\n"
+	if got := command.posix(); got != want {
+		t.Errorf("Unexpected command:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestShellCommand_PowerShell(t *testing.T) {
+	command := shellCommand{
+		path: `C:\Program Files\nodejs\node.exe`,
+		args: []string{"server.js", "it's"},
+		dir:  `C:\Users\me`,
+		env:  []string{"=C:=C:\\", "Path=C:\\Windows", "ProgramFiles(x86)=C:\\Program Files (x86)"},
+	}
+
+	want := "Set-Location -LiteralPath 'C:\\Users\\me'\n" +
+		"Get-ChildItem Env: | Remove-Item\n" +
+		"Set-Item -LiteralPath 'Env:Path' -Value 'C:\\Windows'\n" +
+		"Set-Item -LiteralPath 'Env:ProgramFiles(x86)' -Value 'C:\\Program Files (x86)'\n" +
+		"& 'C:\\Program Files\\nodejs\\node.exe' 'server.js' 'it''s'\n"
+	if got := command.powerShell(); got != want {
+		t.Errorf("Unexpected command:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestShellCommand_Runs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX shell required")
+	}
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	dir := t.TempDir()
+	script, err := ShellCommand(LaunchSpec{
+		Command: "/bin/sh",
+		Args:    []string{"-c", `printf '%s|%s|%s' "$PWD" "$GREETING" "$SECRET"`},
+		Dir:     dir,
+		Env:     map[string]string{"GREETING": "hello 'world'"},
+		EnvMode: EnvClean,
+	})
+	if err != nil {
+		t.Fatalf("ShellCommand failed: %v", err)
+	}
+
+	t.Setenv("SECRET", "leaked")
+	output, err := exec.Command(sh, "-c", script).Output()
+	if err != nil {
+		t.Fatalf("Running the command failed: %v\n%s", err, script)
+	}
+	resolved, _ := filepath.EvalSymlinks(dir)
+	parts := strings.Split(string(output), "|")
+	if len(parts) != 3 || (parts[0] != dir && parts[0] != resolved) || parts[1] != "hello 'world'" || parts[2] != "" {
+		t.Errorf("Expected the launch to be reproduced exactly, got %q", output)
+	}
+}
@@ -35,6 +35,22 @@ func (m *MockStorage) SaveServerLogs(serverID string, logs []models.LogEntry) er
 	return nil
 }
 
+func (m *MockStorage) AppendTransition(transition models.StateTransition) error {
+	return nil
+}
+
+func (m *MockStorage) LoadTransitions(serverID string) ([]models.StateTransition, error) {
+	return []models.StateTransition{}, nil
+}
+
+func (m *MockStorage) SaveDetachedServers(servers []models.DetachedServer) error {
+	return nil
+}
+
+func (m *MockStorage) LoadDetachedServers() ([]models.DetachedServer, error) {
+	return []models.DetachedServer{}, nil
+}
+
 func (m *MockStorage) GetSaveCount() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -1,10 +1,12 @@
 package storage
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/Positronikal/MCPManager/internal/models"
 	"github.com/Positronikal/MCPManager/internal/platform"
@@ -16,11 +18,16 @@ type StorageService interface {
 	SaveState(state *models.ApplicationState) error
 	LoadServerLogs(serverID string) ([]models.LogEntry, error)
 	SaveServerLogs(serverID string, logs []models.LogEntry) error
+	AppendTransition(transition models.StateTransition) error
+	LoadTransitions(serverID string) ([]models.StateTransition, error)
+	SaveDetachedServers(servers []models.DetachedServer) error
+	LoadDetachedServers() ([]models.DetachedServer, error)
 }
 
 // FileStorage implements StorageService using JSON files
 type FileStorage struct {
-	baseDir string
+	baseDir   string
+	journalMu sync.Mutex // serializes appends to transition journals
 }
 
 // NewFileStorage creates a new file storage instance
@@ -193,3 +200,122 @@ func (fs *FileStorage) SaveServerLogs(serverID string, logs []models.LogEntry) e
 
 	return nil
 }
+
+// AppendTransition appends a state transition to the server's journal
+// The journal is a JSON Lines file, so a crash mid-write loses at most the last record
+func (fs *FileStorage) AppendTransition(transition models.StateTransition) error {
+	if transition.ServerID == "" {
+		return fmt.Errorf("serverID cannot be empty")
+	}
+
+	serverDir := filepath.Join(fs.baseDir, "servers", transition.ServerID)
+	if err := fs.ensureDir(serverDir); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(transition)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transition: %w", err)
+	}
+
+	fs.journalMu.Lock()
+	defer fs.journalMu.Unlock()
+
+	file, err := os.OpenFile(filepath.Join(serverDir, "transitions.jsonl"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open transition journal: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to append transition: %w", err)
+	}
+
+	return nil
+}
+
+// LoadTransitions loads the journaled state transitions of a server, oldest first
+// Lines that cannot be parsed (e.g. a torn final write) are skipped
+func (fs *FileStorage) LoadTransitions(serverID string) ([]models.StateTransition, error) {
+	if serverID == "" {
+		return nil, fmt.Errorf("serverID cannot be empty")
+	}
+
+	journalFile := filepath.Join(fs.baseDir, "servers", serverID, "transitions.jsonl")
+
+	file, err := os.Open(journalFile)
+	if os.IsNotExist(err) {
+		return []models.StateTransition{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transition journal: %w", err)
+	}
+	defer file.Close()
+
+	transitions := []models.StateTransition{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var transition models.StateTransition
+		if err := json.Unmarshal(scanner.Bytes(), &transition); err != nil {
+			continue
+		}
+		transitions = append(transitions, transition)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read transition journal: %w", err)
+	}
+
+	return transitions, nil
+}
+
+// SaveDetachedServers writes the PID registry of detached servers atomically
+// An empty list removes the registry
+func (fs *FileStorage) SaveDetachedServers(servers []models.DetachedServer) error {
+	registryFile := filepath.Join(fs.baseDir, "detached.json")
+	if len(servers) == 0 {
+		if err := os.Remove(registryFile); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove PID registry: %w", err)
+		}
+		return nil
+	}
+
+	if err := fs.ensureDir(fs.baseDir); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(servers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal PID registry: %w", err)
+	}
+
+	tmpFile := registryFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := os.Rename(tmpFile, registryFile); err != nil {
+		_ = os.Remove(tmpFile)
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadDetachedServers reads the PID registry of detached servers
+// Returns an empty list if no servers were detached
+func (fs *FileStorage) LoadDetachedServers() ([]models.DetachedServer, error) {
+	data, err := os.ReadFile(filepath.Join(fs.baseDir, "detached.json"))
+	if os.IsNotExist(err) {
+		return []models.DetachedServer{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PID registry: %w", err)
+	}
+
+	var servers []models.DetachedServer
+	if err := json.Unmarshal(data, &servers); err != nil {
+		return nil, fmt.Errorf("failed to parse PID registry: %w", err)
+	}
+
+	return servers, nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Positronikal/MCPManager/internal/models"
 )
@@ -266,3 +267,90 @@ func TestFileStorage_LoadLogsEmptyServerID(t *testing.T) {
 		t.Error("Should not allow empty server ID")
 	}
 }
+
+func TestFileStorage_AppendAndLoadTransitions(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage := NewFileStorageWithPath(tmpDir)
+	serverID := "test-server-id"
+
+	pid := 4321
+	transitions := []models.StateTransition{
+		{ServerID: serverID, OldState: models.StatusStopped, NewState: models.StatusStarting, Reason: "Starting server", Timestamp: time.Now()},
+		{ServerID: serverID, OldState: models.StatusRunning, NewState: models.StatusError, Reason: "crashed", Timestamp: time.Now(),
+			PID: &pid, Exit: &models.ProcessExit{ExitCode: 1}},
+	}
+	for _, transition := range transitions {
+		if err := storage.AppendTransition(transition); err != nil {
+			t.Fatalf("Failed to append transition: %v", err)
+		}
+	}
+
+	// A torn final write must not make the journal unreadable
+	journalFile := filepath.Join(tmpDir, "servers", serverID, "transitions.jsonl")
+	file, err := os.OpenFile(journalFile, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("Journal file should exist: %v", err)
+	}
+	file.WriteString(`{"serverId":"test-ser`)
+	file.Close()
+
+	loaded, err := storage.LoadTransitions(serverID)
+	if err != nil {
+		t.Fatalf("Failed to load transitions: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("Expected 2 transitions, got %d", len(loaded))
+	}
+	if loaded[1].PID == nil || *loaded[1].PID != pid || loaded[1].Exit == nil || loaded[1].Exit.ExitCode != 1 {
+		t.Errorf("PID and exit info should round-trip, got %+v", loaded[1])
+	}
+}
+
+func TestFileStorage_LoadNonExistentTransitions(t *testing.T) {
+	storage := NewFileStorageWithPath(t.TempDir())
+
+	transitions, err := storage.LoadTransitions("nonexistent-server")
+	if err != nil {
+		t.Fatalf("Should not error on missing journal: %v", err)
+	}
+	if len(transitions) != 0 {
+		t.Error("Should return empty slice for missing journal")
+	}
+
+	if err := storage.AppendTransition(models.StateTransition{}); err == nil {
+		t.Error("Should not allow empty server ID")
+	}
+}
+
+func TestFileStorage_SaveAndLoadDetachedServers(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage := NewFileStorageWithPath(tmpDir)
+
+	loaded, err := storage.LoadDetachedServers()
+	if err != nil || len(loaded) != 0 {
+		t.Fatalf("Missing registry should load as empty, got %v, %v", loaded, err)
+	}
+
+	detached := []models.DetachedServer{
+		{ServerID: "server-1", Name: "one", PID: 1234, ProcessName: "node", RunningSince: time.Now().Add(-time.Hour), DetachedAt: time.Now()},
+	}
+	if err := storage.SaveDetachedServers(detached); err != nil {
+		t.Fatalf("Failed to save registry: %v", err)
+	}
+
+	loaded, err = storage.LoadDetachedServers()
+	if err != nil {
+		t.Fatalf("Failed to load registry: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].PID != 1234 || loaded[0].ProcessName != "node" {
+		t.Errorf("Registry should round-trip, got %+v", loaded)
+	}
+
+	// Saving an empty list removes the registry
+	if err := storage.SaveDetachedServers(nil); err != nil {
+		t.Fatalf("Failed to clear registry: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "detached.json")); !os.IsNotExist(err) {
+		t.Error("Registry file should be removed")
+	}
+}
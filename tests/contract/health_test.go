@@ -0,0 +1,79 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/api"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetServerHealth_ContractValidation tests GET /api/v1/servers/{serverId}/health
+func TestGetServerHealth_ContractValidation(t *testing.T) {
+	services, cleanup := setupFullTestServices(t)
+	defer cleanup()
+	router := api.NewRouter(services)
+
+	// Get a valid server ID for testing
+	listReq := httptest.NewRequest(http.MethodGet, "/api/v1/servers", nil)
+	listW := httptest.NewRecorder()
+	router.ServeHTTP(listW, listReq)
+
+	var serverList struct {
+		Servers []struct {
+			ID string `json:"id"`
+		} `json:"servers"`
+	}
+	json.NewDecoder(listW.Body).Decode(&serverList)
+
+	var validUUID string
+	if len(serverList.Servers) > 0 {
+		validUUID = serverList.Servers[0].ID
+	}
+
+	t.Run("should return 200 with health status", func(t *testing.T) {
+		if validUUID == "" {
+			t.Skip("No servers available for testing")
+		}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/servers/%s/health", validUUID), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Expected status 200 OK")
+
+		var response map[string]interface{}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err, "Response should be valid JSON")
+
+		assert.Equal(t, validUUID, response["serverId"], "serverId should match request")
+		validStates := []string{"unknown", "healthy", "unhealthy", "degraded"}
+		assert.Contains(t, validStates, response["state"], "state should be valid enum value")
+		assert.Contains(t, response, "consecutiveFailures", "consecutiveFailures should be present")
+	})
+
+	t.Run("should return 404 for non-existent server", func(t *testing.T) {
+		nonExistentUUID := uuid.New().String()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/servers/%s/health", nonExistentUUID), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected 404 for non-existent server")
+	})
+
+	t.Run("should return 404 for invalid UUID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/servers/not-a-uuid/health", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected 404 for invalid UUID")
+	})
+}
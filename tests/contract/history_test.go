@@ -0,0 +1,130 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/api"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetServerHistory_ContractValidation tests GET /api/v1/servers/{serverId}/history
+// and GET /api/v1/servers/{serverId}/availability
+func TestGetServerHistory_ContractValidation(t *testing.T) {
+	services, cleanup := setupFullTestServices(t)
+	defer cleanup()
+	router := api.NewRouter(services)
+
+	// Get a valid server ID for testing
+	listReq := httptest.NewRequest(http.MethodGet, "/api/v1/servers", nil)
+	listW := httptest.NewRecorder()
+	router.ServeHTTP(listW, listReq)
+
+	var serverList struct {
+		Servers []struct {
+			ID string `json:"id"`
+		} `json:"servers"`
+	}
+	json.NewDecoder(listW.Body).Decode(&serverList)
+
+	var validUUID string
+	if len(serverList.Servers) > 0 {
+		validUUID = serverList.Servers[0].ID
+	}
+
+	t.Run("should return 200 with transition history", func(t *testing.T) {
+		if validUUID == "" {
+			t.Skip("No servers available for testing")
+		}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/servers/%s/history?limit=10", validUUID), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Expected status 200 OK")
+
+		var response map[string]interface{}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err, "Response should be valid JSON")
+
+		assert.Contains(t, response, "transitions", "transitions should be present")
+		assert.Contains(t, response, "total", "total should be present")
+		transitions, ok := response["transitions"].([]interface{})
+		require.True(t, ok, "transitions should be an array")
+		assert.LessOrEqual(t, len(transitions), 10, "limit should be honored")
+	})
+
+	t.Run("should return 400 for invalid since", func(t *testing.T) {
+		if validUUID == "" {
+			t.Skip("No servers available for testing")
+		}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/servers/%s/history?since=yesterday", validUUID), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected 400 for invalid timestamp")
+	})
+
+	t.Run("should return 200 with availability report", func(t *testing.T) {
+		if validUUID == "" {
+			t.Skip("No servers available for testing")
+		}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/servers/%s/availability?days=3", validUUID), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Expected status 200 OK")
+
+		var response map[string]interface{}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err, "Response should be valid JSON")
+
+		assert.Equal(t, validUUID, response["serverId"], "serverId should match request")
+		for _, field := range []string{"uptimePercent", "mtbf", "crashes", "crashesPerDay"} {
+			assert.Contains(t, response, field, "%s should be present", field)
+		}
+	})
+
+	t.Run("should return 400 for out of range days", func(t *testing.T) {
+		if validUUID == "" {
+			t.Skip("No servers available for testing")
+		}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/servers/%s/availability?days=0", validUUID), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected 400 for invalid days")
+	})
+
+	t.Run("should return 404 for non-existent server", func(t *testing.T) {
+		for _, endpoint := range []string{"history", "availability"} {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/servers/%s/%s", uuid.New().String(), endpoint), nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code, "Expected 404 for non-existent server on %s", endpoint)
+		}
+	})
+
+	t.Run("should return 404 for invalid UUID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/servers/not-a-uuid/history", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected 404 for invalid UUID")
+	})
+}
@@ -0,0 +1,96 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Positronikal/MCPManager/internal/api"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostServersBatch_ContractValidation tests POST /api/v1/servers:batch
+func TestPostServersBatch_ContractValidation(t *testing.T) {
+	services, cleanup := setupFullTestServices(t)
+	defer cleanup()
+	router := api.NewRouter(services)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/servers:batch", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should return 200 with per-server results", func(t *testing.T) {
+		unknownID := uuid.New().String()
+		w := post(`{"action":"stop","serverIds":["` + unknownID + `"]}`)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Expected status 200 OK")
+
+		var response struct {
+			BatchID   string `json:"batchId"`
+			Action    string `json:"action"`
+			Succeeded int    `json:"succeeded"`
+			Failed    int    `json:"failed"`
+			Results   []struct {
+				ServerID string `json:"serverId"`
+				Success  bool   `json:"success"`
+				Error    string `json:"error"`
+			} `json:"results"`
+		}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err, "Response should be valid JSON")
+
+		_, err = uuid.Parse(response.BatchID)
+		assert.NoError(t, err, "batchId should be a valid UUID")
+		assert.Equal(t, "stop", response.Action)
+		assert.Equal(t, 0, response.Succeeded)
+		assert.Equal(t, 1, response.Failed)
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, unknownID, response.Results[0].ServerID)
+		assert.False(t, response.Results[0].Success)
+		assert.NotEmpty(t, response.Results[0].Error, "failed results should explain why")
+	})
+
+	t.Run("should accept a selector", func(t *testing.T) {
+		w := post(`{"action":"restart","selector":"error"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Expected status 200 OK")
+
+		var response struct {
+			Results []json.RawMessage `json:"results"`
+		}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err, "Response should be valid JSON")
+		assert.NotNil(t, response.Results, "results should be an array")
+	})
+
+	t.Run("should return 400 for invalid requests", func(t *testing.T) {
+		bodies := map[string]string{
+			"malformed body":   `{"action":`,
+			"invalid action":   `{"action":"pause","selector":"running"}`,
+			"no servers":       `{"action":"start"}`,
+			"invalid selector": `{"action":"start","selector":"stopped"}`,
+			"invalid UUID":     `{"action":"start","serverIds":["not-a-uuid"]}`,
+			"ids and selector": `{"action":"start","serverIds":["` + uuid.New().String() + `"],"selector":"running"}`,
+		}
+
+		for name, body := range bodies {
+			w := post(body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, "%s should be rejected", name)
+
+			var errorResponse struct {
+				Error string `json:"error"`
+			}
+			err := json.NewDecoder(w.Body).Decode(&errorResponse)
+			require.NoError(t, err, "Error response should be valid JSON")
+			assert.NotEmpty(t, errorResponse.Error, "%s should explain the error", name)
+		}
+	})
+}
@@ -139,3 +139,32 @@ func TestPostServersDiscover_ErrorHandling(t *testing.T) {
 		}
 	})
 }
+
+// TestGetServersDiscover_ContractValidation tests GET /api/v1/servers/discover endpoint
+func TestGetServersDiscover_ContractValidation(t *testing.T) {
+	services := createTestRouter()
+	router := api.NewRouter(services)
+	defer services.EventBus.Close()
+
+	t.Run("should return the report of the last scan", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/servers/discover", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Expected status 200 OK")
+
+		var response struct {
+			ScanID  string `json:"scanId"`
+			Sources []struct {
+				Source  string `json:"source"`
+				Enabled bool   `json:"enabled"`
+			} `json:"sources"`
+		}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err, "Response should be valid JSON")
+
+		assert.NotEmpty(t, response.ScanID, "scanId field should be present and not empty")
+		assert.NotEmpty(t, response.Sources, "sources should list every discovery source")
+	})
+}
@@ -224,7 +224,7 @@ func TestGetServerById_ContractValidation(t *testing.T) {
 		if err == nil && w.Code == http.StatusOK {
 			// Validate source is valid enum
 			if source, ok := server["source"].(string); ok {
-				validSources := []string{"client_config", "filesystem", "process"}
+				validSources := []string{"client_config", "claude_code", "codex", "vscode", "filesystem", "process"}
 				assert.Contains(t, validSources, source, "Source should be valid enum value")
 			}
 		}
@@ -80,7 +80,7 @@ func TestGetServers_ContractValidation(t *testing.T) {
 		assert.Equal(t, "client_config", sourceParam, "source query parameter should be 'client_config'")
 
 		// Valid source values per api-spec.yaml
-		validSources := []string{"client_config", "extension", "filesystem", "process"}
+		validSources := []string{"client_config", "claude_code", "codex", "vscode", "extension", "filesystem", "process"}
 		assert.Contains(t, validSources, sourceParam, "source should be one of the valid enum values")
 	})
 
@@ -165,7 +165,7 @@ func TestGetServers_SchemaValidation(t *testing.T) {
 
 			// Validate source is valid enum
 			if source, ok := server["source"].(string); ok {
-				validSources := []string{"client_config", "extension", "filesystem", "process"}
+				validSources := []string{"client_config", "claude_code", "codex", "vscode", "extension", "filesystem", "process"}
 				assert.Contains(t, validSources, source, "Source should be valid enum value")
 			}
 		}
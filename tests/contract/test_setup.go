@@ -8,6 +8,7 @@ import (
 	"github.com/Positronikal/MCPManager/internal/core/dependencies"
 	"github.com/Positronikal/MCPManager/internal/core/discovery"
 	"github.com/Positronikal/MCPManager/internal/core/events"
+	"github.com/Positronikal/MCPManager/internal/core/health"
 	"github.com/Positronikal/MCPManager/internal/core/lifecycle"
 	"github.com/Positronikal/MCPManager/internal/core/monitoring"
 	"github.com/Positronikal/MCPManager/internal/platform"
@@ -33,6 +34,7 @@ func setupFullTestServices(t *testing.T) (*api.Services, func()) {
 	}
 
 	metricsCollector := monitoring.NewMetricsCollector(processInfo, eventBus)
+	healthChecker := health.NewHealthChecker(discoveryService, lifecycleService, eventBus)
 	dependencyService := dependencies.NewDependencyService()
 	updateChecker := dependencies.NewUpdateChecker()
 
@@ -47,6 +49,7 @@ func setupFullTestServices(t *testing.T) (*api.Services, func()) {
 		ConfigService:     configService,
 		MonitoringService: monitoringService,
 		MetricsCollector:  metricsCollector,
+		HealthChecker:     healthChecker,
 		DependencyService: dependencyService,
 		UpdateChecker:     updateChecker,
 		StorageService:    storageService,
@@ -54,6 +57,7 @@ func setupFullTestServices(t *testing.T) (*api.Services, func()) {
 	}
 
 	cleanup := func() {
+		healthChecker.Stop()
 		lifecycleService.StopAll()
 		discoveryService.Close()
 		eventBus.Close()
@@ -113,18 +113,7 @@ func TestLifecycleFlow_StartAndStop(t *testing.T) {
 	}
 
 	// Wait for status to transition to running (may take a moment)
-	deadline := time.Now().Add(5 * time.Second)
-	for time.Now().Before(deadline) {
-		if server.Status.State == models.StatusRunning {
-			break
-		}
-		time.Sleep(100 * time.Millisecond)
-	}
-
-	// Verify server transitioned to running
-	if server.Status.State != models.StatusRunning {
-		t.Errorf("Expected state 'running', got '%s'", server.Status.State)
-	}
+	eventCount := waitForRunning(t, statusEvents, 5*time.Second, server.ID)
 
 	// Verify PID was captured
 	if server.PID == nil {
@@ -160,7 +149,6 @@ func TestLifecycleFlow_StartAndStop(t *testing.T) {
 	}
 
 	// Verify status change events were published
-	eventCount := 0
 	timeout := time.After(1 * time.Second)
 
 EventLoop:
@@ -219,6 +207,40 @@ EventLoop:
 	t.Log("Lifecycle test completed successfully")
 }
 
+// waitForRunning waits until each of the servers has reported the running state and returns
+// the number of status change events received meanwhile
+// Without a discovery cache, a server becoming ready is only observable through its event
+func waitForRunning(t *testing.T, statusEvents <-chan *events.Event, timeout time.Duration, serverIDs ...string) int {
+	t.Helper()
+
+	pending := make(map[string]bool)
+	for _, id := range serverIDs {
+		pending[id] = true
+	}
+
+	eventCount := 0
+	deadline := time.After(timeout)
+	for len(pending) > 0 {
+		select {
+		case event := <-statusEvents:
+			if event == nil {
+				continue
+			}
+			eventCount++
+			t.Logf("Received status change event: %v -> %v", event.Data["oldState"], event.Data["newState"])
+			if event.Data["newState"] == models.StatusRunning {
+				if id, ok := event.Data["serverID"].(string); ok {
+					delete(pending, id)
+				}
+			}
+		case <-deadline:
+			t.Errorf("Expected state 'running' for %v", pending)
+			return eventCount
+		}
+	}
+	return eventCount
+}
+
 func TestLifecycleFlow_InvalidCommand(t *testing.T) {
 	// Skip if running in short mode
 	if testing.Short() {
@@ -319,6 +341,8 @@ func TestLifecycleFlow_MultipleServers(t *testing.T) {
 	eventBus := events.NewEventBus()
 	defer eventBus.Close()
 
+	statusEvents := eventBus.Subscribe(events.EventServerStatusChanged)
+
 	processManager := platform.NewProcessManager()
 	lifecycleService := lifecycle.NewLifecycleService(processManager, nil, nil, eventBus)
 
@@ -339,13 +363,9 @@ func TestLifecycleFlow_MultipleServers(t *testing.T) {
 		}
 	}
 
-	time.Sleep(1 * time.Second)
-
 	// Verify both are running
+	waitForRunning(t, statusEvents, 5*time.Second, servers[0].ID, servers[1].ID)
 	for i, server := range servers {
-		if server.Status.State != models.StatusRunning {
-			t.Errorf("Server %d: expected state 'running', got '%s'", i+1, server.Status.State)
-		}
 		if server.PID == nil {
 			t.Errorf("Server %d: expected PID to be set", i+1)
 		}